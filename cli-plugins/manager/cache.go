@@ -0,0 +1,247 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-v23/pkg/ioutils"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// metadataCacheFileName is the name of the file, in the configuration
+	// directory, that caches the metadata of plugin candidates.
+	metadataCacheFileName = "cli-plugins-metadata.json"
+
+	// metadataCacheVersion is the version of the format of the metadata
+	// cache. A cache with another version is discarded.
+	metadataCacheVersion = 1
+)
+
+// metadataCacheEntry is the cached metadata of a plugin candidate, which is
+// valid as long as the size and modification time of the candidate do not
+// change.
+//
+// Failed entries record candidates whose metadata could not be fetched, or is
+// not valid JSON. They are not used when listing plugins, so that the error is
+// reported, but they are treated as declaring no hooks, so that such
+// candidates are not executed by every command (see HookPlugins).
+type metadataCacheEntry struct {
+	Size     int64           `json:"size"`
+	ModTime  time.Time       `json:"modTime"`
+	Metadata json.RawMessage `json:"metadata,omitempty"`
+	Failed   bool            `json:"failed,omitempty"`
+}
+
+type metadataCacheFile struct {
+	Version int                           `json:"version"`
+	Entries map[string]metadataCacheEntry `json:"entries"`
+}
+
+// metadataCache caches the metadata of plugin candidates on disk, keyed by
+// the path of the candidate, so that candidates do not have to be executed
+// to get their metadata each time plugins are listed.
+type metadataCache struct {
+	path string
+
+	mu      sync.Mutex
+	entries map[string]metadataCacheEntry
+	used    map[string]bool
+	dirty   bool
+}
+
+// loadMetadataCache loads the metadata cache from the configuration
+// directory. A missing, unreadable, or outdated cache results in an empty
+// cache.
+func loadMetadataCache() *metadataCache {
+	c := &metadataCache{
+		entries: map[string]metadataCacheEntry{},
+		used:    map[string]bool{},
+	}
+	path, err := config.Path(metadataCacheFileName)
+	if err != nil {
+		logrus.WithError(err).Debug("plugin metadata cache disabled")
+		return c
+	}
+	c.path = path
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.WithError(err).Debug("failed to read plugin metadata cache")
+		}
+		return c
+	}
+	var f metadataCacheFile
+	if err := json.Unmarshal(data, &f); err != nil || f.Version != metadataCacheVersion {
+		logrus.Debug("discarding invalid or outdated plugin metadata cache")
+		c.dirty = true
+		return c
+	}
+	if f.Entries != nil {
+		c.entries = f.Entries
+	}
+	return c
+}
+
+// candidate returns a Candidate for the plugin at path, which uses the
+// cached metadata of the plugin if it is up to date.
+func (c *metadataCache) candidate(path string) Candidate {
+	return &cachedCandidate{Candidate: &candidate{path: path}, cache: c}
+}
+
+// lookup returns the cache entry of the candidate at path, if its size and
+// modification time match those in the cache.
+func (c *metadataCache) lookup(path string, fi os.FileInfo) (metadataCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.used[path] = true
+	e, ok := c.entries[path]
+	if !ok || e.Size != fi.Size() || !e.ModTime.Equal(fi.ModTime()) {
+		return metadataCacheEntry{}, false
+	}
+	return e, true
+}
+
+// get returns the cached metadata of the candidate at path, if it is up to
+// date, and was fetched successfully.
+func (c *metadataCache) get(path string, fi os.FileInfo) ([]byte, bool) {
+	e, ok := c.lookup(path, fi)
+	if !ok || e.Failed {
+		return nil, false
+	}
+	return e.Metadata, true
+}
+
+// declaresHooks returns whether the cached metadata of the candidate at path
+// declares hooks. ok is false if the metadata is not cached, or outdated.
+// Candidates that cannot be stat'ed, such as broken symlinks, and candidates
+// whose metadata could not be fetched or decoded, declare no hooks.
+func (c *metadataCache) declaresHooks(path string) (hasHooks bool, ok bool) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return false, true
+	}
+	e, ok := c.lookup(path, fi)
+	if !ok {
+		return false, false
+	}
+	if e.Failed {
+		return false, true
+	}
+	var m struct{ Hooks []Hook }
+	if err := json.Unmarshal(e.Metadata, &m); err != nil {
+		return false, true
+	}
+	return len(m.Hooks) > 0, true
+}
+
+func (c *metadataCache) set(path string, fi os.FileInfo, meta []byte) {
+	if !json.Valid(meta) {
+		// Invalid metadata is reported as such by newPlugin, and could not
+		// be stored as a json.RawMessage.
+		c.setFailed(path, fi)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.used[path] = true
+	c.entries[path] = metadataCacheEntry{Size: fi.Size(), ModTime: fi.ModTime(), Metadata: meta}
+	c.dirty = true
+}
+
+// setFailed records that the metadata of the candidate at path could not be
+// fetched, or is invalid.
+func (c *metadataCache) setFailed(path string, fi os.FileInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.used[path] = true
+	if e, ok := c.entries[path]; ok && e.Failed && e.Size == fi.Size() && e.ModTime.Equal(fi.ModTime()) {
+		return
+	}
+	c.entries[path] = metadataCacheEntry{Size: fi.Size(), ModTime: fi.ModTime(), Failed: true}
+	c.dirty = true
+}
+
+// prune removes the entries of candidates that were not looked up since the
+// cache was loaded, such as plugins that were removed.
+func (c *metadataCache) prune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for path := range c.entries {
+		if !c.used[path] {
+			delete(c.entries, path)
+			c.dirty = true
+		}
+	}
+}
+
+// save writes the cache to disk if it changed. Failing to write the cache is
+// not an error, as it only affects performance.
+func (c *metadataCache) save() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dirty || c.path == "" {
+		return
+	}
+	data, err := json.Marshal(metadataCacheFile{Version: metadataCacheVersion, Entries: c.entries})
+	if err != nil {
+		logrus.WithError(err).Debug("failed to encode plugin metadata cache")
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o700); err != nil {
+		logrus.WithError(err).Debug("failed to write plugin metadata cache")
+		return
+	}
+	if err := ioutils.AtomicWriteFile(c.path, data, 0o644); err != nil {
+		logrus.WithError(err).Debug("failed to write plugin metadata cache")
+		return
+	}
+	c.dirty = false
+}
+
+// cachedCandidate is a Candidate that gets its metadata from the metadata
+// cache, and only executes the candidate if the cached metadata is missing
+// or outdated.
+type cachedCandidate struct {
+	Candidate
+	cache *metadataCache
+}
+
+func (c *cachedCandidate) Metadata() ([]byte, error) {
+	path := c.Path()
+	fi, err := os.Stat(path)
+	if err != nil {
+		return c.Candidate.Metadata()
+	}
+	if meta, ok := c.cache.get(path, fi); ok {
+		return meta, nil
+	}
+	meta, err := c.Candidate.Metadata()
+	if err != nil {
+		c.cache.setFailed(path, fi)
+		return nil, err
+	}
+	c.cache.set(path, fi, meta)
+	return meta, nil
+}
+
+// RefreshMetadataCache discards the cached metadata of all plugins, and
+// rebuilds the cache by executing each plugin candidate. It returns the
+// plugins found.
+func RefreshMetadataCache(dockerCli command.Cli, rootcmd *cobra.Command) ([]Plugin, error) {
+	path, err := config.Path(metadataCacheFileName)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return ListPlugins(dockerCli, rootcmd)
+}
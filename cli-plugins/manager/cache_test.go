@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+type countingCandidate struct {
+	fakeCandidate
+	calls int
+}
+
+func (c *countingCandidate) Metadata() ([]byte, error) {
+	c.calls++
+	return c.fakeCandidate.Metadata()
+}
+
+func TestMetadataCache(t *testing.T) {
+	config.SetDir(t.TempDir())
+	dir := fs.NewDir(t, t.Name(), fs.WithFile("docker-aaa", "binary"))
+	path := dir.Join("docker-aaa")
+	const meta = `{"SchemaVersion":"0.1.0","Vendor":"e2e-testing"}`
+
+	c := &countingCandidate{fakeCandidate: fakeCandidate{path: path, exec: true, meta: meta}}
+	cache := loadMetadataCache()
+	actual, err := (&cachedCandidate{Candidate: c, cache: cache}).Metadata()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(actual), meta))
+	assert.Check(t, is.Equal(c.calls, 1))
+	cache.save()
+
+	// the metadata is read from the cache
+	cache = loadMetadataCache()
+	actual, err = (&cachedCandidate{Candidate: c, cache: cache}).Metadata()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(actual), meta))
+	assert.Check(t, is.Equal(c.calls, 1))
+
+	// the cache is invalidated when the plugin changes
+	assert.NilError(t, os.WriteFile(path, []byte("new binary"), 0o644))
+	cache = loadMetadataCache()
+	_, err = (&cachedCandidate{Candidate: c, cache: cache}).Metadata()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(c.calls, 2))
+
+	// failures are retried, so that they are reported, but are recorded as
+	// declaring no hooks
+	c.exec = false
+	assert.NilError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Hour)))
+	cache = loadMetadataCache()
+	hasHooks, ok := cache.declaresHooks(path)
+	assert.Check(t, !hasHooks && !ok)
+	_, err = (&cachedCandidate{Candidate: c, cache: cache}).Metadata()
+	assert.Check(t, is.ErrorContains(err, "faked a failure to exec"))
+	cache.save()
+	cache = loadMetadataCache()
+	hasHooks, ok = cache.declaresHooks(path)
+	assert.Check(t, !hasHooks && ok)
+	_, err = (&cachedCandidate{Candidate: c, cache: cache}).Metadata()
+	assert.Check(t, is.ErrorContains(err, "faked a failure to exec"))
+	assert.Check(t, is.Equal(c.calls, 4))
+
+	// candidates that cannot be stat'ed declare no hooks
+	hasHooks, ok = cache.declaresHooks(dir.Join("docker-missing"))
+	assert.Check(t, !hasHooks && ok)
+}
+
+func TestListPluginsUsesMetadataCache(t *testing.T) {
+	configDir := t.TempDir()
+	config.SetDir(configDir)
+	dir := fs.NewDir(t, t.Name(),
+		fs.WithFile("docker-aaa", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing","Version":"v1"}'`, fs.WithMode(0o777)),
+		fs.WithFile("docker-bbb", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing"}'`, fs.WithMode(0o777)),
+	)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(&configfile.ConfigFile{CLIPluginsExtraDirs: []string{dir.Path()}})
+
+	// other plugins may be installed on the system, so only look at ours
+	isCached := func(name string) bool {
+		_, ok := loadMetadataCache().entries[dir.Join(name)]
+		return ok
+	}
+	findPlugin := func(plugins []Plugin, name string) *Plugin {
+		for _, p := range plugins {
+			if p.Name == name {
+				return &p
+			}
+		}
+		return nil
+	}
+
+	_, err := ListPlugins(cli, &cobra.Command{})
+	assert.NilError(t, err)
+	assert.Check(t, isCached("docker-aaa"))
+	assert.Check(t, isCached("docker-bbb"))
+	assert.Check(t, is.Contains(string(loadMetadataCache().entries[dir.Join("docker-aaa")].Metadata), `"Version":"v1"`))
+
+	// removed plugins are removed from the cache
+	assert.NilError(t, os.Remove(dir.Join("docker-bbb")))
+	_, err = ListPlugins(cli, &cobra.Command{})
+	assert.NilError(t, err)
+	assert.Check(t, isCached("docker-aaa"))
+	assert.Check(t, !isCached("docker-bbb"))
+
+	// a corrupt cache is ignored, and rebuilt
+	assert.NilError(t, os.WriteFile(filepath.Join(configDir, metadataCacheFileName), []byte("invalid"), 0o644))
+	plugins, err := ListPlugins(cli, &cobra.Command{})
+	assert.NilError(t, err)
+	assert.Assert(t, findPlugin(plugins, "aaa") != nil)
+	assert.Check(t, is.Equal(findPlugin(plugins, "aaa").Version, "v1"))
+	assert.Check(t, isCached("docker-aaa"))
+
+	plugins, err = RefreshMetadataCache(cli, &cobra.Command{})
+	assert.NilError(t, err)
+	assert.Check(t, findPlugin(plugins, "aaa") != nil)
+	assert.Check(t, isCached("docker-aaa"))
+}
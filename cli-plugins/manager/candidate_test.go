@@ -69,6 +69,8 @@ func TestValidateCandidate(t *testing.T) {
 		{name: "invalid schemaversion", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "xyzzy"}`}, invalid: `plugin SchemaVersion "xyzzy" is not valid`},
 		{name: "no vendor", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "0.1.0"}`}, invalid: "plugin metadata does not define a vendor"},
 		{name: "empty vendor", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "0.1.0", "Vendor": ""}`}, invalid: "plugin metadata does not define a vendor"},
+		{name: "invalid minimum CLI version", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "0.1.0", "Vendor": "e2e-testing", "MinimumCLIVersion": "latest"}`}, invalid: `plugin MinimumCLIVersion "latest" is not a valid version`},
+		{name: "unsupported API version", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "0.1.0", "Vendor": "e2e-testing", "MinimumAPIVersion": "99.0"}`}, invalid: "plugin requires API version 99.0 or later"},
 		// This one should work
 		{name: "valid", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: `{"SchemaVersion": "0.1.0", "Vendor": "e2e-testing"}`}},
 		{name: "experimental + allowing experimental", c: &fakeCandidate{path: goodPluginPath, exec: true, meta: metaExperimental}},
@@ -91,6 +93,38 @@ func TestValidateCandidate(t *testing.T) {
 	}
 }
 
+func TestCheckVersions(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		meta       Metadata
+		cliVersion string
+		invalid    string
+	}{
+		{name: "no requirements", cliVersion: "23.0.1"},
+		{name: "minimum CLI version", meta: Metadata{MinimumCLIVersion: "23.0"}, cliVersion: "23.0.1"},
+		{name: "minimum CLI version with prefix", meta: Metadata{MinimumCLIVersion: "v23.0.1"}, cliVersion: "v23.0.1"},
+		{name: "CLI too old", meta: Metadata{MinimumCLIVersion: "23.0.2"}, cliVersion: "23.0.1", invalid: "plugin requires docker CLI version 23.0.2 or later, but the docker CLI version is 23.0.1"},
+		{name: "pre-release too old", meta: Metadata{MinimumCLIVersion: "24.0"}, cliVersion: "23.0.0-rc.1", invalid: "plugin requires docker CLI version 24.0 or later"},
+		{name: "maximum CLI version", meta: Metadata{MaximumCLIVersion: "23"}, cliVersion: "23.0.1"},
+		{name: "maximum CLI version with minor", meta: Metadata{MaximumCLIVersion: "23.1"}, cliVersion: "23.1.5"},
+		{name: "CLI too new", meta: Metadata{MaximumCLIVersion: "23.0"}, cliVersion: "23.1.0", invalid: "plugin supports docker CLI versions up to 23.0, but the docker CLI version is 23.1.0"},
+		{name: "development CLI", meta: Metadata{MinimumCLIVersion: "99.0", MaximumCLIVersion: "1.0"}, cliVersion: "unknown-version"},
+		{name: "minimum API version", meta: Metadata{MinimumAPIVersion: "1.41"}, cliVersion: "23.0.1"},
+		{name: "API too old", meta: Metadata{MinimumAPIVersion: "1.43"}, cliVersion: "23.0.1", invalid: "plugin requires API version 1.43 or later, but the docker CLI supports API version 1.42"},
+		{name: "invalid maximum CLI version", meta: Metadata{MaximumCLIVersion: "23.x"}, cliVersion: "23.0.1", invalid: `plugin MaximumCLIVersion "23.x" is not a valid version`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkVersions(tc.meta, tc.cliVersion, "1.42")
+			if tc.invalid == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Assert(t, cmp.ErrorType(err, reflect.TypeOf(&pluginError{})))
+				assert.ErrorContains(t, err, tc.invalid)
+			}
+		})
+	}
+}
+
 func TestCandidatePath(t *testing.T) {
 	exp := "/some/path"
 	cand := &candidate{path: exp}
@@ -0,0 +1,118 @@
+package manager
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	exec "golang.org/x/sys/execabs"
+)
+
+type completionResult struct {
+	completions []string
+	directive   cobra.ShellCompDirective
+}
+
+var (
+	// pluginCompletions caches the completions returned by plugins, so that
+	// each plugin is run at most once per command line to complete during a
+	// completion request.
+	pluginCompletions   = map[string]completionResult{}
+	pluginCompletionsMu sync.Mutex
+)
+
+// completePlugin returns a cobra ValidArgsFunction for the stub command of
+// plugin p, which forwards the completion request to the plugin, and returns
+// its completions and directive.
+func completePlugin(p Plugin) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if p.Err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		// The request always includes descriptions. They are removed by
+		// the completion command of the CLI if they were not requested.
+		cargs := append([]string{cobra.ShellCompRequestCmd, p.Name}, args...)
+		cargs = append(cargs, toComplete)
+		key := p.Path + "\x00" + strings.Join(cargs, "\x00")
+
+		pluginCompletionsMu.Lock()
+		defer pluginCompletionsMu.Unlock()
+		res, ok := pluginCompletions[key]
+		if !ok {
+			var err error
+			if res, err = runPluginCompletion(p, cargs); err != nil {
+				logrus.WithError(err).Debugf("failed to get completions from plugin %q", p.Name)
+				return nil, cobra.ShellCompDirectiveError
+			}
+			pluginCompletions[key] = res
+		}
+		if strings.HasPrefix(toComplete, "-") {
+			// The completion command of the CLI already completes the
+			// names of the global flags, which the plugin completes too.
+			return withoutFlags(res.completions, cmd), res.directive
+		}
+		return res.completions, res.directive
+	}
+}
+
+func runPluginCompletion(p Plugin, cargs []string) (completionResult, error) {
+	cmd := exec.Command(p.Path, cargs...)
+	cmd.Env = append(os.Environ(), ReexecEnvvar+"="+os.Args[0])
+	out, err := cmd.Output()
+	if err != nil {
+		return completionResult{}, err
+	}
+	completions, directive, err := parseCompletions(string(out))
+	if err != nil {
+		return completionResult{}, err
+	}
+	return completionResult{completions: completions, directive: directive}, nil
+}
+
+// withoutFlags removes the completions of the names of the flags of cmd.
+func withoutFlags(completions []string, cmd *cobra.Command) []string {
+	known := map[string]bool{}
+	visit := func(f *pflag.Flag) {
+		known["--"+f.Name] = true
+		if f.Shorthand != "" {
+			known["-"+f.Shorthand] = true
+		}
+	}
+	cmd.InheritedFlags().VisitAll(visit)
+	cmd.NonInheritedFlags().VisitAll(visit)
+
+	res := make([]string, 0, len(completions))
+	for _, c := range completions {
+		name := strings.SplitN(c, "\t", 2)[0]
+		if !known[name] {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// parseCompletions parses the output of a cobra completion request: one
+// completion per line, followed by the directive as ":<directive>".
+func parseCompletions(out string) ([]string, cobra.ShellCompDirective, error) {
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, ":") {
+		return nil, cobra.ShellCompDirectiveError, errors.New("missing completion directive")
+	}
+	directive, err := strconv.Atoi(last[1:])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError, errors.Errorf("invalid completion directive %q", last)
+	}
+	var completions []string
+	for _, l := range lines[:len(lines)-1] {
+		if l != "" {
+			completions = append(completions, l)
+		}
+	}
+	return completions, cobra.ShellCompDirective(directive), nil
+}
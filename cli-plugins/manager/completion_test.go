@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestParseCompletions(t *testing.T) {
+	completions, directive, err := parseCompletions("build\tStart a build\nls\n:4\n")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(completions, []string{"build\tStart a build", "ls"}))
+	assert.Check(t, is.Equal(directive, cobra.ShellCompDirectiveNoFileComp))
+
+	completions, directive, err = parseCompletions(":0\n")
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(completions, 0))
+	assert.Check(t, is.Equal(directive, cobra.ShellCompDirectiveDefault))
+
+	_, _, err = parseCompletions("build\nls\n")
+	assert.Check(t, is.Error(err, "missing completion directive"))
+	_, _, err = parseCompletions(":invalid\n")
+	assert.Check(t, is.Error(err, `invalid completion directive ":invalid"`))
+}
+
+func TestCompletePlugin(t *testing.T) {
+	callsFile := t.TempDir() + "/calls"
+	dir := fs.NewDir(t, t.Name(),
+		fs.WithFile("docker-completer", `#!/bin/sh
+echo "$@" >> `+callsFile+`
+case "$4" in
+-*)
+	printf -- '--global\tGlobal flag\n--own\tFlag of the plugin\n:4\n'
+	;;
+*)
+	printf 'build\tStart a build\nls\n:4\n'
+	;;
+esac
+`, fs.WithMode(0o777)),
+	)
+	p := Plugin{Name: "completer", Path: dir.Join("docker-completer")}
+
+	rootCmd := &cobra.Command{Use: "docker"}
+	rootCmd.PersistentFlags().Bool("global", false, "Global flag")
+	rootCmd.AddCommand(&cobra.Command{
+		Use:                p.Name,
+		Run:                func(_ *cobra.Command, _ []string) {},
+		DisableFlagParsing: true,
+		ValidArgsFunction:  completePlugin(p),
+	})
+	complete := func(args ...string) string {
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&bytes.Buffer{})
+		rootCmd.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+		assert.NilError(t, rootCmd.Execute())
+		return out.String()
+	}
+
+	assert.Check(t, is.Equal(complete("completer", "sub", ""), "build\tStart a build\nls\n:4\n"))
+	assert.Check(t, is.Equal(complete("completer", "sub", ""), "build\tStart a build\nls\n:4\n"))
+	// the names of global flags are only completed once
+	assert.Check(t, is.Equal(complete("completer", "sub", "--"), "--global\tGlobal flag\n--help\thelp for completer\n--own\tFlag of the plugin\n:4\n"))
+
+	calls, err := os.ReadFile(callsFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(calls), "__complete completer sub \n__complete completer sub --\n"))
+}
+
+func TestCompletePluginInvalid(t *testing.T) {
+	p := Plugin{Name: "invalid", Path: "/does/not/exist/docker-invalid"}
+	completions, directive := completePlugin(p)(&cobra.Command{}, nil, "")
+	assert.Check(t, is.Len(completions, 0))
+	assert.Check(t, is.Equal(directive, cobra.ShellCompDirectiveError))
+
+	p.Err = NewPluginError("invalid plugin")
+	completions, directive = completePlugin(p)(&cobra.Command{}, nil, "")
+	assert.Check(t, is.Len(completions, 0))
+	assert.Check(t, is.Equal(directive, cobra.ShellCompDirectiveNoFileComp))
+}
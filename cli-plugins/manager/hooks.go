@@ -0,0 +1,243 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	exec "golang.org/x/sys/execabs"
+)
+
+const (
+	// HookBefore is the When of a hook that runs before the command.
+	HookBefore = "before"
+	// HookAfter is the When of a hook that runs after the command,
+	// whether it succeeded or not.
+	HookAfter = "after"
+	// HookAfterFailure is the When of a hook that runs after the command,
+	// only if it failed.
+	HookAfterFailure = "after-failure"
+)
+
+// hookTimeout is the time hooks have to complete, after which they are
+// killed. It is a var for unit testing.
+var hookTimeout = 2 * time.Second
+
+// Hook declares a builtin command that a plugin is invoked for.
+type Hook struct {
+	// Command is the path of the command, without "docker", for example
+	// "build" or "image push".
+	Command string
+	// When the plugin is invoked: HookBefore, HookAfter, or
+	// HookAfterFailure. Defaults to HookAfter.
+	When string `json:",omitempty"`
+	// Flags are the names of the flags of the command whose values are
+	// passed to the plugin, if they are set.
+	Flags []string `json:",omitempty"`
+}
+
+// HookRequest is passed, as JSON, as the argument of the HookSubcommandName
+// subcommand of a plugin to run one of its hooks.
+type HookRequest struct {
+	// Command is the path of the command, without "docker".
+	Command string
+	// When is the When of the hook.
+	When string
+	// Args are the positional arguments of the command.
+	Args []string `json:",omitempty"`
+	// Flags are the values of the flags of the command that the hook
+	// requested and that are set.
+	Flags map[string]string `json:",omitempty"`
+	// ExitCode is the exit status of the command, for hooks that run after
+	// the command.
+	ExitCode int
+}
+
+// HookResponse is written, as JSON, to stdout by the HookSubcommandName
+// subcommand of a plugin.
+type HookResponse struct {
+	// Messages are printed by the CLI, for example to suggest follow-up
+	// commands.
+	Messages []string `json:",omitempty"`
+}
+
+func (h Hook) when() string {
+	if h.When == "" {
+		return HookAfter
+	}
+	return h.When
+}
+
+// matches returns whether the hook is to run for the command at the given
+// stage (HookBefore or HookAfter). Hooks for HookAfterFailure run after the
+// command if it failed.
+func (h Hook) matches(commandPath, stage string, exitCode int) bool {
+	if h.Command != commandPath {
+		return false
+	}
+	switch h.when() {
+	case HookAfterFailure:
+		return stage == HookAfter && exitCode != 0
+	default:
+		return h.when() == stage
+	}
+}
+
+// HookPlugins returns the plugins that declare hooks, to be passed to
+// RunHooks. To keep the overhead of hooks low, plugins are only listed, which
+// executes the plugins whose metadata is not cached, if the metadata of a
+// plugin is not cached, or declares hooks. Candidates whose metadata could not
+// be fetched, or that cannot be stat'ed, are considered to declare no hooks.
+func HookPlugins(dockerCli command.Cli, rootCmd *cobra.Command) ([]Plugin, error) {
+	pluginDirs, err := getPluginDirs(dockerCli)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := listPluginCandidates(pluginDirs)
+	if err != nil {
+		return nil, err
+	}
+	cache := loadMetadataCache()
+	mayHaveHooks := false
+	for _, paths := range candidates {
+		if len(paths) == 0 {
+			continue
+		}
+		if hasHooks, ok := cache.declaresHooks(paths[0]); !ok || hasHooks {
+			mayHaveHooks = true
+			break
+		}
+	}
+	if !mayHaveHooks {
+		return nil, nil
+	}
+
+	plugins, err := ListPlugins(dockerCli, rootCmd)
+	if err != nil {
+		return nil, err
+	}
+	var hookPlugins []Plugin
+	for _, p := range plugins {
+		if p.Err == nil && len(p.Hooks) > 0 {
+			hookPlugins = append(hookPlugins, p)
+		}
+	}
+	return hookPlugins, nil
+}
+
+// RunHooks runs the hooks that plugins, as returned by HookPlugins, declare
+// for cmd at the given stage, HookBefore or HookAfter, and prints the messages
+// they return. Hooks have a short timeout, and failing hooks are ignored, so
+// that they never affect the command itself.
+func RunHooks(dockerCli command.Cli, plugins []Plugin, rootCmd, cmd *cobra.Command, stage string, exitCode int) {
+	if len(plugins) == 0 || IsPluginCommand(cmd) || !cmd.Runnable() {
+		return
+	}
+	commandPath := strings.TrimPrefix(cmd.CommandPath(), rootCmd.Name()+" ")
+
+	type invocation struct {
+		plugin Plugin
+		req    HookRequest
+	}
+	var invocations []invocation
+	for _, p := range plugins {
+		if p.Err != nil {
+			continue
+		}
+		for _, h := range p.Hooks {
+			if !h.matches(commandPath, stage, exitCode) {
+				continue
+			}
+			req := HookRequest{
+				Command:  commandPath,
+				When:     h.when(),
+				Args:     cmd.Flags().Args(),
+				Flags:    hookFlags(cmd.Flags(), h.Flags),
+				ExitCode: exitCode,
+			}
+			invocations = append(invocations, invocation{plugin: p, req: req})
+		}
+	}
+	if len(invocations) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+	messages := make([][]string, len(invocations))
+	var wg sync.WaitGroup
+	for i, inv := range invocations {
+		wg.Add(1)
+		go func(i int, p Plugin, req HookRequest) {
+			defer wg.Done()
+			resp, err := invokeHook(ctx, p, req)
+			if err != nil {
+				logrus.WithError(err).Debugf("hook of plugin %q failed", p.Name)
+				return
+			}
+			messages[i] = resp.Messages
+		}(i, inv.plugin, inv.req)
+	}
+	wg.Wait()
+
+	// Messages are printed in the order of the plugins, which is sorted,
+	// and to stderr, so as not to mix with the output of the command.
+	for _, msgs := range messages {
+		for _, msg := range msgs {
+			fmt.Fprintln(dockerCli.Err(), msg)
+		}
+	}
+}
+
+func hookFlags(flags *pflag.FlagSet, names []string) map[string]string {
+	values := map[string]string{}
+	for _, name := range names {
+		if f := flags.Lookup(name); f != nil && f.Changed {
+			values[name] = f.Value.String()
+		}
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+func invokeHook(ctx context.Context, p Plugin, req HookRequest) (HookResponse, error) {
+	var resp HookResponse
+	data, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+	cmd := exec.CommandContext(ctx, p.Path, HookSubcommandName, string(data))
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	// Killing the plugin when the timeout expires does not kill processes
+	// it started, which may keep its stdout open, so the plugin is not waited
+	// for past the timeout.
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Run()
+	}()
+	select {
+	case <-ctx.Done():
+		return resp, ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return resp, err
+		}
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return resp, errors.Wrap(err, "invalid hook response")
+	}
+	return resp, nil
+}
@@ -0,0 +1,164 @@
+package manager
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+// hookPlugin creates a plugin which declares the given hooks, records the
+// requests of its hooks in requestFile, and runs hookScript to respond.
+func hookPlugin(name, hooks, requestFile, hookScript string) fs.PathOp {
+	return fs.WithFile(name, `#!/bin/sh
+case "$1" in
+docker-cli-plugin-metadata)
+	echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing","Hooks":`+hooks+`}'
+	;;
+docker-cli-plugin-hook)
+	echo "$2" > `+requestFile+`
+	`+hookScript+`
+	;;
+esac
+`, fs.WithMode(0o777))
+}
+
+func setupHookTest(t *testing.T, ops ...fs.PathOp) (*test.FakeCli, *cobra.Command, *cobra.Command) {
+	t.Helper()
+	config.SetDir(t.TempDir())
+	dir := fs.NewDir(t, t.Name(), ops...)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(&configfile.ConfigFile{CLIPluginsExtraDirs: []string{dir.Path()}})
+
+	rootCmd := &cobra.Command{Use: "docker"}
+	buildCmd := &cobra.Command{Use: "build", RunE: func(*cobra.Command, []string) error { return nil }}
+	buildCmd.Flags().StringP("tag", "t", "", "")
+	buildCmd.Flags().String("secret", "", "")
+	rootCmd.AddCommand(buildCmd)
+	assert.NilError(t, rootCmd.ParseFlags(nil))
+	assert.NilError(t, buildCmd.ParseFlags([]string{"--tag", "myimage", "--secret", "s3cr3t", "."}))
+	return cli, rootCmd, buildCmd
+}
+
+func readHookRequest(t *testing.T, path string) HookRequest {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	var req HookRequest
+	assert.NilError(t, json.Unmarshal(data, &req))
+	return req
+}
+
+func hookPlugins(t *testing.T, cli *test.FakeCli, rootCmd *cobra.Command) []Plugin {
+	t.Helper()
+	plugins, err := HookPlugins(cli, rootCmd)
+	assert.NilError(t, err)
+	return plugins
+}
+
+func TestRunHooks(t *testing.T) {
+	requestFile := t.TempDir() + "/request.json"
+	cli, rootCmd, buildCmd := setupHookTest(t,
+		hookPlugin("docker-hooks",
+			`[{"Command":"build","When":"after","Flags":["tag","missing"]}]`,
+			requestFile,
+			`echo '{"Messages":["Try docker hooks scan"]}'`,
+		),
+	)
+
+	plugins := hookPlugins(t, cli, rootCmd)
+	RunHooks(cli, plugins, rootCmd, buildCmd, HookBefore, 0)
+	_, err := os.Stat(requestFile)
+	assert.Check(t, os.IsNotExist(err), "hook ran before the command")
+
+	RunHooks(cli, plugins, rootCmd, buildCmd, HookAfter, 0)
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "Try docker hooks scan\n"))
+	assert.Check(t, is.DeepEqual(readHookRequest(t, requestFile), HookRequest{
+		Command: "build",
+		When:    HookAfter,
+		Args:    []string{"."},
+		Flags:   map[string]string{"tag": "myimage"},
+	}))
+}
+
+func TestRunHooksAfterFailure(t *testing.T) {
+	requestFile := t.TempDir() + "/request.json"
+	cli, rootCmd, buildCmd := setupHookTest(t,
+		hookPlugin("docker-hooks",
+			`[{"Command":"build","When":"after-failure"}]`,
+			requestFile,
+			`echo '{"Messages":["The build failed"]}'`,
+		),
+	)
+
+	plugins := hookPlugins(t, cli, rootCmd)
+	RunHooks(cli, plugins, rootCmd, buildCmd, HookAfter, 0)
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), ""))
+
+	RunHooks(cli, plugins, rootCmd, buildCmd, HookAfter, 2)
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "The build failed\n"))
+	assert.Check(t, is.Equal(readHookRequest(t, requestFile).ExitCode, 2))
+}
+
+func TestRunHooksNeverFail(t *testing.T) {
+	hookTimeout = 500 * time.Millisecond
+	defer func() { hookTimeout = 2 * time.Second }()
+
+	tmpDir := t.TempDir()
+	cli, rootCmd, buildCmd := setupHookTest(t,
+		hookPlugin("docker-aaa", `[{"Command":"build","When":"before"}]`, tmpDir+"/aaa.json", `exit 1`),
+		hookPlugin("docker-bbb", `[{"Command":"build","When":"before"}]`, tmpDir+"/bbb.json", `echo invalid`),
+		hookPlugin("docker-ccc", `[{"Command":"build","When":"before"}]`, tmpDir+"/ccc.json", `sleep 10`),
+		hookPlugin("docker-ddd", `[{"Command":"build","When":"before"}]`, tmpDir+"/ddd.json", `echo '{"Messages":["Checked the build"]}'`),
+	)
+
+	plugins := hookPlugins(t, cli, rootCmd)
+	start := time.Now()
+	RunHooks(cli, plugins, rootCmd, buildCmd, HookBefore, 0)
+	assert.Check(t, time.Since(start) < 5*time.Second, "hooks did not time out")
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "Checked the build\n"))
+}
+
+func TestHookPluginsSkipsListing(t *testing.T) {
+	marker := t.TempDir() + "/executed"
+	cli, rootCmd, _ := setupHookTest(t,
+		fs.WithFile("docker-nohooks", `#!/bin/sh
+touch `+marker+`
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing"}'`, fs.WithMode(0o777)),
+	)
+
+	// listing the plugins caches their metadata
+	_, err := ListPlugins(cli, rootCmd)
+	assert.NilError(t, err)
+	assert.NilError(t, os.Remove(marker))
+
+	assert.Check(t, is.Len(hookPlugins(t, cli, rootCmd), 0))
+	_, err = os.Stat(marker)
+	assert.Check(t, os.IsNotExist(err), "plugin was executed although no cached metadata declares hooks")
+}
+
+func TestHookPluginsSkipsFailedCandidates(t *testing.T) {
+	marker := t.TempDir() + "/executed"
+	cli, rootCmd, _ := setupHookTest(t,
+		fs.WithFile("docker-notaplugin", `#!/bin/sh
+touch `+marker+`
+echo 'not json'`, fs.WithMode(0o777)),
+		fs.WithSymlink("docker-broken", "/does/not/exist"),
+	)
+
+	// the first command lists the candidates, and caches the failure
+	assert.Check(t, is.Len(hookPlugins(t, cli, rootCmd), 0))
+	assert.NilError(t, os.Remove(marker))
+
+	assert.Check(t, is.Len(hookPlugins(t, cli, rootCmd), 0))
+	_, err := os.Stat(marker)
+	assert.Check(t, os.IsNotExist(err), "candidate with invalid metadata was executed again")
+}
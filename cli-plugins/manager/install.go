@@ -0,0 +1,42 @@
+package manager
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// FileName returns the name of the executable of the plugin with the given
+// name.
+func FileName(name string) string {
+	return addExeSuffix(NamePrefix + name)
+}
+
+// NameFromFileName returns the name of the plugin that an executable with
+// the given file name provides, if it is a valid plugin executable name.
+// A ".exe" suffix is ignored on all platforms, so that the names of Windows
+// executables can be recognized when downloading them.
+func NameFromFileName(fileName string) (string, bool) {
+	name := strings.TrimSuffix(filepath.Base(fileName), ".exe")
+	if !strings.HasPrefix(name, NamePrefix) {
+		return "", false
+	}
+	name = strings.TrimPrefix(name, NamePrefix)
+	return name, pluginNameRe.MatchString(name)
+}
+
+// ValidateCandidate applies the candidate tests to the plugin executable at
+// path, and returns the resulting Plugin. Unlike GetPlugin and ListPlugins,
+// an error is returned if the candidate is not a valid plugin. The metadata
+// of the candidate is not cached.
+func ValidateCandidate(path string, rootcmd *cobra.Command) (Plugin, error) {
+	p, err := newPlugin(&candidate{path: path}, rootcmd.Commands())
+	if err != nil {
+		return p, err
+	}
+	if p.Err != nil {
+		return p, p.Err
+	}
+	return p, nil
+}
@@ -0,0 +1,53 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestNameFromFileName(t *testing.T) {
+	for _, tc := range []struct {
+		fileName string
+		name     string
+		valid    bool
+	}{
+		{fileName: "docker-foo", name: "foo", valid: true},
+		{fileName: "/some/dir/docker-foo", name: "foo", valid: true},
+		{fileName: "docker-foo.exe", name: "foo", valid: true},
+		{fileName: "docker-foo-linux-amd64", name: "foo-linux-amd64"},
+		{fileName: "docker-", name: ""},
+		{fileName: "foo"},
+	} {
+		name, valid := NameFromFileName(tc.fileName)
+		assert.Check(t, is.Equal(name, tc.name), tc.fileName)
+		assert.Check(t, is.Equal(valid, tc.valid), tc.fileName)
+	}
+}
+
+func TestValidateCandidateExecutable(t *testing.T) {
+	dir := fs.NewDir(t, t.Name(),
+		fs.WithFile("docker-valid", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing","Version":"v1.2.3"}'`, fs.WithMode(0o777)),
+		fs.WithFile("docker-novendor", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0"}'`, fs.WithMode(0o777)),
+		fs.WithFile("docker-builtin", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing"}'`, fs.WithMode(0o777)),
+	)
+	rootcmd := &cobra.Command{}
+	rootcmd.AddCommand(&cobra.Command{Use: "builtin"})
+
+	p, err := ValidateCandidate(dir.Join("docker-valid"), rootcmd)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p.Name, "valid"))
+	assert.Check(t, is.Equal(p.Version, "v1.2.3"))
+
+	_, err = ValidateCandidate(dir.Join("docker-novendor"), rootcmd)
+	assert.Check(t, is.Error(err, "plugin metadata does not define a vendor"))
+
+	_, err = ValidateCandidate(dir.Join("docker-builtin"), rootcmd)
+	assert.Check(t, is.Error(err, `plugin "builtin" duplicates builtin command`))
+}
@@ -42,13 +42,19 @@ func IsNotFound(err error) bool {
 	return ok
 }
 
+// UserPluginDir returns the directory, in the configuration directory, that
+// holds the CLI plugins of the user.
+func UserPluginDir() (string, error) {
+	return config.Path("cli-plugins")
+}
+
 func getPluginDirs(dockerCli command.Cli) ([]string, error) {
 	var pluginDirs []string
 
 	if cfg := dockerCli.ConfigFile(); cfg != nil {
 		pluginDirs = append(pluginDirs, cfg.CLIPluginsExtraDirs...)
 	}
-	pluginDir, err := config.Path("cli-plugins")
+	pluginDir, err := UserPluginDir()
 	if err != nil {
 		return nil, err
 	}
@@ -122,8 +128,9 @@ func GetPlugin(name string, dockerCli command.Cli, rootcmd *cobra.Command) (*Plu
 		if len(paths) == 0 {
 			return nil, errPluginNotFound(name)
 		}
-		c := &candidate{paths[0]}
-		p, err := newPlugin(c, rootcmd.Commands())
+		cache := loadMetadataCache()
+		defer cache.save()
+		p, err := newPlugin(cache.candidate(paths[0]), rootcmd.Commands())
 		if err != nil {
 			return nil, err
 		}
@@ -150,6 +157,7 @@ func ListPlugins(dockerCli command.Cli, rootcmd *cobra.Command) ([]Plugin, error
 
 	var plugins []Plugin
 	var mu sync.Mutex
+	cache := loadMetadataCache()
 	eg, _ := errgroup.WithContext(context.TODO())
 	cmds := rootcmd.Commands()
 	for _, paths := range candidates {
@@ -158,8 +166,7 @@ func ListPlugins(dockerCli command.Cli, rootcmd *cobra.Command) ([]Plugin, error
 				if len(paths) == 0 {
 					return nil
 				}
-				c := &candidate{paths[0]}
-				p, err := newPlugin(c, cmds)
+				p, err := newPlugin(cache.candidate(paths[0]), cmds)
 				if err != nil {
 					return err
 				}
@@ -176,6 +183,8 @@ func ListPlugins(dockerCli command.Cli, rootcmd *cobra.Command) ([]Plugin, error
 	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
+	cache.prune()
+	cache.save()
 
 	sort.Slice(plugins, func(i, j int) bool {
 		return sortorder.NaturalLess(plugins[i].Name, plugins[j].Name)
@@ -214,8 +223,9 @@ func PluginRunCommand(dockerCli command.Cli, name string, rootcmd *cobra.Command
 			continue
 		}
 
-		c := &candidate{path: path}
-		plugin, err := newPlugin(c, rootcmd.Commands())
+		cache := loadMetadataCache()
+		plugin, err := newPlugin(cache.candidate(path), rootcmd.Commands())
+		cache.save()
 		if err != nil {
 			return nil, err
 		}
@@ -8,6 +8,10 @@ const (
 	// which must be supported by every plugin and returns the
 	// plugin metadata.
 	MetadataSubcommandName = "docker-cli-plugin-metadata"
+
+	// HookSubcommandName is the name of the plugin subcommand which
+	// is invoked to run the hooks declared in the plugin metadata.
+	HookSubcommandName = "docker-cli-plugin-hook"
 )
 
 // Metadata provided by the plugin.
@@ -25,4 +29,17 @@ type Metadata struct {
 	// Experimental specifies whether the plugin is experimental.
 	// Deprecated: experimental features are now always enabled in the CLI
 	Experimental bool `json:",omitempty"`
+	// MinimumCLIVersion is the optional minimum version of the docker CLI
+	// that the plugin supports, for example "23.0".
+	MinimumCLIVersion string `json:",omitempty"`
+	// MaximumCLIVersion is the optional maximum version of the docker CLI
+	// that the plugin supports. Only the components it specifies are
+	// compared, so "23" includes all 23.x.y versions.
+	MaximumCLIVersion string `json:",omitempty"`
+	// MinimumAPIVersion is the optional minimum version of the Engine API
+	// that the docker CLI must support, for example "1.41".
+	MinimumAPIVersion string `json:",omitempty"`
+	// Hooks are the builtin commands the plugin is invoked for, before or
+	// after they run.
+	Hooks []Hook `json:",omitempty"`
 }
@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/harness-community/docker-cli-v23/cli/version"
+	"github.com/harness-community/docker-v23/api"
+	"github.com/harness-community/docker-v23/api/types/versions"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -98,5 +101,54 @@ func newPlugin(c Candidate, cmds []*cobra.Command) (Plugin, error) {
 		p.Err = NewPluginError("plugin metadata does not define a vendor")
 		return p, nil
 	}
+	if err := checkVersions(p.Metadata, version.Version, api.DefaultVersion); err != nil {
+		p.Err = err
+		return p, nil
+	}
 	return p, nil
 }
+
+var versionRe = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*$`)
+
+// checkVersions checks that the plugin supports the version of the CLI, and
+// the version of the API that the CLI supports. Development builds of the
+// CLI, which do not have a release version, are assumed to be compatible.
+func checkVersions(meta Metadata, cliVersion, apiVersion string) error {
+	for _, f := range []struct{ name, value string }{
+		{name: "MinimumCLIVersion", value: meta.MinimumCLIVersion},
+		{name: "MaximumCLIVersion", value: meta.MaximumCLIVersion},
+		{name: "MinimumAPIVersion", value: meta.MinimumAPIVersion},
+	} {
+		if f.value != "" && !versionRe.MatchString(f.value) {
+			return NewPluginError("plugin %s %q is not a valid version", f.name, f.value)
+		}
+	}
+
+	if meta.MinimumAPIVersion != "" && versions.LessThan(apiVersion, strings.TrimPrefix(meta.MinimumAPIVersion, "v")) {
+		return NewPluginError("plugin requires API version %s or later, but the docker CLI supports API version %s", meta.MinimumAPIVersion, apiVersion)
+	}
+
+	// Ignore pre-release and build suffixes, such as "-rc.1".
+	current := strings.TrimPrefix(cliVersion, "v")
+	if i := strings.IndexAny(current, "-+"); i >= 0 {
+		current = current[:i]
+	}
+	if !versionRe.MatchString(current) {
+		return nil
+	}
+	if minVersion := strings.TrimPrefix(meta.MinimumCLIVersion, "v"); minVersion != "" && versions.LessThan(current, minVersion) {
+		return NewPluginError("plugin requires docker CLI version %s or later, but the docker CLI version is %s", meta.MinimumCLIVersion, cliVersion)
+	}
+	if maxVersion := strings.TrimPrefix(meta.MaximumCLIVersion, "v"); maxVersion != "" {
+		// Only compare the components of the maximum version, so that
+		// "23" includes all 23.x.y versions.
+		components := strings.Split(current, ".")
+		if n := len(strings.Split(maxVersion, ".")); len(components) > n {
+			components = components[:n]
+		}
+		if versions.GreaterThan(strings.Join(components, "."), maxVersion) {
+			return NewPluginError("plugin supports docker CLI versions up to %s, but the docker CLI version is %s", meta.MaximumCLIVersion, cliVersion)
+		}
+	}
+	return nil
+}
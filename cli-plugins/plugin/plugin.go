@@ -24,9 +24,17 @@ import (
 // called.
 var PersistentPreRunE func(*cobra.Command, []string) error
 
+// HookFunc runs a hook that a plugin declares in its metadata, and returns
+// the messages to print, if any.
+type HookFunc func(dockerCli command.Cli, req manager.HookRequest) (manager.HookResponse, error)
+
 // RunPlugin executes the specified plugin command
 func RunPlugin(dockerCli *command.DockerCli, plugin *cobra.Command, meta manager.Metadata) error {
-	tcmd := newPluginCommand(dockerCli, plugin, meta)
+	return runPlugin(dockerCli, plugin, meta, nil)
+}
+
+func runPlugin(dockerCli *command.DockerCli, plugin *cobra.Command, meta manager.Metadata, hook HookFunc) error {
+	tcmd := newPluginCommand(dockerCli, plugin, meta, hook)
 
 	var persistentPreRunOnce sync.Once
 	PersistentPreRunE = func(_ *cobra.Command, _ []string) error {
@@ -53,6 +61,13 @@ func RunPlugin(dockerCli *command.DockerCli, plugin *cobra.Command, meta manager
 
 // Run is the top-level entry point to the CLI plugin framework. It should be called from your plugin's `main()` function.
 func Run(makeCmd func(command.Cli) *cobra.Command, meta manager.Metadata) {
+	RunWithHooks(makeCmd, meta, nil)
+}
+
+// RunWithHooks is the top-level entry point to the CLI plugin framework for
+// plugins which declare hooks in their metadata. The hook function is called
+// to run the hooks. It should be called from your plugin's `main()` function.
+func RunWithHooks(makeCmd func(command.Cli) *cobra.Command, meta manager.Metadata, hook HookFunc) {
 	dockerCli, err := command.NewDockerCli()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -61,7 +76,7 @@ func Run(makeCmd func(command.Cli) *cobra.Command, meta manager.Metadata) {
 
 	plugin := makeCmd(dockerCli)
 
-	if err := RunPlugin(dockerCli, plugin, meta); err != nil {
+	if err := runPlugin(dockerCli, plugin, meta, hook); err != nil {
 		if sterr, ok := err.(cli.StatusError); ok {
 			if sterr.Status != "" {
 				fmt.Fprintln(dockerCli.Err(), sterr.Status)
@@ -110,7 +125,7 @@ func withPluginClientConn(name string) command.InitializeOpt {
 	})
 }
 
-func newPluginCommand(dockerCli *command.DockerCli, plugin *cobra.Command, meta manager.Metadata) *cli.TopLevelCommand {
+func newPluginCommand(dockerCli *command.DockerCli, plugin *cobra.Command, meta manager.Metadata, hook HookFunc) *cli.TopLevelCommand {
 	name := plugin.Name()
 	fullname := manager.NamePrefix + name
 
@@ -141,6 +156,9 @@ func newPluginCommand(dockerCli *command.DockerCli, plugin *cobra.Command, meta
 		plugin,
 		newMetadataSubcommand(plugin, meta),
 	)
+	if hook != nil {
+		cmd.AddCommand(newHookSubcommand(dockerCli, hook))
+	}
 
 	cli.DisableFlagsInUseLine(cmd)
 
@@ -168,10 +186,34 @@ func newMetadataSubcommand(plugin *cobra.Command, meta manager.Metadata) *cobra.
 	return cmd
 }
 
+func newHookSubcommand(dockerCli command.Cli, hook HookFunc) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    manager.HookSubcommandName + " REQUEST",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		// Suppress the global/parent PersistentPreRunE, which
+		// needlessly initializes the client and tries to
+		// connect to the daemon.
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var req manager.HookRequest
+			if err := json.Unmarshal([]byte(args[0]), &req); err != nil {
+				return err
+			}
+			resp, err := hook(dockerCli, req)
+			if err != nil {
+				return err
+			}
+			return json.NewEncoder(os.Stdout).Encode(resp)
+		},
+	}
+	return cmd
+}
+
 // RunningStandalone tells a CLI plugin it is run standalone by direct execution
 func RunningStandalone() bool {
 	if os.Getenv(manager.ReexecEnvvar) != "" {
 		return false
 	}
-	return len(os.Args) < 2 || os.Args[1] != manager.MetadataSubcommandName
+	return len(os.Args) < 2 || (os.Args[1] != manager.MetadataSubcommandName && os.Args[1] != manager.HookSubcommandName)
 }
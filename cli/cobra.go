@@ -21,6 +21,10 @@ import (
 	"github.com/spf13/pflag"
 )
 
+// CommandAnnotationAlias is added to the commands standing for user-defined
+// aliases. Its value is the expansion of the alias.
+const CommandAnnotationAlias = "com.docker.cli.alias"
+
 // setupCommonRootCommand contains the setup common to
 // SetupRootCommand and SetupPluginRootCommand.
 func setupCommonRootCommand(rootCmd *cobra.Command) (*cliflags.ClientOptions, *pflag.FlagSet, *cobra.Command) {
@@ -28,6 +32,7 @@ func setupCommonRootCommand(rootCmd *cobra.Command) (*cliflags.ClientOptions, *p
 	flags := rootCmd.Flags()
 
 	flags.StringVar(&opts.ConfigDir, "config", config.Dir(), "Location of client config files")
+	flags.BoolVar(&opts.StrictConfig, "strict-config", false, "Fail if client config files contain unknown or invalid properties")
 	opts.InstallFlags(flags)
 
 	cobra.AddTemplateFunc("add", func(a, b int) int { return a + b })
@@ -37,12 +42,14 @@ func setupCommonRootCommand(rootCmd *cobra.Command) (*cliflags.ClientOptions, *p
 	cobra.AddTemplateFunc("hasManagementSubCommands", hasManagementSubCommands)
 	cobra.AddTemplateFunc("hasSwarmSubCommands", hasSwarmSubCommands)
 	cobra.AddTemplateFunc("hasInvalidPlugins", hasInvalidPlugins)
+	cobra.AddTemplateFunc("hasUserAliases", hasUserAliases)
 	cobra.AddTemplateFunc("topCommands", topCommands)
 	cobra.AddTemplateFunc("commandAliases", commandAliases)
 	cobra.AddTemplateFunc("operationSubCommands", operationSubCommands)
 	cobra.AddTemplateFunc("managementSubCommands", managementSubCommands)
 	cobra.AddTemplateFunc("orchestratorSubCommands", orchestratorSubCommands)
 	cobra.AddTemplateFunc("invalidPlugins", invalidPlugins)
+	cobra.AddTemplateFunc("userAliases", userAliases)
 	cobra.AddTemplateFunc("wrappedFlagUsages", wrappedFlagUsages)
 	cobra.AddTemplateFunc("vendorAndVersion", vendorAndVersion)
 	cobra.AddTemplateFunc("invalidPluginReason", invalidPluginReason)
@@ -55,6 +62,7 @@ func setupCommonRootCommand(rootCmd *cobra.Command) (*cliflags.ClientOptions, *p
 	rootCmd.SetUsageTemplate(usageTemplate)
 	rootCmd.SetHelpTemplate(helpTemplate)
 	rootCmd.SetFlagErrorFunc(FlagErrorFunc)
+	helpCommand := newHelpCommand()
 	rootCmd.SetHelpCommand(helpCommand)
 
 	rootCmd.PersistentFlags().BoolP("help", "h", false, "Print usage")
@@ -214,20 +222,24 @@ func HasCompletionArg(args []string) bool {
 	return false
 }
 
-var helpCommand = &cobra.Command{
-	Use:               "help [command]",
-	Short:             "Help about the command",
-	PersistentPreRun:  func(cmd *cobra.Command, args []string) {},
-	PersistentPostRun: func(cmd *cobra.Command, args []string) {},
-	RunE: func(c *cobra.Command, args []string) error {
-		cmd, args, e := c.Root().Find(args)
-		if cmd == nil || e != nil || len(args) > 0 {
-			return errors.Errorf("unknown help topic: %v", strings.Join(args, " "))
-		}
-		helpFunc := cmd.HelpFunc()
-		helpFunc(cmd, args)
-		return nil
-	},
+// newHelpCommand returns the help command of a root command. Each root
+// command gets its own, as commands cannot be shared between command trees.
+func newHelpCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:               "help [command]",
+		Short:             "Help about the command",
+		PersistentPreRun:  func(cmd *cobra.Command, args []string) {},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {},
+		RunE: func(c *cobra.Command, args []string) error {
+			cmd, args, e := c.Root().Find(args)
+			if cmd == nil || e != nil || len(args) > 0 {
+				return errors.Errorf("unknown help topic: %v", strings.Join(args, " "))
+			}
+			helpFunc := cmd.HelpFunc()
+			helpFunc(cmd, args)
+			return nil
+		},
+	}
 }
 
 func isExperimental(cmd *cobra.Command) bool {
@@ -263,6 +275,12 @@ func isPlugin(cmd *cobra.Command) bool {
 	return pluginmanager.IsPluginCommand(cmd)
 }
 
+// IsAliasCommand returns true if cmd stands for a user-defined alias.
+func IsAliasCommand(cmd *cobra.Command) bool {
+	_, ok := cmd.Annotations[CommandAnnotationAlias]
+	return ok
+}
+
 func hasAliases(cmd *cobra.Command) bool {
 	return len(cmd.Aliases) > 0 || cmd.Annotations["aliases"] != ""
 }
@@ -283,6 +301,10 @@ func hasInvalidPlugins(cmd *cobra.Command) bool {
 	return len(invalidPlugins(cmd)) > 0
 }
 
+func hasUserAliases(cmd *cobra.Command) bool {
+	return len(userAliases(cmd)) > 0
+}
+
 func hasTopCommands(cmd *cobra.Command) bool {
 	return len(topCommands(cmd)) > 0
 }
@@ -329,7 +351,7 @@ func topCommands(cmd *cobra.Command) []*cobra.Command {
 func operationSubCommands(cmd *cobra.Command) []*cobra.Command {
 	cmds := []*cobra.Command{}
 	for _, sub := range cmd.Commands() {
-		if isPlugin(sub) {
+		if isPlugin(sub) || IsAliasCommand(sub) {
 			continue
 		}
 		if _, ok := sub.Annotations["category-top"]; ok {
@@ -422,6 +444,16 @@ func invalidPlugins(cmd *cobra.Command) []*cobra.Command {
 	return cmds
 }
 
+func userAliases(cmd *cobra.Command) []*cobra.Command {
+	cmds := []*cobra.Command{}
+	for _, sub := range cmd.Commands() {
+		if IsAliasCommand(sub) {
+			cmds = append(cmds, sub)
+		}
+	}
+	return cmds
+}
+
 func invalidPluginReason(cmd *cobra.Command) string {
 	return cmd.Annotations[pluginmanager.CommandAnnotationPluginInvalid]
 }
@@ -496,6 +528,15 @@ Commands:
 {{- end}}
 {{- end}}
 
+{{- if hasUserAliases . }}
+
+Command Aliases:
+
+{{- range userAliases . }}
+  {{rpad .Name .NamePadding }} {{.Short}}
+{{- end}}
+{{- end}}
+
 {{- if hasInvalidPlugins . }}
 
 Invalid Plugins:
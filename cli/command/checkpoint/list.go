@@ -7,12 +7,14 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command"
 	"github.com/harness-community/docker-cli-v23/cli/command/completion"
 	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/spf13/cobra"
 )
 
 type listOptions struct {
 	checkpointDir string
+	sort          string
 }
 
 func newListCommand(dockerCli command.Cli) *cobra.Command {
@@ -31,6 +33,7 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVarP(&opts.checkpointDir, "checkpoint-dir", "", "", "Use a custom checkpoint storage directory")
+	flags.StringVar(&opts.sort, "sort", "", flagsHelper.SortHelp)
 
 	return cmd
 }
@@ -50,6 +53,7 @@ func runList(dockerCli command.Cli, container string, opts listOptions) error {
 	cpCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(formatter.TableFormatKey),
+		Sort:   opts.sort,
 	}
 	return FormatWrite(cpCtx, checkpoints)
 }
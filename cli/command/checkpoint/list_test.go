@@ -65,3 +65,19 @@ func TestCheckpointListWithOptions(t *testing.T) {
 	assert.Check(t, is.Equal("/dir/foo", checkpointDir))
 	golden.Assert(t, cli.OutBuffer().String(), "checkpoint-list-with-options.golden")
 }
+
+func TestCheckpointListSort(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		checkpointListFunc: func(container string, options types.CheckpointListOptions) ([]types.Checkpoint, error) {
+			return []types.Checkpoint{
+				{Name: "checkpoint-2"},
+				{Name: "checkpoint-10"},
+				{Name: "checkpoint-1"},
+			}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.SetArgs([]string{"container-foo", "--sort", "name:desc"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "CHECKPOINT NAME\ncheckpoint-10\ncheckpoint-2\ncheckpoint-1\n"))
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"io"
@@ -27,7 +28,7 @@ import (
 	dopts "github.com/harness-community/docker-cli-v23/opts"
 	"github.com/harness-community/docker-v23/api"
 	"github.com/harness-community/docker-v23/api/types"
-	"github.com/harness-community/docker-v23/api/types/registry"
+	"github.com/harness-community/docker-v23/registry"
 	"github.com/harness-community/docker-v23/api/types/swarm"
 	"github.com/harness-community/docker-v23/client"
 	"github.com/docker/go-connections/tlsconfig"
@@ -67,6 +68,13 @@ type Cli interface {
 	DockerEndpoint() docker.Endpoint
 }
 
+// ContextSourceProvider is implemented by a Cli that knows where its current
+// context was selected from. It is not part of the Cli interface, so that
+// existing implementations of Cli do not have to implement it.
+type ContextSourceProvider interface {
+	CurrentContextSource() ContextSource
+}
+
 // DockerCli is an instance the docker command line client.
 // Instances of the client can be returned from NewDockerCli.
 type DockerCli struct {
@@ -80,6 +88,7 @@ type DockerCli struct {
 	contentTrust       bool
 	contextStore       store.Store
 	currentContext     string
+	contextSource      ContextSource
 	init               sync.Once
 	initErr            error
 	dockerEndpoint     docker.Endpoint
@@ -191,10 +200,10 @@ func (cli *DockerCli) ManifestStore() manifeststore.Store {
 // RegistryClient returns a client for communicating with a Docker distribution
 // registry
 func (cli *DockerCli) RegistryClient(allowInsecure bool) registryclient.RegistryClient {
-	resolver := func(ctx context.Context, index *registry.IndexInfo) types.AuthConfig {
-		return ResolveAuthConfig(ctx, cli, index)
+	resolver := func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+		return ResolveRepositoryAuthConfig(ctx, cli, repoInfo)
 	}
-	return registryclient.NewRegistryClient(resolver, UserAgent(), allowInsecure)
+	return registryclient.NewRepositoryRegistryClient(resolver, UserAgent(), allowInsecure)
 }
 
 // InitializeOpt is the type of the functional options passed to DockerCli.Initialize
@@ -231,8 +240,16 @@ func (cli *DockerCli) Initialize(opts *cliflags.ClientOptions, ops ...Initialize
 	}
 
 	cli.options = opts
-	cli.configFile = config.LoadDefaultConfigFile(cli.err)
-	cli.currentContext = resolveContextName(cli.options, cli.configFile)
+	if opts.StrictConfig {
+		configFile, err := config.LoadDefaultConfigFileStrict(cli.err)
+		if err != nil {
+			return err
+		}
+		cli.configFile = configFile
+	} else {
+		cli.configFile = config.LoadDefaultConfigFile(cli.err)
+	}
+	cli.currentContext, cli.contextSource = resolveContextNameAndSource(cli.options, cli.configFile)
 	cli.contextStore = &ContextStoreWithDefault{
 		Store: store.New(config.ContextStoreDir(), cli.contextStoreConfig),
 		Resolver: func() (*DefaultContext, error) {
@@ -262,6 +279,16 @@ func NewAPIClientFromFlags(opts *cliflags.ClientOptions, configFile *configfile.
 	return newAPIClientFromEndpoint(endpoint, configFile)
 }
 
+// NewAPIClientFromContext creates a new APIClient for the docker endpoint of
+// the given context.
+func NewAPIClientFromContext(s store.Reader, contextName string, configFile *configfile.ConfigFile) (client.APIClient, error) {
+	endpoint, err := resolveDockerEndpoint(s, contextName)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to resolve docker endpoint")
+	}
+	return newAPIClientFromEndpoint(endpoint, configFile)
+}
+
 func newAPIClientFromEndpoint(ep docker.Endpoint, configFile *configfile.ConfigFile) (client.APIClient, error) {
 	clientOpts, err := ep.ClientOpts()
 	if err != nil {
@@ -288,7 +315,11 @@ func resolveDockerEndpoint(s store.Reader, contextName string) (docker.Endpoint,
 	if err != nil {
 		return docker.Endpoint{}, err
 	}
-	return docker.WithTLSData(s, contextName, epMeta)
+	ep, err := docker.WithTLSData(s, contextName, epMeta)
+	if err != nil {
+		return docker.Endpoint{}, err
+	}
+	return docker.WithFailover(ep, docker.DefaultFailoverTimeout), nil
 }
 
 // Resolve the Docker endpoint for the default context (based on config, env vars and CLI flags)
@@ -373,9 +404,12 @@ func (cli *DockerCli) ContextStore() store.Store {
 //
 //  1. The "--context" command-line option.
 //  2. The "DOCKER_CONTEXT" environment variable.
-//  3. The current context as configured through the in "currentContext"
+//  3. A ".dockercontext" file ([ContextFileName]) in the current working
+//     directory or any of its parent directories, containing the name of
+//     the context to use.
+//  4. The current context as configured through the in "currentContext"
 //     field in the CLI configuration file ("~/.docker/config.json").
-//  4. If no context is configured, use the "default" context.
+//  5. If no context is configured, use the "default" context.
 //
 // # Fallbacks for backward-compatibility
 //
@@ -398,6 +432,42 @@ func (cli *DockerCli) CurrentContext() string {
 	return cli.currentContext
 }
 
+// CurrentContextSource returns where the current context was selected from.
+func (cli *DockerCli) CurrentContextSource() ContextSource {
+	return cli.contextSource
+}
+
+// ContextFileName is the name of the file that selects the context to use
+// for the directory it is in, and its subdirectories.
+const ContextFileName = ".dockercontext"
+
+// Types of ContextSource.
+const (
+	ContextSourceFlag    = "flag"
+	ContextSourceEnv     = "env"
+	ContextSourceFile    = "file"
+	ContextSourceConfig  = "config"
+	ContextSourceDefault = "default"
+)
+
+// ContextSource describes where the current context was selected from.
+type ContextSource struct {
+	// Type is the type of source: "flag", "env", "file", "config", or
+	// "default" if no context was configured.
+	Type string
+	// Location is the flag, environment variable, or file that selected
+	// the context. It is empty if no context was configured.
+	Location string `json:",omitempty"`
+}
+
+// String returns a human-readable description of the source.
+func (s ContextSource) String() string {
+	if s.Location == "" {
+		return s.Type
+	}
+	return s.Type + " (" + s.Location + ")"
+}
+
 // CurrentContext returns the current context name, based on flags,
 // environment variables and the cli configuration file. It does not
 // validate if the given context exists or if it's valid; errors may
@@ -405,23 +475,72 @@ func (cli *DockerCli) CurrentContext() string {
 //
 // Refer to [DockerCli.CurrentContext] above for further details.
 func resolveContextName(opts *cliflags.ClientOptions, config *configfile.ConfigFile) string {
+	name, _ := resolveContextNameAndSource(opts, config)
+	return name
+}
+
+// resolveContextNameAndSource is like resolveContextName, but also returns
+// where the context was selected from.
+func resolveContextNameAndSource(opts *cliflags.ClientOptions, config *configfile.ConfigFile) (string, ContextSource) {
 	if opts != nil && opts.Context != "" {
-		return opts.Context
+		return opts.Context, ContextSource{Type: ContextSourceFlag, Location: "--context"}
 	}
 	if opts != nil && len(opts.Hosts) > 0 {
-		return DefaultContextName
+		return DefaultContextName, ContextSource{Type: ContextSourceFlag, Location: "--host"}
 	}
 	if os.Getenv(client.EnvOverrideHost) != "" {
-		return DefaultContextName
+		return DefaultContextName, ContextSource{Type: ContextSourceEnv, Location: client.EnvOverrideHost}
 	}
 	if ctxName := os.Getenv("DOCKER_CONTEXT"); ctxName != "" {
-		return ctxName
+		return ctxName, ContextSource{Type: ContextSourceEnv, Location: "DOCKER_CONTEXT"}
+	}
+	if wd, err := os.Getwd(); err == nil {
+		if ctxName, file := findContextFile(wd); ctxName != "" {
+			return ctxName, ContextSource{Type: ContextSourceFile, Location: file}
+		}
 	}
 	if config != nil && config.CurrentContext != "" {
 		// We don't validate if this context exists: errors may occur when trying to use it.
-		return config.CurrentContext
+		location := config.Filename
+		if config.Layers != nil {
+			if origin, ok := config.Origins()["currentContext"]; ok {
+				location = origin.Filename
+			}
+		}
+		return config.CurrentContext, ContextSource{Type: ContextSourceConfig, Location: location}
+	}
+	return DefaultContextName, ContextSource{Type: ContextSourceDefault}
+}
+
+// findContextFile looks for a ContextFileName file in dir and its parent
+// directories, and returns the context name it contains, and its path. The
+// context name is the first line of the file; files that are empty or
+// cannot be read are ignored.
+func findContextFile(dir string) (name string, file string) {
+	for {
+		file = filepath.Join(dir, ContextFileName)
+		if name = readContextFile(file); name != "" {
+			return name, file
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
 	}
-	return DefaultContextName
+}
+
+func readContextFile(file string) string {
+	f, err := os.Open(file)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	if fi, err := f.Stat(); err != nil || !fi.Mode().IsRegular() {
+		return ""
+	}
+	line, _ := bufio.NewReader(io.LimitReader(f, 1024)).ReadString('\n')
+	return strings.TrimSpace(line)
 }
 
 // DockerEndpoint returns the current docker endpoint
@@ -535,10 +654,29 @@ func RegisterDefaultStoreEndpoints(ep ...store.NamedTypeGetter) {
 	defaultStoreEndpoints = append(defaultStoreEndpoints, ep...)
 }
 
+const (
+	// EnvContextTLSKeyFile is the name of the environment variable that
+	// points to a file holding the secret used to encrypt TLS material in
+	// the context store.
+	EnvContextTLSKeyFile = "DOCKER_CONTEXT_TLS_KEYFILE"
+	// EnvContextTLSPassphrase is the name of the environment variable that
+	// holds a passphrase used to encrypt TLS material in the context store.
+	// It is ignored if EnvContextTLSKeyFile is set.
+	EnvContextTLSPassphrase = "DOCKER_CONTEXT_TLS_PASSPHRASE"
+)
+
 // DefaultContextStoreConfig returns a new store.Config with the default set of endpoints configured.
+// TLS material is encrypted at rest if either the DOCKER_CONTEXT_TLS_KEYFILE
+// or the DOCKER_CONTEXT_TLS_PASSPHRASE environment variable is set.
 func DefaultContextStoreConfig() store.Config {
-	return store.NewConfig(
+	cfg := store.NewConfig(
 		func() interface{} { return &DockerContext{} },
 		defaultStoreEndpoints...,
 	)
+	if keyFile := os.Getenv(EnvContextTLSKeyFile); keyFile != "" {
+		cfg = cfg.WithTLSEncryption(store.KeyFile(keyFile))
+	} else if passphrase := os.Getenv(EnvContextTLSPassphrase); passphrase != "" {
+		cfg = cfg.WithTLSEncryption(store.PassphraseKey(passphrase))
+	}
+	return cfg
 }
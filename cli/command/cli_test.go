@@ -307,3 +307,104 @@ func TestInitializeShouldAlwaysCreateTheContextStore(t *testing.T) {
 	})))
 	assert.Check(t, cli.ContextStore() != nil)
 }
+
+func TestFindContextFile(t *testing.T) {
+	dir := fs.NewDir(t, t.Name(),
+		fs.WithFile(ContextFileName, "project-ctx\nignored\n"),
+		fs.WithDir("sub",
+			fs.WithDir("empty", fs.WithFile(ContextFileName, "  \n")),
+			fs.WithDir("nested", fs.WithFile(ContextFileName, " nested-ctx ")),
+		),
+	)
+	defer dir.Remove()
+
+	name, file := findContextFile(dir.Join("sub", "empty"))
+	assert.Equal(t, name, "project-ctx")
+	assert.Equal(t, file, dir.Join(ContextFileName))
+
+	name, file = findContextFile(dir.Join("sub", "nested"))
+	assert.Equal(t, name, "nested-ctx")
+	assert.Equal(t, file, dir.Join("sub", "nested", ContextFileName))
+}
+
+func TestResolveContextNameAndSource(t *testing.T) {
+	dir := fs.NewDir(t, t.Name(), fs.WithFile(ContextFileName, "file-ctx\n"))
+	defer dir.Remove()
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	assert.NilError(t, os.Chdir(dir.Path()))
+	defer func() { _ = os.Chdir(wd) }()
+
+	cfg := &configfile.ConfigFile{Filename: "/config.json", CurrentContext: "config-ctx"}
+
+	testCases := []struct {
+		doc            string
+		opts           *flags.ClientOptions
+		env            map[string]string
+		expectedName   string
+		expectedSource ContextSource
+	}{
+		{
+			doc:            "flag",
+			opts:           &flags.ClientOptions{Context: "flag-ctx"},
+			env:            map[string]string{"DOCKER_CONTEXT": "env-ctx"},
+			expectedName:   "flag-ctx",
+			expectedSource: ContextSource{Type: ContextSourceFlag, Location: "--context"},
+		},
+		{
+			doc:            "host flag",
+			opts:           &flags.ClientOptions{Hosts: []string{"tcp://127.0.0.1:2375"}},
+			expectedName:   DefaultContextName,
+			expectedSource: ContextSource{Type: ContextSourceFlag, Location: "--host"},
+		},
+		{
+			doc:            "DOCKER_HOST",
+			env:            map[string]string{"DOCKER_HOST": "tcp://127.0.0.1:2375", "DOCKER_CONTEXT": "env-ctx"},
+			expectedName:   DefaultContextName,
+			expectedSource: ContextSource{Type: ContextSourceEnv, Location: "DOCKER_HOST"},
+		},
+		{
+			doc:            "DOCKER_CONTEXT",
+			env:            map[string]string{"DOCKER_CONTEXT": "env-ctx"},
+			expectedName:   "env-ctx",
+			expectedSource: ContextSource{Type: ContextSourceEnv, Location: "DOCKER_CONTEXT"},
+		},
+		{
+			doc:            "context file",
+			expectedName:   "file-ctx",
+			expectedSource: ContextSource{Type: ContextSourceFile, Location: filepath.Join(dir.Path(), ContextFileName)},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			t.Setenv("DOCKER_HOST", "")
+			t.Setenv("DOCKER_CONTEXT", "")
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			name, source := resolveContextNameAndSource(tc.opts, cfg)
+			assert.Equal(t, name, tc.expectedName)
+			assert.Equal(t, source, tc.expectedSource)
+		})
+	}
+}
+
+func TestResolveContextNameAndSourceConfig(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("DOCKER_CONTEXT", "")
+	wd, err := os.Getwd()
+	assert.NilError(t, err)
+	// make sure there's no context file in any of the parent directories
+	if name, _ := findContextFile(wd); name != "" {
+		t.Skip("a context file is present in a parent directory")
+	}
+
+	name, source := resolveContextNameAndSource(nil, &configfile.ConfigFile{Filename: "/config.json", CurrentContext: "config-ctx"})
+	assert.Equal(t, name, "config-ctx")
+	assert.Equal(t, source, ContextSource{Type: ContextSourceConfig, Location: "/config.json"})
+
+	name, source = resolveContextNameAndSource(nil, &configfile.ConfigFile{})
+	assert.Equal(t, name, DefaultContextName)
+	assert.Equal(t, source, ContextSource{Type: ContextSourceDefault})
+}
@@ -0,0 +1,41 @@
+package cliconfig
+
+import (
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCLIConfigCommand returns the cli-config cli subcommand
+func NewCLIConfigCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cli-config",
+		Short: "Manage the configuration of the CLI",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newGetCommand(dockerCli),
+		newListCommand(dockerCli),
+		newSetCommand(dockerCli),
+		newUnsetCommand(dockerCli),
+	)
+	return cmd
+}
+
+// completeKeys completes the first argument of a command with the names of
+// the configuration properties, and the keys that are currently set.
+func completeKeys(dockerCli command.Cli) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		keys := propertyNames()
+		for _, e := range entries(dockerCli.ConfigFile()) {
+			if _, ok := propertyByName[e.key]; !ok {
+				keys = append(keys, e.key)
+			}
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	}
+}
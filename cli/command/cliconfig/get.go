@@ -0,0 +1,45 @@
+package cliconfig
+
+import (
+	"fmt"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newGetCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get KEY",
+		Short: "Print the value of a configuration key",
+		Long: `Print the value of a configuration key.
+
+If KEY holds a group of values, such as "aliases" or "plugins.<plugin>", each
+value of the group is printed as "key=value".`,
+		Args: cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGet(dockerCli, args[0])
+		},
+		ValidArgsFunction: completeKeys(dockerCli),
+	}
+}
+
+func runGet(dockerCli command.Cli, name string) error {
+	k, err := parseKey(name)
+	if err != nil {
+		return err
+	}
+	values := lookup(dockerCli.ConfigFile(), k)
+	if len(values) == 0 {
+		return errors.Errorf("%s is not set", k)
+	}
+	if len(values) == 1 && values[0].key == k.String() {
+		fmt.Fprintln(dockerCli.Out(), values[0].value)
+		return nil
+	}
+	for _, e := range values {
+		fmt.Fprintf(dockerCli.Out(), "%s=%s\n", e.key, e.value)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package cliconfig
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestGet(t *testing.T) {
+	cli := newTestCli(t, `{
+	"psFormat": "table {{.ID}}",
+	"pruneFilters": ["label=foo", "until=24h"],
+	"plugins": {"myplugin": {"a": "1", "b": "2"}}
+}`)
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{key: "psFormat", expected: "table {{.ID}}\n"},
+		{key: "pruneFilters", expected: "label=foo,until=24h\n"},
+		{key: "plugins.myplugin.a", expected: "1\n"},
+		{key: "plugins", expected: "plugins.myplugin.a=1\nplugins.myplugin.b=2\n"},
+	}
+	for _, tc := range tests {
+		cli.ResetOutputBuffers()
+		assert.NilError(t, runGet(cli, tc.key))
+		assert.Check(t, is.Equal(cli.OutBuffer().String(), tc.expected), tc.key)
+	}
+
+	assert.Check(t, is.Error(runGet(cli, "imagesFormat"), "imagesFormat is not set"))
+	assert.Check(t, is.Error(runGet(cli, "plugins.otherplugin"), "plugins.otherplugin is not set"))
+}
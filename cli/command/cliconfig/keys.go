@@ -0,0 +1,332 @@
+package cliconfig
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/pkg/errors"
+)
+
+type propertyKind int
+
+const (
+	kindString  propertyKind = iota // a string, such as psFormat
+	kindList                        // a list of strings, such as pruneFilters
+	kindMap                         // a map of strings, such as aliases.<name>
+	kindNested                      // a map of maps of strings, such as plugins.<plugin>.<option>
+	kindProxies                     // proxies.<daemon>.<option>
+)
+
+// property is a property of the configuration file that can be managed
+// using the cli-config command.
+type property struct {
+	name  string // name of the property in the configuration file
+	index int    // index of the field of the ConfigFile struct
+	kind  propertyKind
+}
+
+// unsupportedProperties are properties that cannot be managed using the
+// cli-config command, with the reason why.
+var unsupportedProperties = map[string]string{
+	"auths":             `use "docker login" and "docker logout" to manage credentials`,
+	"stackOrchestrator": "this option is deprecated and ignored",
+}
+
+// nestedKeys describes the keys of the values of properties that hold maps
+// of maps of strings.
+var nestedKeys = map[string]string{
+	"plugins": "plugins.<plugin>.<option>",
+	"formats": "formats.<command>.<name>",
+}
+
+var (
+	properties     []property
+	propertyByName = map[string]property{}
+	proxyOptions   []string
+)
+
+func init() {
+	t := reflect.TypeOf(configfile.ConfigFile{})
+	for i := 0; i < t.NumField(); i++ {
+		name := jsonName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		if _, ok := unsupportedProperties[name]; ok {
+			continue
+		}
+		p := property{name: name, index: i}
+		switch ft := t.Field(i).Type; {
+		case ft.Kind() == reflect.String:
+			p.kind = kindString
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.String:
+			p.kind = kindList
+		case ft.Kind() == reflect.Map && ft.Elem().Kind() == reflect.String:
+			p.kind = kindMap
+		case ft.Kind() == reflect.Map && ft.Elem().Kind() == reflect.Map:
+			p.kind = kindNested
+		case ft.Kind() == reflect.Map && ft.Elem() == reflect.TypeOf(configfile.ProxyConfig{}):
+			p.kind = kindProxies
+		default:
+			continue
+		}
+		properties = append(properties, p)
+		propertyByName[name] = p
+	}
+
+	pt := reflect.TypeOf(configfile.ProxyConfig{})
+	for i := 0; i < pt.NumField(); i++ {
+		proxyOptions = append(proxyOptions, jsonName(pt.Field(i)))
+	}
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// key is a configuration key, such as "psFormat", "aliases.builder",
+// "plugins.myplugin.option", "formats.ps.ports", or "proxies.default.httpProxy".
+type key struct {
+	property
+	path []string // path of the value within the property
+}
+
+func (k key) String() string {
+	return strings.Join(append([]string{k.name}, k.path...), ".")
+}
+
+// isValue returns true if the key designates a single value, as opposed to a
+// group of values such as "aliases" or "plugins.myplugin".
+func (k key) isValue() bool {
+	switch k.kind {
+	case kindString, kindList:
+		return true
+	case kindMap:
+		return len(k.path) == 1
+	default:
+		return len(k.path) == 2
+	}
+}
+
+// parseKey parses a configuration key. Keys of maps can contain dots (for
+// example "credHelpers.registry.example.com"), so only the property name is
+// split from the rest of the key, except for plugins and formats (whose
+// options and presets are separated from the name of the plugin or command by
+// the first dot) and proxies (whose options are separated from the daemon by
+// the last dot).
+func parseKey(s string) (key, error) {
+	name, rest, hasRest := strings.Cut(s, ".")
+	if reason, ok := unsupportedProperties[name]; ok {
+		return key{}, errors.Errorf("%q cannot be managed with this command: %s", name, reason)
+	}
+	p, ok := propertyByName[name]
+	if !ok {
+		return key{}, errors.Errorf("unknown configuration key %q", s)
+	}
+	k := key{property: p}
+	if !hasRest {
+		return k, nil
+	}
+	if rest == "" {
+		return key{}, errors.Errorf("invalid configuration key %q", s)
+	}
+	switch p.kind {
+	case kindString, kindList:
+		return key{}, errors.Errorf("invalid configuration key %q: %s does not have nested keys", s, name)
+	case kindMap:
+		k.path = []string{rest}
+	case kindNested:
+		group, option, hasOption := strings.Cut(rest, ".")
+		if group == "" || (hasOption && option == "") {
+			return key{}, errors.Errorf("invalid configuration key %q: expected %s", s, nestedKeys[name])
+		}
+		k.path = []string{group}
+		if hasOption {
+			k.path = append(k.path, option)
+		}
+	case kindProxies:
+		k.path = []string{rest}
+		if i := strings.LastIndex(rest, "."); i > 0 && isProxyOption(rest[i+1:]) {
+			k.path = []string{rest[:i], rest[i+1:]}
+		}
+	}
+	return k, nil
+}
+
+func isProxyOption(option string) bool {
+	for _, o := range proxyOptions {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// entry is a value set in the configuration.
+type entry struct {
+	key   string
+	value string
+}
+
+// entries returns the values set in the configuration, in the order of the
+// properties of the configuration file. Values of lists are comma-separated.
+func entries(cfg *configfile.ConfigFile) []entry {
+	var result []entry
+	add := func(k, value string) {
+		if value != "" {
+			result = append(result, entry{key: k, value: value})
+		}
+	}
+	v := reflect.ValueOf(cfg).Elem()
+	for _, p := range properties {
+		f := v.Field(p.index)
+		switch p.kind {
+		case kindString:
+			add(p.name, f.String())
+		case kindList:
+			add(p.name, strings.Join(f.Interface().([]string), ","))
+		case kindMap:
+			m := f.Interface().(map[string]string)
+			for _, k := range sortedKeys(m) {
+				add(p.name+"."+k, m[k])
+			}
+		case kindNested:
+			m := f.Interface().(map[string]map[string]string)
+			for _, group := range sortedKeys(m) {
+				for _, option := range sortedKeys(m[group]) {
+					add(p.name+"."+group+"."+option, m[group][option])
+				}
+			}
+		case kindProxies:
+			for _, daemon := range sortedKeys(cfg.Proxies) {
+				pv := reflect.ValueOf(cfg.Proxies[daemon])
+				for i, option := range proxyOptions {
+					add(p.name+"."+daemon+"."+option, pv.Field(i).String())
+				}
+			}
+		}
+	}
+	return result
+}
+
+// lookup returns the values set in the configuration for the given key,
+// including the values nested under it.
+func lookup(cfg *configfile.ConfigFile, k key) []entry {
+	s := k.String()
+	var result []entry
+	for _, e := range entries(cfg) {
+		if e.key == s || strings.HasPrefix(e.key, s+".") {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// setValue sets the value of the given key in the configuration.
+func setValue(cfg *configfile.ConfigFile, k key, value string) {
+	f := reflect.ValueOf(cfg).Elem().Field(k.index)
+	switch k.kind {
+	case kindString:
+		f.SetString(value)
+	case kindList:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		f.Set(reflect.ValueOf(list))
+	case kindMap:
+		if f.IsNil() {
+			f.Set(reflect.MakeMap(f.Type()))
+		}
+		f.SetMapIndex(reflect.ValueOf(k.path[0]), reflect.ValueOf(value))
+	case kindNested:
+		if f.IsNil() {
+			f.Set(reflect.MakeMap(f.Type()))
+		}
+		setNestedValue(f.Interface().(map[string]map[string]string), k.path[0], k.path[1], value)
+	case kindProxies:
+		if cfg.Proxies == nil {
+			cfg.Proxies = map[string]configfile.ProxyConfig{}
+		}
+		proxy := cfg.Proxies[k.path[0]]
+		setProxyOption(&proxy, k.path[1], value)
+		if proxy == (configfile.ProxyConfig{}) {
+			delete(cfg.Proxies, k.path[0])
+		} else {
+			cfg.Proxies[k.path[0]] = proxy
+		}
+	}
+}
+
+// setNestedValue sets an option of a group of a map of maps, in the same way
+// as configfile.ConfigFile.SetPluginConfig: setting an option to "" removes
+// it, and removing the last option of a group removes the group.
+func setNestedValue(m map[string]map[string]string, group, option, value string) {
+	if m[group] == nil {
+		m[group] = map[string]string{}
+	}
+	if value != "" {
+		m[group][option] = value
+	} else {
+		delete(m[group], option)
+	}
+	if len(m[group]) == 0 {
+		delete(m, group)
+	}
+}
+
+func setProxyOption(proxy *configfile.ProxyConfig, option, value string) {
+	pv := reflect.ValueOf(proxy).Elem()
+	for i, o := range proxyOptions {
+		if o == option {
+			pv.Field(i).SetString(value)
+		}
+	}
+}
+
+// unsetValue removes the given key, and the values nested under it, from the
+// configuration.
+func unsetValue(cfg *configfile.ConfigFile, k key) {
+	f := reflect.ValueOf(cfg).Elem().Field(k.index)
+	switch {
+	case k.kind == kindString || k.kind == kindList:
+		f.Set(reflect.Zero(f.Type()))
+	case len(k.path) == 0:
+		// keep maps initialized, as done by configfile.New.
+		f.Set(reflect.MakeMap(f.Type()))
+	case len(k.path) == 1:
+		f.SetMapIndex(reflect.ValueOf(k.path[0]), reflect.Value{})
+	default:
+		// setting an option of a plugin, format, or proxy to "" removes it.
+		setValue(cfg, k, "")
+	}
+}
+
+// propertyNames returns the names of the properties that can be managed using
+// the cli-config command.
+func propertyNames() []string {
+	names := make([]string, 0, len(properties))
+	for _, p := range properties {
+		names = append(names, p.name)
+	}
+	return names
+}
+
+// sortedKeys returns the sorted keys of a map with string keys.
+func sortedKeys(m interface{}) []string {
+	mv := reflect.ValueOf(m)
+	keys := make([]string, 0, mv.Len())
+	for _, k := range mv.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
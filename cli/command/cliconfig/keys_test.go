@@ -0,0 +1,105 @@
+package cliconfig
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected []string
+		isValue  bool
+	}{
+		{key: "psFormat", expected: []string{"psFormat"}, isValue: true},
+		{key: "pruneFilters", expected: []string{"pruneFilters"}, isValue: true},
+		{key: "aliases", expected: []string{"aliases"}},
+		{key: "aliases.builder", expected: []string{"aliases", "builder"}, isValue: true},
+		{key: "credHelpers.registry.example.com", expected: []string{"credHelpers", "registry.example.com"}, isValue: true},
+		{key: "plugins.myplugin", expected: []string{"plugins", "myplugin"}},
+		{key: "plugins.myplugin.some.option", expected: []string{"plugins", "myplugin", "some.option"}, isValue: true},
+		{key: "formats.ps", expected: []string{"formats", "ps"}},
+		{key: "formats.ps.ports", expected: []string{"formats", "ps", "ports"}, isValue: true},
+		{key: "proxies.tcp://docker.example.com:2376", expected: []string{"proxies", "tcp://docker.example.com:2376"}},
+		{key: "proxies.tcp://docker.example.com:2376.noProxy", expected: []string{"proxies", "tcp://docker.example.com:2376", "noProxy"}, isValue: true},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.key, func(t *testing.T) {
+			k, err := parseKey(tc.key)
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(append([]string{k.name}, k.path...), tc.expected))
+			assert.Check(t, is.Equal(k.isValue(), tc.isValue))
+			assert.Check(t, is.Equal(k.String(), tc.key))
+		})
+	}
+}
+
+func TestParseKeyErrors(t *testing.T) {
+	tests := []struct {
+		key         string
+		expectedErr string
+	}{
+		{key: "unknown", expectedErr: `unknown configuration key "unknown"`},
+		{key: "Filename", expectedErr: `unknown configuration key "Filename"`},
+		{key: "auths.registry.example.com", expectedErr: `"auths" cannot be managed with this command: use "docker login" and "docker logout" to manage credentials`},
+		{key: "psFormat.foo", expectedErr: `invalid configuration key "psFormat.foo": psFormat does not have nested keys`},
+		{key: "aliases.", expectedErr: `invalid configuration key "aliases."`},
+		{key: "plugins.myplugin.", expectedErr: `invalid configuration key "plugins.myplugin.": expected plugins.<plugin>.<option>`},
+		{key: "formats..ports", expectedErr: `invalid configuration key "formats..ports": expected formats.<command>.<name>`},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.key, func(t *testing.T) {
+			_, err := parseKey(tc.key)
+			assert.Check(t, is.Error(err, tc.expectedErr))
+		})
+	}
+}
+
+func TestSetUnsetValue(t *testing.T) {
+	cfg := configfile.New("config.json")
+	for _, kv := range [][2]string{
+		{"psFormat", "table {{.ID}}"},
+		{"pruneFilters", "label=foo, until=24h"},
+		{"aliases.builder", "buildx"},
+		{"plugins.myplugin.option", "value"},
+		{"formats.ps.ports", "table {{.Names}}\\t{{.Ports}}"},
+		{"proxies.default.httpProxy", "http://proxy:3128"},
+		{"proxies.default.noProxy", "localhost"},
+	} {
+		k, err := parseKey(kv[0])
+		assert.NilError(t, err)
+		setValue(cfg, k, kv[1])
+	}
+	assert.Check(t, is.DeepEqual(entries(cfg), []entry{
+		{key: "psFormat", value: "table {{.ID}}"},
+		{key: "pruneFilters", value: "label=foo,until=24h"},
+		{key: "proxies.default.httpProxy", value: "http://proxy:3128"},
+		{key: "proxies.default.noProxy", value: "localhost"},
+		{key: "plugins.myplugin.option", value: "value"},
+		{key: "aliases.builder", value: "buildx"},
+		{key: "formats.ps.ports", value: "table {{.Names}}\\t{{.Ports}}"},
+	}, cmp.AllowUnexported(entry{})))
+
+	for _, name := range []string{"psFormat", "pruneFilters", "aliases", "plugins.myplugin.option", "formats.ps.ports", "proxies.default.httpProxy"} {
+		k, err := parseKey(name)
+		assert.NilError(t, err)
+		unsetValue(cfg, k)
+	}
+	assert.Check(t, is.DeepEqual(entries(cfg), []entry{
+		{key: "proxies.default.noProxy", value: "localhost"},
+	}, cmp.AllowUnexported(entry{})))
+	assert.Check(t, is.Len(cfg.Plugins, 0))
+	assert.Check(t, is.Len(cfg.Formats, 0))
+	assert.Check(t, cfg.Aliases != nil)
+
+	k, err := parseKey("proxies.default.noProxy")
+	assert.NilError(t, err)
+	unsetValue(cfg, k)
+	assert.Check(t, is.Len(cfg.Proxies, 0))
+}
@@ -0,0 +1,45 @@
+package cliconfig
+
+import (
+	"fmt"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/completion"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	showOrigin bool
+}
+
+func newListCommand(dockerCli command.Cli) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List the configuration keys that are set",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runList(dockerCli, opts)
+			return nil
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+	cmd.Flags().BoolVar(&opts.showOrigin, "show-origin", false, "Show the configuration file each value comes from")
+	return cmd
+}
+
+func runList(dockerCli command.Cli, opts listOptions) {
+	cfg := dockerCli.ConfigFile()
+	for _, e := range entries(cfg) {
+		if opts.showOrigin {
+			origin := "-"
+			if sources := cfg.Sources(e.key); len(sources) > 0 {
+				origin = sources[len(sources)-1].String()
+			}
+			fmt.Fprintf(dockerCli.Out(), "%s\t", origin)
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s=%s\n", e.key, e.value)
+	}
+}
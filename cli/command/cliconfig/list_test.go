@@ -0,0 +1,42 @@
+package cliconfig
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestList(t *testing.T) {
+	cli := newTestCli(t, `{
+	"psFormat": "table {{.ID}}",
+	"credHelpers": {"registry.example.com": "helper"},
+	"aliases": {"builder": "buildx"}
+}`)
+	runList(cli, listOptions{})
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), `psFormat=table {{.ID}}
+credHelpers.registry.example.com=helper
+aliases.builder=buildx
+`))
+}
+
+func TestListShowOrigin(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "config.json")
+	cfg, _, err := configfile.NewLayered([]configfile.Layer{
+		{Name: configfile.LayerSystem, Filename: "/etc/docker/cli-config.json", Data: []byte(`{"proxies": {"default": {"httpProxy": "http://proxy:3128"}}}`)},
+		{Name: configfile.LayerUser, Filename: filename, Data: []byte(`{"psFormat": "table {{.ID}}"}`)},
+		{Name: configfile.LayerProject, Filename: "/project/.dockercli.json", Data: []byte(`{"aliases": {"builder": "buildx"}}`)},
+	})
+	assert.NilError(t, err)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(cfg)
+
+	runList(cli, listOptions{showOrigin: true})
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), `user:`+filename+`	psFormat=table {{.ID}}
+system:/etc/docker/cli-config.json	proxies.default.httpProxy=http://proxy:3128
+project:/project/.dockercli.json	aliases.builder=buildx
+`))
+}
@@ -0,0 +1,116 @@
+package cliconfig
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/cli/config/schema"
+	"github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newSetCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set KEY VALUE",
+		Short: "Set the value of a configuration key",
+		Long: `Set the value of a configuration key.
+
+Values of lists, such as "pruneFilters", are comma-separated.`,
+		Args: cli.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSet(dockerCli, args[0], args[1])
+		},
+		ValidArgsFunction: completeKeys(dockerCli),
+	}
+}
+
+func runSet(dockerCli command.Cli, name, value string) error {
+	k, err := parseKey(name)
+	if err != nil {
+		return err
+	}
+	if !k.isValue() {
+		return errors.Errorf("%s holds a group of values: %s", k, expectedKey(k))
+	}
+	if value == "" {
+		return errors.Errorf("invalid value for %s: value cannot be empty; use \"docker cli-config unset\" to unset it", k)
+	}
+	if err := validateValue(k, value); err != nil {
+		return errors.Wrapf(err, "invalid value for %s", k)
+	}
+	if k.name == "currentContext" && value != command.DefaultContextName {
+		if _, err := dockerCli.ContextStore().GetMetadata(value); err != nil {
+			return err
+		}
+	}
+
+	cfg := dockerCli.ConfigFile()
+	setValue(cfg, k, value)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	if sources := cfg.Sources(k.String()); len(sources) > 0 {
+		if s := sources[len(sources)-1]; s.Layer != configfile.LayerUser {
+			fmt.Fprintf(dockerCli.Err(), "WARNING: %s is overridden by the %s configuration file %s\n", k, s.Layer, s.Filename)
+		}
+	}
+	return nil
+}
+
+// expectedKey describes the keys of the values held by a group.
+func expectedKey(k key) string {
+	switch k.kind {
+	case kindNested:
+		return "expected " + nestedKeys[k.name]
+	case kindProxies:
+		return fmt.Sprintf("expected proxies.<daemon>.<option>, where <option> is one of %s", strings.Join(proxyOptions, ", "))
+	default:
+		return fmt.Sprintf("expected %s.<key>", k.name)
+	}
+}
+
+// validateValue checks that value is valid for the given key.
+func validateValue(k key, value string) error {
+	switch {
+	case strings.HasSuffix(k.name, "Format"), k.name == "formats":
+		return schema.ValidateFormat(value)
+	case k.name == "detachKeys":
+		_, err := term.ToBytes(value)
+		return err
+	case k.name == "experimental":
+		if value != "enabled" && value != "disabled" {
+			return errors.New(`expected "enabled" or "disabled"`)
+		}
+	case k.name == "aliases":
+		return validateAlias(value)
+	case k.name == "credsStore", k.name == "credHelpers":
+		if strings.ContainsAny(value, `/\ `) {
+			return errors.Errorf("expected the name of a credential helper (docker-credential-<name>), got %q", value)
+		}
+	}
+	return nil
+}
+
+// validateAlias checks that value is either a shell command prefixed with
+// "!", or a command and its arguments, quoted as in a shell.
+func validateAlias(value string) error {
+	if strings.HasPrefix(value, "!") {
+		if strings.TrimSpace(value[1:]) == "" {
+			return errors.New("expected a shell command after \"!\"")
+		}
+		return nil
+	}
+	fields, err := shlex.Split(value)
+	if err != nil {
+		return err
+	}
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "-") {
+		return errors.New("expected a command, optionally followed by options and arguments")
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cliconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// newTestCli returns a FakeCli using a configuration file with the given
+// content in a temporary directory.
+func newTestCli(t *testing.T, content string) *test.FakeCli {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "config.json")
+	cfg, _, err := configfile.NewLayered([]configfile.Layer{
+		{Name: configfile.LayerUser, Filename: filename, Data: []byte(content)},
+	})
+	assert.NilError(t, err)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(cfg)
+	return cli
+}
+
+func readConfig(t *testing.T, cli *test.FakeCli) string {
+	t.Helper()
+	data, err := os.ReadFile(cli.ConfigFile().Filename)
+	assert.NilError(t, err)
+	return string(data)
+}
+
+func TestSet(t *testing.T) {
+	cli := newTestCli(t, `{"psFormat": "table {{.ID}}"}`)
+	assert.NilError(t, runSet(cli, "psFormat", "table {{.ID}}\\t{{.Names}}"))
+	assert.NilError(t, runSet(cli, "plugins.myplugin.option", "value"))
+	assert.NilError(t, runSet(cli, "proxies.default.httpProxy", "http://proxy:3128"))
+	assert.NilError(t, runSet(cli, "detachKeys", "ctrl-x,ctrl-y"))
+	assert.NilError(t, runSet(cli, "formats.ps.ports", "table {{.Names}}\\t{{.Ports}}"))
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), ""))
+	assert.Check(t, is.Equal(readConfig(t, cli), `{
+	"auths": {},
+	"psFormat": "table {{.ID}}\\t{{.Names}}",
+	"detachKeys": "ctrl-x,ctrl-y",
+	"proxies": {
+		"default": {
+			"httpProxy": "http://proxy:3128"
+		}
+	},
+	"plugins": {
+		"myplugin": {
+			"option": "value"
+		}
+	},
+	"formats": {
+		"ps": {
+			"ports": "table {{.Names}}\\t{{.Ports}}"
+		}
+	}
+}`))
+}
+
+func TestSetErrors(t *testing.T) {
+	tests := []struct {
+		key, value  string
+		expectedErr string
+	}{
+		{key: "unknown", value: "foo", expectedErr: `unknown configuration key "unknown"`},
+		{key: "aliases", value: "foo", expectedErr: "aliases holds a group of values: expected aliases.<key>"},
+		{key: "plugins.myplugin", value: "foo", expectedErr: "plugins.myplugin holds a group of values: expected plugins.<plugin>.<option>"},
+		{key: "proxies.default", value: "foo", expectedErr: "proxies.default holds a group of values: expected proxies.<daemon>.<option>, where <option> is one of httpProxy, httpsProxy, noProxy, ftpProxy, allProxy"},
+		{key: "psFormat", value: "", expectedErr: `invalid value for psFormat: value cannot be empty; use "docker cli-config unset" to unset it`},
+		{key: "psFormat", value: "table {{.ID", expectedErr: "invalid value for psFormat: template: :1: unclosed action"},
+		{key: "formats.ps", value: "foo", expectedErr: "formats.ps holds a group of values: expected formats.<command>.<name>"},
+		{key: "formats.ps.ports", value: "table {{.Ports", expectedErr: "invalid value for formats.ps.ports: template: :1: unclosed action"},
+		{key: "detachKeys", value: "ctrl-", expectedErr: "invalid value for detachKeys: Unknown character: 'ctrl-'"},
+		{key: "experimental", value: "yes", expectedErr: `invalid value for experimental: expected "enabled" or "disabled"`},
+		{key: "aliases.lsa", value: "--debug ps", expectedErr: "invalid value for aliases.lsa: expected a command, optionally followed by options and arguments"},
+		{key: "aliases.lsa", value: "ps --format 'table", expectedErr: "invalid value for aliases.lsa: EOF found when expecting closing quote"},
+		{key: "aliases.clean", value: "! ", expectedErr: `invalid value for aliases.clean: expected a shell command after "!"`},
+		{key: "credHelpers.registry.example.com", value: "/usr/bin/helper", expectedErr: `invalid value for credHelpers.registry.example.com: expected the name of a credential helper (docker-credential-<name>), got "/usr/bin/helper"`},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.key+"="+tc.value, func(t *testing.T) {
+			cli := newTestCli(t, "")
+			assert.Check(t, is.Error(runSet(cli, tc.key, tc.value), tc.expectedErr))
+			_, err := os.Stat(cli.ConfigFile().Filename)
+			assert.Check(t, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestSetOverridden(t *testing.T) {
+	dir := t.TempDir()
+	cfg, _, err := configfile.NewLayered([]configfile.Layer{
+		{Name: configfile.LayerUser, Filename: filepath.Join(dir, "config.json")},
+		{Name: configfile.LayerProject, Filename: "/project/.dockercli.json", Data: []byte(`{"psFormat": "table {{.Names}}"}`)},
+	})
+	assert.NilError(t, err)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(cfg)
+
+	assert.NilError(t, runSet(cli, "psFormat", "table {{.ID}}"))
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "WARNING: psFormat is overridden by the project configuration file /project/.dockercli.json\n"))
+	assert.Check(t, is.Contains(readConfig(t, cli), `"psFormat": "table {{.ID}}"`))
+}
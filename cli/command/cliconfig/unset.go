@@ -0,0 +1,62 @@
+package cliconfig
+
+import (
+	"fmt"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newUnsetCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unset KEY",
+		Short: "Unset a configuration key",
+		Long: `Unset a configuration key.
+
+If KEY holds a group of values, such as "aliases" or "plugins.<plugin>", all
+values of the group are unset.`,
+		Args: cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUnset(dockerCli, args[0])
+		},
+		ValidArgsFunction: completeKeys(dockerCli),
+	}
+}
+
+func runUnset(dockerCli command.Cli, name string) error {
+	k, err := parseKey(name)
+	if err != nil {
+		return err
+	}
+	cfg := dockerCli.ConfigFile()
+	if len(lookup(cfg, k)) == 0 {
+		return errors.Errorf("%s is not set", k)
+	}
+	sources := cfg.Sources(k.String())
+	if !hasLayer(sources, configfile.LayerUser) && len(sources) > 0 {
+		s := sources[len(sources)-1]
+		return errors.Errorf("%s is not set in %s, but in the %s configuration file %s", k, cfg.Filename, s.Layer, s.Filename)
+	}
+
+	unsetValue(cfg, k)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+	if sources := cfg.Sources(k.String()); len(sources) > 0 {
+		s := sources[len(sources)-1]
+		fmt.Fprintf(dockerCli.Err(), "WARNING: %s is still set in the %s configuration file %s\n", k, s.Layer, s.Filename)
+	}
+	return nil
+}
+
+func hasLayer(sources []configfile.Origin, layer string) bool {
+	for _, s := range sources {
+		if s.Layer == layer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package cliconfig
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestUnset(t *testing.T) {
+	cli := newTestCli(t, `{
+	"psFormat": "table {{.ID}}",
+	"aliases": {"builder": "buildx", "image": "images"},
+	"plugins": {"myplugin": {"a": "1", "b": "2"}}
+}`)
+	assert.NilError(t, runUnset(cli, "psFormat"))
+	assert.NilError(t, runUnset(cli, "aliases.builder"))
+	assert.NilError(t, runUnset(cli, "plugins.myplugin"))
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), ""))
+	assert.Check(t, is.Equal(readConfig(t, cli), `{
+	"auths": {},
+	"aliases": {
+		"image": "images"
+	}
+}`))
+
+	assert.Check(t, is.Error(runUnset(cli, "psFormat"), "psFormat is not set"))
+}
+
+func TestUnsetLayered(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config.json")
+	cfg, _, err := configfile.NewLayered([]configfile.Layer{
+		{Name: configfile.LayerSystem, Filename: "/etc/docker/cli-config.json", Data: []byte(`{"psFormat": "table {{.Names}}", "detachKeys": "ctrl-x"}`)},
+		{Name: configfile.LayerUser, Filename: filename, Data: []byte(`{"psFormat": "table {{.ID}}"}`)},
+	})
+	assert.NilError(t, err)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(cfg)
+
+	err = runUnset(cli, "detachKeys")
+	assert.Check(t, is.Error(err, "detachKeys is not set in "+filename+", but in the system configuration file /etc/docker/cli-config.json"))
+
+	assert.NilError(t, runUnset(cli, "psFormat"))
+	assert.Check(t, is.Equal(cli.ErrBuffer().String(), "WARNING: psFormat is still set in the system configuration file /etc/docker/cli-config.json\n"))
+	assert.Check(t, is.Equal(readConfig(t, cli), `{
+	"auths": {}
+}`))
+}
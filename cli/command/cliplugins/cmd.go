@@ -0,0 +1,42 @@
+package cliplugins
+
+import (
+	"sort"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCLIPluginsCommand returns the cli-plugins cli subcommand
+func NewCLIPluginsCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cli-plugins",
+		Short: "Manage CLI plugins",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newInstallCommand(dockerCli),
+		newListCommand(dockerCli),
+		newRefreshCommand(dockerCli),
+		newRemoveCommand(dockerCli),
+		newUpgradeCommand(dockerCli),
+	)
+	return cmd
+}
+
+// completeInstalled completes the names of the plugins that were installed
+// with "docker cli-plugins install".
+func completeInstalled(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	m, err := loadManifest()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	names := make([]string, 0, len(m.Plugins))
+	for name := range m.Plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,113 @@
+package cliplugins
+
+import (
+	"github.com/harness-community/docker-cli-v23/cli-plugins/manager"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+)
+
+const (
+	defaultPluginTableFormat = "table {{.Name}}\t{{.Version}}\t{{.Source}}\t{{.Path}}"
+	quietPluginFormat        = "{{.Name}}"
+
+	versionHeader = "VERSION"
+	vendorHeader  = "VENDOR"
+	sourceHeader  = "SOURCE"
+	pathHeader    = "PATH"
+
+	maxErrLength = 45
+)
+
+// pluginEntry is a CLI plugin, and the source it was installed from with
+// "docker cli-plugins install", if any.
+type pluginEntry struct {
+	manager.Plugin
+	Source string
+}
+
+// newFormat returns a Format for rendering CLI plugins.
+func newFormat(source string, quiet bool) formatter.Format {
+	if quiet {
+		return quietPluginFormat
+	}
+	switch source {
+	case "", formatter.TableFormatKey:
+		return defaultPluginTableFormat
+	}
+	return formatter.Format(source)
+}
+
+// formatWrite writes formatted CLI plugins using the Context
+func formatWrite(ctx formatter.Context, plugins []pluginEntry) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, p := range plugins {
+			if err := format(&pluginContext{trunc: ctx.Trunc, p: p}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	pluginCtx := pluginContext{}
+	pluginCtx.Header = formatter.SubHeaderContext{
+		"Name":        formatter.NameHeader,
+		"Version":     versionHeader,
+		"Vendor":      vendorHeader,
+		"Description": formatter.DescriptionHeader,
+		"Source":      sourceHeader,
+		"Path":        pathHeader,
+		"Error":       formatter.ErrorHeader,
+	}
+	pluginCtx.Priorities = formatter.ColumnPriorities{
+		"Description": 1,
+		"Error":       1,
+		"Path":        2,
+		"Source":      2,
+	}
+	return ctx.Write(&pluginCtx, render)
+}
+
+type pluginContext struct {
+	formatter.HeaderContext
+	trunc bool
+	p     pluginEntry
+}
+
+func (c *pluginContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *pluginContext) Name() string {
+	return c.p.Name
+}
+
+func (c *pluginContext) Version() string {
+	return c.p.Version
+}
+
+func (c *pluginContext) Vendor() string {
+	return c.p.Vendor
+}
+
+func (c *pluginContext) Description() string {
+	return c.p.ShortDescription
+}
+
+// Source returns the URL or file the plugin was installed from, or an empty
+// string if it was not installed with "docker cli-plugins install".
+func (c *pluginContext) Source() string {
+	return c.p.Source
+}
+
+func (c *pluginContext) Path() string {
+	return c.p.Path
+}
+
+// Error returns the (truncated) reason the plugin is not valid, if any.
+func (c *pluginContext) Error() string {
+	if c.p.Err == nil {
+		return ""
+	}
+	if c.trunc {
+		return formatter.Ellipsis(c.p.Err.Error(), maxErrLength)
+	}
+	return c.p.Err.Error()
+}
@@ -0,0 +1,219 @@
+package cliplugins
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli-plugins/manager"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type installOptions struct {
+	source string
+	name   string
+	sha256 string
+	force  bool
+}
+
+func newInstallCommand(dockerCli command.Cli) *cobra.Command {
+	var opts installOptions
+	cmd := &cobra.Command{
+		Use:   "install [OPTIONS] URL|FILE",
+		Short: "Install a CLI plugin",
+		Args:  cli.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.source = args[0]
+			return runInstall(dockerCli, cmd.Root(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.name, "name", "", "Name of the plugin (default: derived from the name of the executable)")
+	flags.StringVar(&opts.sha256, "sha256", "", "Expected SHA-256 checksum of the plugin executable")
+	flags.BoolVarP(&opts.force, "force", "f", false, "Replace the plugin if it is already installed")
+	return cmd
+}
+
+func runInstall(dockerCli command.Cli, rootcmd *cobra.Command, opts installOptions) error {
+	name := opts.name
+	if name == "" {
+		var ok bool
+		if name, ok = nameFromSource(opts.source); !ok {
+			return errors.Errorf("cannot determine the name of the plugin from %q: use --name to specify it", opts.source)
+		}
+	}
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+	if !opts.force {
+		if _, ok := m.Plugins[name]; ok {
+			return errors.Errorf("plugin %q is already installed: use \"docker cli-plugins upgrade\" to upgrade it", name)
+		}
+		if dir, err := manager.UserPluginDir(); err == nil {
+			if _, err := os.Stat(filepath.Join(dir, manager.FileName(name))); err == nil {
+				return errors.Errorf("plugin %q is already installed in %s: use --force to replace it", name, dir)
+			}
+		}
+	}
+
+	entry, err := installPlugin(context.Background(), rootcmd, name, opts.source, opts.sha256)
+	if err != nil {
+		return err
+	}
+	m.Plugins[name] = entry
+	if err := m.save(); err != nil {
+		return err
+	}
+	if entry.Version != "" {
+		fmt.Fprintf(dockerCli.Out(), "Installed plugin %q (%s)\n", name, entry.Version)
+	} else {
+		fmt.Fprintf(dockerCli.Out(), "Installed plugin %q\n", name)
+	}
+	return nil
+}
+
+// installPlugin installs the plugin executable at source, which is a URL or
+// a path to a local file, as the plugin name in the plugin directory of the
+// user. The executable is verified against the expected SHA-256 checksum, if
+// any, and validated as a plugin before it replaces any existing plugin of
+// the same name.
+func installPlugin(ctx context.Context, rootcmd *cobra.Command, name, source, expectedSHA256 string) (manifestEntry, error) {
+	expectedSHA256, err := parseSHA256(expectedSHA256)
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	if !isURL(source) {
+		if source, err = filepath.Abs(source); err != nil {
+			return manifestEntry{}, err
+		}
+	}
+	if name != filepath.Base(name) {
+		return manifestEntry{}, errors.Errorf("invalid plugin name %q", name)
+	}
+
+	pluginDir, err := manager.UserPluginDir()
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
+		return manifestEntry{}, err
+	}
+	// The executable is staged in the plugin directory, so that it can be
+	// moved into place atomically, but in a sub-directory, so that it is
+	// not picked up as a plugin before it is validated.
+	stagingDir, err := os.MkdirTemp(pluginDir, ".install-")
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	staged := filepath.Join(stagingDir, manager.FileName(name))
+	digest, err := fetch(ctx, source, staged)
+	if err != nil {
+		return manifestEntry{}, err
+	}
+	if expectedSHA256 != "" && digest != expectedSHA256 {
+		return manifestEntry{}, errors.Errorf("checksum mismatch for %s: expected sha256 %s, got %s", source, expectedSHA256, digest)
+	}
+	p, err := manager.ValidateCandidate(staged, rootcmd)
+	if err != nil {
+		return manifestEntry{}, errors.Wrapf(err, "%s is not a valid CLI plugin", source)
+	}
+	if err := os.Rename(staged, filepath.Join(pluginDir, manager.FileName(name))); err != nil {
+		return manifestEntry{}, err
+	}
+	return manifestEntry{
+		Version:     p.Version,
+		Source:      source,
+		SHA256:      digest,
+		InstalledAt: time.Now().UTC(),
+	}, nil
+}
+
+// fetch copies the executable at source to target, and returns its SHA-256
+// checksum.
+func fetch(ctx context.Context, source, target string) (string, error) {
+	var r io.ReadCloser
+	if isURL(source) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("User-Agent", command.UserAgent())
+		httpClient := &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to download %s", source)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return "", errors.Errorf("failed to download %s: %s", source, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return "", err
+		}
+		r = f
+	}
+	defer r.Close()
+
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o755)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(f, h), r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to copy %s", source)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func isURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// nameFromSource returns the name of the plugin provided by the executable at
+// source, based on its file name.
+func nameFromSource(source string) (string, bool) {
+	if isURL(source) {
+		u, err := url.Parse(source)
+		if err != nil {
+			return "", false
+		}
+		return manager.NameFromFileName(path.Base(u.Path))
+	}
+	return manager.NameFromFileName(source)
+}
+
+// parseSHA256 validates and normalizes a hex-encoded SHA-256 checksum, which
+// may have a "sha256:" prefix.
+func parseSHA256(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	s = strings.ToLower(strings.TrimPrefix(s, "sha256:"))
+	if b, err := hex.DecodeString(s); err != nil || len(b) != sha256.Size {
+		return "", errors.Errorf("invalid SHA-256 checksum %q", s)
+	}
+	return s, nil
+}
@@ -0,0 +1,120 @@
+package cliplugins
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func pluginScript(version string) string {
+	return `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing","Version":"` + version + `"}'`
+}
+
+func sha256Hex(s string) string {
+	digest := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(digest[:])
+}
+
+// setupPluginServer sets up a configuration directory for the test, and
+// serves the files in a temporary directory over HTTP.
+func setupPluginServer(t *testing.T, ops ...fs.PathOp) (*test.FakeCli, *fs.Dir, *httptest.Server) {
+	t.Helper()
+	config.SetDir(t.TempDir())
+	dir := fs.NewDir(t, t.Name(), ops...)
+	server := httptest.NewServer(http.FileServer(http.Dir(dir.Path())))
+	t.Cleanup(server.Close)
+
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(configfile.New(filepath.Join(config.Dir(), "config.json")))
+	return cli, dir, server
+}
+
+func installedPath(name string) string {
+	return filepath.Join(config.Dir(), "cli-plugins", "docker-"+name)
+}
+
+func TestInstall(t *testing.T) {
+	script := pluginScript("v1.0.0")
+	cli, _, server := setupPluginServer(t, fs.WithFile("docker-hello", script))
+
+	source := server.URL + "/docker-hello"
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: source, sha256: sha256Hex(script)}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "Installed plugin \"hello\" (v1.0.0)\n"))
+
+	fi, err := os.Stat(installedPath("hello"))
+	assert.NilError(t, err)
+	assert.Check(t, fi.Mode()&0o100 != 0, "plugin is not executable")
+
+	m, err := loadManifest()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(m.Plugins["hello"].Version, "v1.0.0"))
+	assert.Check(t, is.Equal(m.Plugins["hello"].Source, source))
+	assert.Check(t, is.Equal(m.Plugins["hello"].SHA256, sha256Hex(script)))
+
+	err = runInstall(cli, &cobra.Command{}, installOptions{source: source})
+	assert.Check(t, is.Error(err, `plugin "hello" is already installed: use "docker cli-plugins upgrade" to upgrade it`))
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: source, force: true}))
+}
+
+func TestInstallFromFile(t *testing.T) {
+	cli, dir, _ := setupPluginServer(t, fs.WithFile("hello-linux-amd64", pluginScript("v1.0.0")))
+
+	source := dir.Join("hello-linux-amd64")
+	err := runInstall(cli, &cobra.Command{}, installOptions{source: source})
+	assert.Check(t, is.Error(err, `cannot determine the name of the plugin from "`+source+`": use --name to specify it`))
+
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: source, name: "hello"}))
+	_, err = os.Stat(installedPath("hello"))
+	assert.NilError(t, err)
+}
+
+func TestInstallChecksumMismatch(t *testing.T) {
+	cli, _, server := setupPluginServer(t, fs.WithFile("docker-hello", pluginScript("v1.0.0")))
+
+	source := server.URL + "/docker-hello"
+	err := runInstall(cli, &cobra.Command{}, installOptions{source: source, sha256: sha256Hex("something else")})
+	assert.Check(t, is.ErrorContains(err, "checksum mismatch for "+source))
+	_, err = os.Stat(installedPath("hello"))
+	assert.Check(t, os.IsNotExist(err))
+
+	err = runInstall(cli, &cobra.Command{}, installOptions{source: source, sha256: "abc"})
+	assert.Check(t, is.Error(err, `invalid SHA-256 checksum "abc"`))
+}
+
+func TestInstallInvalidPlugin(t *testing.T) {
+	cli, _, server := setupPluginServer(t,
+		fs.WithFile("docker-novendor", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0"}'`),
+		fs.WithFile("docker-builtin", pluginScript("v1.0.0")),
+	)
+	rootcmd := &cobra.Command{}
+	rootcmd.AddCommand(&cobra.Command{Use: "builtin"})
+
+	err := runInstall(cli, rootcmd, installOptions{source: server.URL + "/docker-novendor"})
+	assert.Check(t, is.ErrorContains(err, "plugin metadata does not define a vendor"))
+	_, err = os.Stat(installedPath("novendor"))
+	assert.Check(t, os.IsNotExist(err))
+
+	err = runInstall(cli, rootcmd, installOptions{source: server.URL + "/docker-builtin"})
+	assert.Check(t, is.ErrorContains(err, `plugin "builtin" duplicates builtin command`))
+
+	err = runInstall(cli, rootcmd, installOptions{source: server.URL + "/docker-missing"})
+	assert.Check(t, is.ErrorContains(err, "404 Not Found"))
+
+	m, err := loadManifest()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(m.Plugins, 0))
+}
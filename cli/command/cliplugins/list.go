@@ -0,0 +1,87 @@
+package cliplugins
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli-plugins/manager"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/completion"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	format string
+	sort   string
+	wide   bool
+	quiet  bool
+}
+
+func newListCommand(dockerCli command.Cli) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List CLI plugins",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, cmd.Root(), opts)
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.wide, "wide", false, flagsHelper.WideHelp)
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only show plugin names")
+	return cmd
+}
+
+func runList(dockerCli command.Cli, rootcmd *cobra.Command, opts listOptions) error {
+	plugins, err := manager.ListPlugins(dockerCli, rootcmd)
+	if err != nil {
+		return err
+	}
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+	pluginDir, err := manager.UserPluginDir()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]pluginEntry, 0, len(plugins))
+	for _, p := range plugins {
+		e := pluginEntry{Plugin: p}
+		// Only the manifest entries of plugins in the plugin directory of
+		// the user apply, as it is where plugins are installed to.
+		if mp, ok := m.Plugins[p.Name]; ok && sameFile(p.Path, pluginDir, p.Name) {
+			e.Source = mp.Source
+		}
+		entries = append(entries, e)
+	}
+	pluginCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: newFormat(opts.format, opts.quiet),
+		Sort:   opts.sort,
+		Wide:   opts.wide,
+	}
+	return formatWrite(pluginCtx, entries)
+}
+
+func sameFile(path, pluginDir, name string) bool {
+	fi1, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	fi2, err := os.Stat(filepath.Join(pluginDir, manager.FileName(name)))
+	if err != nil {
+		return false
+	}
+	return os.SameFile(fi1, fi2)
+}
@@ -0,0 +1,21 @@
+package cliplugins
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestList(t *testing.T) {
+	cli, _, server := setupPluginServer(t, fs.WithFile("docker-hello", pluginScript("v1.0.0")))
+	source := server.URL + "/docker-hello"
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: source}))
+	cli.OutBuffer().Reset()
+
+	// other plugins may be installed on the system, so only look at ours
+	assert.NilError(t, runList(cli, &cobra.Command{}, listOptions{format: `{{if eq .Name "hello"}}{{.Name}} {{.Version}} {{.Source}} {{.Path}}{{end}}`}))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "hello v1.0.0 "+source+" "+installedPath("hello")))
+}
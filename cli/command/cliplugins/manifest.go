@@ -0,0 +1,64 @@
+package cliplugins
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-v23/pkg/ioutils"
+	"github.com/pkg/errors"
+)
+
+// manifestFileName is the name of the file, in the configuration directory,
+// that records the plugins installed with "docker cli-plugins install".
+const manifestFileName = "cli-plugins-manifest.json"
+
+// manifestEntry records where an installed plugin was installed from.
+type manifestEntry struct {
+	Version     string    `json:"version,omitempty"`
+	Source      string    `json:"source"`
+	SHA256      string    `json:"sha256"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+type manifest struct {
+	path    string
+	Plugins map[string]manifestEntry `json:"plugins"`
+}
+
+// loadManifest loads the manifest of installed plugins. A missing manifest
+// results in an empty manifest.
+func loadManifest() (*manifest, error) {
+	path, err := config.Path(manifestFileName)
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{path: path, Plugins: map[string]manifestEntry{}}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return m, nil
+		}
+		return nil, errors.Wrap(err, "failed to read the manifest of installed plugins")
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest of installed plugins %s", path)
+	}
+	if m.Plugins == nil {
+		m.Plugins = map[string]manifestEntry{}
+	}
+	return m, nil
+}
+
+func (m *manifest) save() error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o700); err != nil {
+		return err
+	}
+	return errors.Wrap(ioutils.AtomicWriteFile(m.path, data, 0o644), "failed to write the manifest of installed plugins")
+}
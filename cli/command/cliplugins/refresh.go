@@ -0,0 +1,40 @@
+package cliplugins
+
+import (
+	"fmt"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli-plugins/manager"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/completion"
+	"github.com/spf13/cobra"
+)
+
+func newRefreshCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "refresh",
+		Short: "Rebuild the cached metadata of CLI plugins",
+		Args:  cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRefresh(dockerCli, cmd.Root())
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+}
+
+func runRefresh(dockerCli command.Cli, rootcmd *cobra.Command) error {
+	plugins, err := manager.RefreshMetadataCache(dockerCli, rootcmd)
+	if err != nil {
+		return err
+	}
+	var valid int
+	for _, p := range plugins {
+		if p.Err != nil {
+			fmt.Fprintf(dockerCli.Err(), "WARNING: Plugin %q is not valid: %s\n", p.Path, p.Err)
+			continue
+		}
+		valid++
+	}
+	fmt.Fprintf(dockerCli.Out(), "Refreshed the metadata of %d CLI plugins\n", valid)
+	return nil
+}
@@ -0,0 +1,35 @@
+package cliplugins
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestRefresh(t *testing.T) {
+	configDir := t.TempDir()
+	config.SetDir(configDir)
+	dir := fs.NewDir(t, t.Name(),
+		fs.WithFile("docker-refreshtest", `#!/bin/sh
+echo '{"SchemaVersion":"0.1.0","Vendor":"e2e-testing"}'`, fs.WithMode(0o777)),
+	)
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(&configfile.ConfigFile{CLIPluginsExtraDirs: []string{dir.Path()}})
+
+	cacheFile := filepath.Join(configDir, "cli-plugins-metadata.json")
+	assert.NilError(t, os.WriteFile(cacheFile, []byte("stale"), 0o644))
+	assert.NilError(t, runRefresh(cli, &cobra.Command{}))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "Refreshed the metadata of"))
+
+	data, err := os.ReadFile(cacheFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Contains(string(data), dir.Join("docker-refreshtest")))
+}
@@ -0,0 +1,65 @@
+package cliplugins
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli-plugins/manager"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newRemoveCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:     "rm PLUGIN [PLUGIN...]",
+		Aliases: []string{"remove"},
+		Short:   "Remove one or more installed CLI plugins",
+		Args:    cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(dockerCli, args)
+		},
+		ValidArgsFunction: completeInstalled,
+	}
+}
+
+// runRemove removes the plugins that were installed with "docker cli-plugins
+// install". Other plugins are not managed by the CLI, and are left alone.
+func runRemove(dockerCli command.Cli, names []string) error {
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+	pluginDir, err := manager.UserPluginDir()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	var removed bool
+	for _, name := range names {
+		if _, ok := m.Plugins[name]; !ok {
+			errs = append(errs, fmt.Sprintf("plugin %q was not installed with \"docker cli-plugins install\"", name))
+			continue
+		}
+		if err := os.Remove(filepath.Join(pluginDir, manager.FileName(name))); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err.Error())
+			continue
+		}
+		delete(m.Plugins, name)
+		removed = true
+		fmt.Fprintln(dockerCli.Out(), name)
+	}
+	if removed {
+		if err := m.save(); err != nil {
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package cliplugins
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestRemove(t *testing.T) {
+	cli, _, server := setupPluginServer(t, fs.WithFile("docker-hello", pluginScript("v1.0.0")))
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: server.URL + "/docker-hello"}))
+	cli.OutBuffer().Reset()
+
+	err := runRemove(cli, []string{"hello", "other"})
+	assert.Check(t, is.Error(err, `plugin "other" was not installed with "docker cli-plugins install"`))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "hello\n"))
+
+	_, err = os.Stat(installedPath("hello"))
+	assert.Check(t, os.IsNotExist(err))
+	m, err := loadManifest()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(m.Plugins, 0))
+}
@@ -0,0 +1,82 @@
+package cliplugins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type upgradeOptions struct {
+	name   string
+	source string
+	sha256 string
+}
+
+func newUpgradeCommand(dockerCli command.Cli) *cobra.Command {
+	var opts upgradeOptions
+	cmd := &cobra.Command{
+		Use:   "upgrade [OPTIONS] PLUGIN [URL|FILE]",
+		Short: "Upgrade an installed CLI plugin",
+		Long:  "Upgrade an installed CLI plugin from the source it was installed from, or from a new source",
+		Args:  cli.RequiresRangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.name = args[0]
+			if len(args) > 1 {
+				opts.source = args[1]
+			}
+			return runUpgrade(dockerCli, cmd.Root(), opts)
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				// complete the new source as a file
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return completeInstalled(cmd, args, toComplete)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.sha256, "sha256", "", "Expected SHA-256 checksum of the plugin executable")
+	return cmd
+}
+
+func runUpgrade(dockerCli command.Cli, rootcmd *cobra.Command, opts upgradeOptions) error {
+	m, err := loadManifest()
+	if err != nil {
+		return err
+	}
+	current, ok := m.Plugins[opts.name]
+	if !ok {
+		return errors.Errorf("plugin %q was not installed with \"docker cli-plugins install\"", opts.name)
+	}
+	source := opts.source
+	if source == "" {
+		source = current.Source
+	}
+
+	entry, err := installPlugin(context.Background(), rootcmd, opts.name, source, opts.sha256)
+	if err != nil {
+		return err
+	}
+	if entry.SHA256 == current.SHA256 && entry.Source == current.Source {
+		fmt.Fprintf(dockerCli.Out(), "Plugin %q is up to date\n", opts.name)
+		return nil
+	}
+	m.Plugins[opts.name] = entry
+	if err := m.save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(dockerCli.Out(), "Upgraded plugin %q from %s to %s\n", opts.name, versionOrUnknown(current.Version), versionOrUnknown(entry.Version))
+	return nil
+}
+
+func versionOrUnknown(version string) string {
+	if version == "" {
+		return "an unknown version"
+	}
+	return version
+}
@@ -0,0 +1,56 @@
+package cliplugins
+
+import (
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+func TestUpgrade(t *testing.T) {
+	cli, dir, server := setupPluginServer(t, fs.WithFile("docker-hello", pluginScript("v1.0.0")))
+	source := server.URL + "/docker-hello"
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: source}))
+	cli.OutBuffer().Reset()
+
+	assert.NilError(t, runUpgrade(cli, &cobra.Command{}, upgradeOptions{name: "hello"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "Plugin \"hello\" is up to date\n"))
+	cli.OutBuffer().Reset()
+
+	// a new version is published at the same URL
+	assert.NilError(t, os.WriteFile(dir.Join("docker-hello"), []byte(pluginScript("v1.1.0")), 0o644))
+	assert.NilError(t, runUpgrade(cli, &cobra.Command{}, upgradeOptions{name: "hello"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "Upgraded plugin \"hello\" from v1.0.0 to v1.1.0\n"))
+
+	m, err := loadManifest()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(m.Plugins["hello"].Version, "v1.1.0"))
+	assert.Check(t, is.Equal(m.Plugins["hello"].SHA256, sha256Hex(pluginScript("v1.1.0"))))
+}
+
+func TestUpgradeNewSource(t *testing.T) {
+	cli, _, server := setupPluginServer(t,
+		fs.WithFile("docker-hello", pluginScript("v1.0.0")),
+		fs.WithFile("docker-hello-v2", pluginScript("v2.0.0")),
+	)
+	assert.NilError(t, runInstall(cli, &cobra.Command{}, installOptions{source: server.URL + "/docker-hello"}))
+
+	err := runUpgrade(cli, &cobra.Command{}, upgradeOptions{name: "hello", source: server.URL + "/docker-hello-v2", sha256: sha256Hex("something else")})
+	assert.Check(t, is.ErrorContains(err, "checksum mismatch"))
+
+	source := server.URL + "/docker-hello-v2"
+	assert.NilError(t, runUpgrade(cli, &cobra.Command{}, upgradeOptions{name: "hello", source: source}))
+	m, err := loadManifest()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(m.Plugins["hello"].Version, "v2.0.0"))
+	assert.Check(t, is.Equal(m.Plugins["hello"].Source, source))
+}
+
+func TestUpgradeNotInstalled(t *testing.T) {
+	cli, _, _ := setupPluginServer(t)
+	err := runUpgrade(cli, &cobra.Command{}, upgradeOptions{name: "hello"})
+	assert.Check(t, is.Error(err, `plugin "hello" was not installed with "docker cli-plugins install"`))
+}
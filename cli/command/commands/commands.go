@@ -6,9 +6,12 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command"
 	"github.com/harness-community/docker-cli-v23/cli/command/builder"
 	"github.com/harness-community/docker-cli-v23/cli/command/checkpoint"
+	"github.com/harness-community/docker-cli-v23/cli/command/cliconfig"
+	"github.com/harness-community/docker-cli-v23/cli/command/cliplugins"
 	"github.com/harness-community/docker-cli-v23/cli/command/config"
 	"github.com/harness-community/docker-cli-v23/cli/command/container"
 	"github.com/harness-community/docker-cli-v23/cli/command/context"
+	"github.com/harness-community/docker-cli-v23/cli/command/credentials"
 	"github.com/harness-community/docker-cli-v23/cli/command/image"
 	"github.com/harness-community/docker-cli-v23/cli/command/manifest"
 	"github.com/harness-community/docker-cli-v23/cli/command/network"
@@ -45,8 +48,11 @@ func AddCommands(cmd *cobra.Command, dockerCli command.Cli) {
 		// management commands
 		builder.NewBuilderCommand(dockerCli),
 		checkpoint.NewCheckpointCommand(dockerCli),
+		cliconfig.NewCLIConfigCommand(dockerCli),
+		cliplugins.NewCLIPluginsCommand(dockerCli),
 		container.NewContainerCommand(dockerCli),
 		context.NewContextCommand(dockerCli),
+		credentials.NewCredentialsCommand(dockerCli),
 		image.NewImageCommand(dockerCli),
 		manifest.NewManifestCommand(dockerCli),
 		network.NewNetworkCommand(dockerCli),
@@ -19,6 +19,8 @@ import (
 type ListOptions struct {
 	Quiet  bool
 	Format string
+	Sort   string
+	Wide   bool
 	Filter opts.FilterOpt
 }
 
@@ -38,7 +40,9 @@ func newConfigListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&listOpts.Quiet, "quiet", "q", false, "Only display IDs")
-	flags.StringVarP(&listOpts.Format, "format", "", "", flagsHelper.FormatHelp)
+	flags.StringVarP(&listOpts.Format, "format", "", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&listOpts.Sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&listOpts.Wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&listOpts.Filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
@@ -46,6 +50,15 @@ func newConfigListCommand(dockerCli command.Cli) *cobra.Command {
 
 // RunConfigList lists Swarm configs.
 func RunConfigList(dockerCli command.Cli, options ListOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().ConfigFormat) > 0 && !options.Quiet {
+		defaultFormat = dockerCli.ConfigFile().ConfigFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "config", options.Format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -54,15 +67,6 @@ func RunConfigList(dockerCli command.Cli, options ListOptions) error {
 		return err
 	}
 
-	format := options.Format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().ConfigFormat) > 0 && !options.Quiet {
-			format = dockerCli.ConfigFile().ConfigFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	sort.Slice(configs, func(i, j int) bool {
 		return sortorder.NaturalLess(configs[i].Spec.Name, configs[j].Spec.Name)
 	})
@@ -70,6 +74,8 @@ func RunConfigList(dockerCli command.Cli, options ListOptions) error {
 	configCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(format, options.Quiet),
+		Sort:   options.Sort,
+		Wide:   options.Wide,
 	}
 	return FormatWrite(configCtx, configs)
 }
@@ -124,7 +124,7 @@ func pullImage(ctx context.Context, dockerCli command.Cli, image string, platfor
 		return err
 	}
 
-	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
+	authConfig := command.ResolveRepositoryAuthConfig(ctx, dockerCli, repoInfo)
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
 		return err
@@ -25,6 +25,8 @@ type psOptions struct {
 	nLatest     bool
 	last        int
 	format      string
+	sort        string
+	wide        bool
 	filter      opts.FilterOpt
 }
 
@@ -41,8 +43,9 @@ func NewPsCommand(dockerCli command.Cli) *cobra.Command {
 			return runPs(dockerCli, &options)
 		},
 		Annotations: map[string]string{
-			"category-top": "3",
-			"aliases":      "docker container ls, docker container list, docker container ps, docker ps",
+			"category-top":                 "3",
+			"aliases":                      "docker container ls, docker container list, docker container ps, docker ps",
+			command.MultiContextAnnotation: "",
 		},
 		ValidArgsFunction: completion.NoComplete,
 	}
@@ -55,7 +58,9 @@ func NewPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.BoolVarP(&options.nLatest, "latest", "l", false, "Show the latest created container (includes all states)")
 	flags.IntVarP(&options.last, "last", "n", -1, "Show n last created containers (includes all states)")
-	flags.StringVarP(&options.format, "format", "", "", flagsHelper.FormatHelp)
+	flags.StringVarP(&options.format, "format", "", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
@@ -117,10 +122,12 @@ func buildContainerListOptions(opts *psOptions) (*types.ContainerListOptions, er
 func runPs(dockerCli command.Cli, options *psOptions) error {
 	ctx := context.Background()
 
-	if len(options.format) == 0 {
-		// load custom psFormat from CLI config (if any)
-		options.format = dockerCli.ConfigFile().PsFormat
+	// fall back to the custom psFormat from CLI config (if any)
+	format, ok, err := command.ResolveFormat(dockerCli, "ps", options.format, dockerCli.ConfigFile().PsFormat)
+	if !ok {
+		return err
 	}
+	options.format = format
 
 	listOptions, err := buildContainerListOptions(options)
 	if err != nil {
@@ -135,6 +142,8 @@ func runPs(dockerCli command.Cli, options *psOptions) error {
 	containerCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: formatter.NewContainerFormat(options.format, options.quiet, listOptions.Size),
+		Sort:   options.sort,
+		Wide:   options.wide || options.noTrunc,
 		Trunc:  !options.noTrunc,
 	}
 	return formatter.ContainerWrite(containerCtx, containers)
@@ -300,6 +300,26 @@ func TestContainerListWithConfigFormat(t *testing.T) {
 	golden.Assert(t, cli.OutBuffer().String(), "container-list-with-config-format.golden")
 }
 
+func TestContainerListWithConfigFormatPreset(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		containerListFunc: func(_ types.ContainerListOptions) ([]types.Container, error) {
+			return []types.Container{
+				*Container("c1", WithLabel("some.label", "value"), WithSize(10700000)),
+				*Container("c2", WithName("foo/bar"), WithLabel("foo", "bar"), WithSize(3200000)),
+			}, nil
+		},
+	})
+	cli.SetConfigFile(&configfile.ConfigFile{
+		PsFormat: "@labels",
+		Formats: map[string]map[string]string{
+			"ps": {"labels": "{{ .Names }} {{ .Image }} {{ .Labels }} {{ .Size}}"},
+		},
+	})
+	cmd := newListCommand(cli)
+	assert.NilError(t, cmd.Execute())
+	golden.Assert(t, cli.OutBuffer().String(), "container-list-with-config-format.golden")
+}
+
 func TestContainerListWithFormat(t *testing.T) {
 	cli := test.NewFakeCli(&fakeClient{
 		containerListFunc: func(_ types.ContainerListOptions) ([]types.Container, error) {
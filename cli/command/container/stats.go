@@ -50,7 +50,7 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.all, "all", "a", false, "Show all containers (default shows just running)")
 	flags.BoolVar(&opts.noStream, "no-stream", false, "Disable streaming stats and only pull the first result")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Do not truncate output")
-	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatPresetHelp)
 	return cmd
 }
 
@@ -59,6 +59,15 @@ func NewStatsCommand(dockerCli command.Cli) *cobra.Command {
 //
 //nolint:gocyclo
 func runStats(dockerCli command.Cli, opts *statsOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().StatsFormat) > 0 {
+		defaultFormat = dockerCli.ConfigFile().StatsFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "stats", opts.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	showAll := len(opts.containers) == 0
 	closeChan := make(chan error)
 
@@ -200,14 +209,6 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 		}
 	}
 
-	format := opts.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().StatsFormat) > 0 {
-			format = dockerCli.ConfigFile().StatsFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
 	statsCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewStatsFormat(format, daemonOSType),
@@ -219,7 +220,6 @@ func runStats(dockerCli command.Cli, opts *statsOptions) error {
 		}
 	}
 
-	var err error
 	ticker := time.NewTicker(500 * time.Millisecond)
 	defer ticker.Stop()
 	for range ticker.C {
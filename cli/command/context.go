@@ -3,6 +3,7 @@ package command
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/harness-community/docker-cli-v23/cli/context/store"
 )
@@ -10,6 +11,7 @@ import (
 // DockerContext is a typed representation of what we put in Context metadata
 type DockerContext struct {
 	Description      string
+	Labels           map[string]string
 	AdditionalFields map[string]interface{}
 }
 
@@ -19,6 +21,9 @@ func (dc DockerContext) MarshalJSON() ([]byte, error) {
 	if dc.Description != "" {
 		s["Description"] = dc.Description
 	}
+	if len(dc.Labels) > 0 {
+		s["Labels"] = dc.Labels
+	}
 	if dc.AdditionalFields != nil {
 		for k, v := range dc.AdditionalFields {
 			s[k] = v
@@ -37,6 +42,17 @@ func (dc *DockerContext) UnmarshalJSON(payload []byte) error {
 		switch k {
 		case "Description":
 			dc.Description = v.(string)
+		case "Labels":
+			labels, ok := v.(map[string]interface{})
+			if !ok {
+				return errors.New("context labels must be a map of strings")
+			}
+			dc.Labels = make(map[string]string, len(labels))
+			for lk, lv := range labels {
+				if dc.Labels[lk], ok = lv.(string); !ok {
+					return fmt.Errorf("context label %q must be a string", lk)
+				}
+			}
 		default:
 			if dc.AdditionalFields == nil {
 				dc.AdditionalFields = make(map[string]interface{})
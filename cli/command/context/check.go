@@ -0,0 +1,86 @@
+package context
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"github.com/pkg/errors"
+)
+
+const defaultCheckTimeout = 5 * time.Second
+
+// checkEndpoints concurrently checks the docker endpoint of each context, and
+// stores the result in the context's Check field. Contexts that failed to load
+// are skipped. Errors are reported per context, and never abort the check.
+func checkEndpoints(dockerCli command.Cli, contexts []*formatter.ClientContext, timeout time.Duration) {
+	var wg sync.WaitGroup
+	for _, c := range contexts {
+		if c.Error != "" {
+			continue
+		}
+		wg.Add(1)
+		go func(c *formatter.ClientContext) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			check, err := checkEndpoint(ctx, dockerCli, c.Name)
+			if err != nil {
+				c.Error = err.Error()
+			}
+			c.Check = check
+		}(c)
+	}
+	wg.Wait()
+}
+
+// checkEndpoint pings the docker endpoint of the given context, and fetches
+// its version. The returned EndpointCheck is never nil.
+func checkEndpoint(ctx context.Context, dockerCli command.Cli, contextName string) (*formatter.EndpointCheck, error) {
+	type result struct {
+		check *formatter.EndpointCheck
+		err   error
+	}
+	// Connection helpers (such as ssh) do not always respect cancellation
+	// of the context, so don't wait for the check longer than the timeout.
+	done := make(chan result, 1)
+	go func() {
+		check, err := doCheckEndpoint(ctx, dockerCli, contextName)
+		done <- result{check: check, err: err}
+	}()
+	select {
+	case r := <-done:
+		return r.check, r.err
+	case <-ctx.Done():
+		return &formatter.EndpointCheck{}, errors.Wrap(ctx.Err(), "failed to connect")
+	}
+}
+
+func doCheckEndpoint(ctx context.Context, dockerCli command.Cli, contextName string) (*formatter.EndpointCheck, error) {
+	check := &formatter.EndpointCheck{}
+	apiClient, err := command.NewAPIClientFromContext(dockerCli.ContextStore(), contextName, dockerCli.ConfigFile())
+	if err != nil {
+		return check, err
+	}
+	defer apiClient.Close()
+
+	start := time.Now()
+	ping, err := apiClient.Ping(ctx)
+	if err != nil {
+		return check, err
+	}
+	check.Reachable = true
+	check.Latency = time.Since(start)
+	check.APIVersion = ping.APIVersion
+
+	apiClient.NegotiateAPIVersionPing(ping)
+	version, err := apiClient.ServerVersion(ctx)
+	if err != nil {
+		return check, err
+	}
+	check.ServerVersion = version.Version
+	check.APIVersion = version.APIVersion
+	return check, nil
+}
@@ -0,0 +1,118 @@
+package context
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func newTestEngine(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Api-Version", "1.42")
+		switch r.URL.Path {
+		case "/_ping":
+			_, _ = w.Write([]byte("OK"))
+		case "/v1.42/version":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"Version":"23.0.6","ApiVersion":"1.42"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCheckEndpoints(t *testing.T) {
+	srv := newTestEngine(t)
+
+	// reserve an address that nothing is listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	downAddr := l.Addr().String()
+	assert.NilError(t, l.Close())
+
+	cli := makeFakeCli(t)
+	for name, host := range map[string]string{
+		"up":   "tcp://" + srv.Listener.Addr().String(),
+		"down": "tcp://" + downAddr,
+	} {
+		assert.NilError(t, RunCreate(cli, &CreateOptions{
+			Name:   name,
+			Docker: map[string]string{keyHost: host},
+		}))
+	}
+
+	contexts := []*formatter.ClientContext{
+		{Name: "up"},
+		{Name: "down"},
+		{Name: "broken", Error: "failed to load"},
+	}
+	checkEndpoints(cli, contexts, 5*time.Second)
+
+	up := contexts[0]
+	assert.Check(t, is.Equal(up.Error, ""))
+	assert.Assert(t, up.Check != nil)
+	assert.Check(t, up.Check.Reachable)
+	assert.Check(t, is.Equal(up.Check.ServerVersion, "23.0.6"))
+	assert.Check(t, is.Equal(up.Check.APIVersion, "1.42"))
+
+	down := contexts[1]
+	assert.Check(t, down.Error != "")
+	assert.Assert(t, down.Check != nil)
+	assert.Check(t, !down.Check.Reachable)
+
+	broken := contexts[2]
+	assert.Check(t, is.Equal(broken.Error, "failed to load"))
+	assert.Check(t, broken.Check == nil)
+}
+
+func TestCheckEndpointTimeout(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	cli := makeFakeCli(t)
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name:   "slow",
+		Docker: map[string]string{keyHost: "tcp://" + srv.Listener.Addr().String()},
+	}))
+	contexts := []*formatter.ClientContext{{Name: "slow"}}
+	checkEndpoints(cli, contexts, 100*time.Millisecond)
+	assert.Check(t, is.Contains(contexts[0].Error, "context deadline exceeded"))
+	assert.Check(t, !contexts[0].Check.Reachable)
+}
+
+func TestListCheckFormat(t *testing.T) {
+	cli := makeFakeCli(t)
+	contexts := []*formatter.ClientContext{
+		{
+			Name:           "up",
+			Current:        true,
+			DockerEndpoint: "tcp://127.0.0.1:2375",
+			Check: &formatter.EndpointCheck{
+				Reachable:     true,
+				Latency:       12345678 * time.Nanosecond,
+				ServerVersion: "23.0.6",
+				APIVersion:    "1.42",
+			},
+		},
+		{
+			Name:           "down",
+			DockerEndpoint: "tcp://127.0.0.1:2376",
+			Error:          "connection refused",
+			Check:          &formatter.EndpointCheck{},
+		},
+	}
+	assert.NilError(t, format(cli, &listOptions{format: formatter.TableFormatKey, check: true}, contexts))
+	golden.Assert(t, cli.OutBuffer().String(), "list-check.golden")
+}
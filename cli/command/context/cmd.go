@@ -16,6 +16,7 @@ func NewContextCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	cmd.AddCommand(
 		newCreateCommand(dockerCli),
+		newEncryptCommand(dockerCli),
 		newListCommand(dockerCli),
 		newUseCommand(dockerCli),
 		newExportCommand(dockerCli),
@@ -10,6 +10,7 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command/formatter/tabwriter"
 	"github.com/harness-community/docker-cli-v23/cli/context/docker"
 	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	dopts "github.com/harness-community/docker-cli-v23/opts"
 	"github.com/harness-community/docker-v23/errdefs"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -19,6 +20,7 @@ import (
 type CreateOptions struct {
 	Name        string
 	Description string
+	Labels      map[string]string
 	Docker      map[string]string
 	From        string
 }
@@ -38,12 +40,14 @@ func longCreateDescription() string {
 
 func newCreateCommand(dockerCli command.Cli) *cobra.Command {
 	opts := &CreateOptions{}
+	labels := dopts.NewListOpts(dopts.ValidateLabel)
 	cmd := &cobra.Command{
 		Use:   "create [OPTIONS] CONTEXT",
 		Short: "Create a context",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Name = args[0]
+			opts.Labels = dopts.ConvertKVStringsToMap(labels.GetAll())
 			return RunCreate(dockerCli, opts)
 		},
 		Long:              longCreateDescription(),
@@ -51,6 +55,7 @@ func newCreateCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&opts.Description, "description", "", "Description of the context")
+	flags.Var(&labels, "label", "Set a label on the context")
 	flags.String(
 		"default-stack-orchestrator", "",
 		`Default orchestrator for stack operations to use with this context ("swarm", "kubernetes", "all")`,
@@ -103,6 +108,7 @@ func createNewContext(o *CreateOptions, cli command.Cli, s store.Writer) error {
 		},
 		Metadata: command.DockerContext{
 			Description: o.Description,
+			Labels:      o.Labels,
 		},
 		Name: o.Name,
 	}
@@ -141,20 +147,24 @@ func createFromExistingContext(s store.ReaderWriter, fromContextName string, o *
 	if len(o.Docker) != 0 {
 		return errors.New("cannot use --docker flag when --from is set")
 	}
-	reader := store.Export(fromContextName, &descriptionDecorator{
+	reader := store.Export(fromContextName, &metadataDecorator{
 		Reader:      s,
 		description: o.Description,
+		labels:      o.Labels,
 	})
 	defer reader.Close()
 	return store.Import(o.Name, s, reader)
 }
 
-type descriptionDecorator struct {
+// metadataDecorator overrides the description of a context, and adds labels
+// to it, for creating a context from an existing context.
+type metadataDecorator struct {
 	store.Reader
 	description string
+	labels      map[string]string
 }
 
-func (d *descriptionDecorator) GetMetadata(name string) (store.Metadata, error) {
+func (d *metadataDecorator) GetMetadata(name string) (store.Metadata, error) {
 	c, err := d.Reader.GetMetadata(name)
 	if err != nil {
 		return c, err
@@ -166,6 +176,16 @@ func (d *descriptionDecorator) GetMetadata(name string) (store.Metadata, error)
 	if d.description != "" {
 		typedContext.Description = d.description
 	}
+	if len(d.labels) > 0 {
+		labels := make(map[string]string, len(typedContext.Labels)+len(d.labels))
+		for k, v := range typedContext.Labels {
+			labels[k] = v
+		}
+		for k, v := range d.labels {
+			labels[k] = v
+		}
+		typedContext.Labels = labels
+	}
 	c.Metadata = typedContext
 	return c, nil
 }
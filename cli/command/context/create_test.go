@@ -10,17 +10,25 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/context/store"
 	"github.com/harness-community/docker-cli-v23/internal/test"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
-func makeFakeCli(t *testing.T, opts ...func(*test.FakeCli)) *test.FakeCli {
-	t.Helper()
-	dir := t.TempDir()
-	storeConfig := store.NewConfig(
+func testStoreConfig() store.Config {
+	return store.NewConfig(
 		func() interface{} { return &command.DockerContext{} },
 		store.EndpointTypeGetter(docker.DockerEndpoint, func() interface{} { return &docker.EndpointMeta{} }),
 	)
+}
+
+func makeFakeCli(t *testing.T, opts ...func(*test.FakeCli)) *test.FakeCli {
+	t.Helper()
+	return makeFakeCliWithStore(t, store.New(t.TempDir(), testStoreConfig()), opts...)
+}
+
+func makeFakeCliWithStore(t *testing.T, s store.Store, opts ...func(*test.FakeCli)) *test.FakeCli {
+	t.Helper()
 	store := &command.ContextStoreWithDefault{
-		Store: store.New(dir, storeConfig),
+		Store: s,
 		Resolver: func() (*command.DefaultContext, error) {
 			return &command.DefaultContext{
 				Meta: store.Metadata{
@@ -89,6 +97,15 @@ func TestCreate(t *testing.T) {
 			},
 			expecterErr: `unable to parse docker host`,
 		},
+		{
+			options: CreateOptions{
+				Name: "invalid-failover-host",
+				Docker: map[string]string{
+					keyHost: "tcp://primary:2376;some///invalid/host",
+				},
+			},
+			expecterErr: `unable to parse docker host`,
+		},
 	}
 	for _, tc := range tests {
 		tc := tc
@@ -103,6 +120,58 @@ func TestCreate(t *testing.T) {
 	}
 }
 
+func TestCreateMultipleHosts(t *testing.T) {
+	cli := makeFakeCli(t)
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name: "test",
+		Docker: map[string]string{
+			keyHost: "tcp://primary:2376; tcp://standby:2376;",
+		},
+	}))
+	c, err := cli.ContextStore().GetMetadata("test")
+	assert.NilError(t, err)
+	ep := c.Endpoints[docker.DockerEndpoint].(docker.EndpointMeta)
+	assert.Equal(t, ep.Host, "tcp://primary:2376")
+	assert.DeepEqual(t, ep.Hosts, []string{"tcp://primary:2376", "tcp://standby:2376"})
+
+	// a single host does not set Hosts
+	assert.NilError(t, RunUpdate(cli, &UpdateOptions{
+		Name:   "test",
+		Docker: map[string]string{keyHost: "tcp://primary:2376"},
+	}))
+	c, err = cli.ContextStore().GetMetadata("test")
+	assert.NilError(t, err)
+	ep = c.Endpoints[docker.DockerEndpoint].(docker.EndpointMeta)
+	assert.Equal(t, ep.Host, "tcp://primary:2376")
+	assert.Check(t, is.Len(ep.Hosts, 0))
+}
+
+func TestCreateFromContextWithLabels(t *testing.T) {
+	cli := makeFakeCli(t)
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name:   "source",
+		Labels: map[string]string{"env": "staging", "region": "eu"},
+		Docker: map[string]string{keyHost: "tcp://example.com:2376"},
+	}))
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name:   "dest",
+		From:   "source",
+		Labels: map[string]string{"env": "prod"},
+	}))
+	c, err := cli.ContextStore().GetMetadata("dest")
+	assert.NilError(t, err)
+	dc, err := command.GetDockerContext(c)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dc.Labels, map[string]string{"env": "prod", "region": "eu"})
+
+	// the source context is not modified
+	c, err = cli.ContextStore().GetMetadata("source")
+	assert.NilError(t, err)
+	dc, err = command.GetDockerContext(c)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dc.Labels, map[string]string{"env": "staging", "region": "eu"})
+}
+
 func assertContextCreateLogging(t *testing.T, cli *test.FakeCli, n string) {
 	assert.Equal(t, n+"\n", cli.OutBuffer().String())
 	assert.Equal(t, fmt.Sprintf("Successfully created context %q\n", n), cli.ErrBuffer().String())
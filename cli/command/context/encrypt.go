@@ -0,0 +1,58 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func newEncryptCommand(dockerCli command.Cli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "encrypt CONTEXT [CONTEXT...]",
+		Short: "Encrypt the TLS material of one or more contexts",
+		Long: `Encrypt the TLS material of one or more contexts
+
+TLS material is encrypted using the key file or passphrase set through the
+` + command.EnvContextTLSKeyFile + ` or ` + command.EnvContextTLSPassphrase + `
+environment variables. Contexts that are created or updated while one of these
+is set are encrypted automatically; this command migrates existing contexts.`,
+		Args: cli.RequiresMinArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunEncrypt(dockerCli, args)
+		},
+	}
+}
+
+// RunEncrypt encrypts the TLS material of one or more contexts
+func RunEncrypt(dockerCli command.Cli, names []string) error {
+	if e, ok := dockerCli.ContextStore().(store.TLSEncrypter); !ok || !e.TLSEncryptionEnabled() {
+		return errors.Errorf("no encryption key configured: set %s or %s", command.EnvContextTLSKeyFile, command.EnvContextTLSPassphrase)
+	}
+	var errs []string
+	for _, name := range names {
+		if name == command.DefaultContextName {
+			errs = append(errs, `default: context "default" cannot be encrypted`)
+		} else if err := doEncrypt(dockerCli, name); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+		} else {
+			fmt.Fprintln(dockerCli.Out(), name)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func doEncrypt(dockerCli command.Cli, name string) error {
+	s := dockerCli.ContextStore()
+	if _, err := s.GetMetadata(name); err != nil {
+		return err
+	}
+	return store.MigrateTLSMaterial(s, name)
+}
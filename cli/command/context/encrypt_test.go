@@ -0,0 +1,71 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/context"
+	"github.com/harness-community/docker-cli-v23/cli/context/docker"
+	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"github.com/harness-community/docker-v23/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func makeEncryptedFakeCli(t *testing.T, dir string) *test.FakeCli {
+	t.Helper()
+	return makeFakeCliWithStore(t, store.New(dir, testStoreConfig().WithTLSEncryption(store.PassphraseKey("secret"))))
+}
+
+func TestEncryptNoKey(t *testing.T) {
+	// the store of the CLI decides, not the environment
+	t.Setenv(command.EnvContextTLSPassphrase, "secret")
+	cli := makeFakeCli(t)
+	createTestContext(t, cli, "test")
+	cli.OutBuffer().Reset()
+	err := RunEncrypt(cli, []string{"test"})
+	assert.ErrorContains(t, err, "no encryption key configured")
+	assert.Equal(t, cli.OutBuffer().String(), "")
+}
+
+func TestEncrypt(t *testing.T) {
+	dir := t.TempDir()
+	cli := makeEncryptedFakeCli(t, dir)
+	createTestContext(t, cli, "test")
+
+	// TLS material written before encryption was enabled
+	plain := store.New(dir, testStoreConfig())
+	assert.NilError(t, plain.ResetEndpointTLSMaterial("test", docker.DockerEndpoint, &store.EndpointTLSData{
+		Files: map[string][]byte{"ca.pem": []byte("ca-data")},
+	}))
+	caFile := filepath.Join(plain.GetStorageInfo("test").TLSPath, docker.DockerEndpoint, "ca.pem")
+	raw, err := os.ReadFile(caFile)
+	assert.NilError(t, err)
+	assert.Check(t, !store.IsEncryptedTLSData(raw))
+
+	cli.OutBuffer().Reset()
+	assert.NilError(t, RunEncrypt(cli, []string{"test"}))
+	assert.Equal(t, cli.OutBuffer().String(), "test\n")
+
+	raw, err = os.ReadFile(caFile)
+	assert.NilError(t, err)
+	assert.Check(t, store.IsEncryptedTLSData(raw))
+
+	_, err = cli.ContextStore().GetMetadata("test")
+	assert.NilError(t, err)
+	tlsData, err := context.LoadTLSData(cli.ContextStore(), "test", docker.DockerEndpoint)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(tlsData.CA), "ca-data"))
+}
+
+func TestEncryptErrors(t *testing.T) {
+	cli := makeEncryptedFakeCli(t, t.TempDir())
+	err := RunEncrypt(cli, []string{"default", "not-a-context"})
+	assert.ErrorContains(t, err, `context "default" cannot be encrypted`)
+	assert.ErrorContains(t, err, `not-a-context`)
+	_, err = cli.ContextStore().GetMetadata("not-a-context")
+	assert.ErrorType(t, err, errdefs.IsNotFound)
+}
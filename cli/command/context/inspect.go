@@ -49,10 +49,25 @@ func runInspect(dockerCli command.Cli, opts inspectOptions) error {
 		if err != nil {
 			return nil, nil, err
 		}
+		var (
+			source     *command.ContextSource
+			activeHost string
+		)
+		if ref == dockerCli.CurrentContext() {
+			if p, ok := dockerCli.(command.ContextSourceProvider); ok {
+				s := p.CurrentContextSource()
+				source = &s
+			}
+			if ep := dockerCli.DockerEndpoint(); len(ep.Hosts) > 1 {
+				activeHost = ep.Host
+			}
+		}
 		return contextWithTLSListing{
 			Metadata:    c,
 			TLSMaterial: tlsListing,
 			Storage:     dockerCli.ContextStore().GetStorageInfo(ref),
+			Source:      source,
+			ActiveHost:  activeHost,
 		}, nil, nil
 	}
 	return inspect.Inspect(dockerCli.Out(), opts.refs, opts.format, getRefFunc)
@@ -62,4 +77,11 @@ type contextWithTLSListing struct {
 	store.Metadata
 	TLSMaterial map[string]store.EndpointFiles
 	Storage     store.StorageInfo
+	// Source is where the context was selected from, and only set for the
+	// current context.
+	Source *command.ContextSource `json:",omitempty"`
+	// ActiveHost is the host of the docker endpoint that was chosen when
+	// connecting, and only set for the current context if its endpoint has
+	// multiple hosts.
+	ActiveHost string `json:",omitempty"`
 }
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/context/docker"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/golden"
 )
@@ -21,3 +23,33 @@ func TestInspect(t *testing.T) {
 	expected = strings.Replace(expected, "<TLS_PATH>", strings.Replace(si.TLSPath, `\`, `\\`, -1), 1)
 	assert.Equal(t, cli.OutBuffer().String(), expected)
 }
+
+func TestInspectCurrentSource(t *testing.T) {
+	cli := makeFakeCli(t)
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+	cli.SetCurrentContextSource(command.ContextSource{Type: command.ContextSourceEnv, Location: "DOCKER_CONTEXT"})
+	cli.OutBuffer().Reset()
+	assert.NilError(t, runInspect(cli, inspectOptions{
+		refs:   []string{"current"},
+		format: "{{json .Source}}",
+	}))
+	assert.Equal(t, cli.OutBuffer().String(), `{"Type":"env","Location":"DOCKER_CONTEXT"}`+"\n")
+}
+
+func TestInspectActiveHost(t *testing.T) {
+	cli := makeFakeCli(t)
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+	ep := docker.Endpoint{EndpointMeta: docker.EndpointMeta{
+		Host:  "tcp://standby:2376",
+		Hosts: []string{"tcp://primary:2376", "tcp://standby:2376"},
+	}}
+	cli.SetDockerEndpoint(ep)
+	cli.OutBuffer().Reset()
+	assert.NilError(t, runInspect(cli, inspectOptions{
+		refs:   []string{"current"},
+		format: "{{.ActiveHost}}",
+	}))
+	assert.Equal(t, cli.OutBuffer().String(), "tcp://standby:2376\n")
+}
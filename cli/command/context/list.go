@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/harness-community/docker-cli-v23/cli"
 	"github.com/harness-community/docker-cli-v23/cli/command"
@@ -11,32 +12,49 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
 	"github.com/harness-community/docker-cli-v23/cli/context/docker"
 	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
+	"github.com/harness-community/docker-cli-v23/opts"
+	"github.com/harness-community/docker-v23/api/types/filters"
 	"github.com/harness-community/docker-v23/client"
 	"github.com/fvbommel/sortorder"
 	"github.com/spf13/cobra"
 )
 
 type listOptions struct {
-	format string
-	quiet  bool
+	format  string
+	sort    string
+	wide    bool
+	quiet   bool
+	check   bool
+	timeout time.Duration
+	filter  opts.FilterOpt
+}
+
+var acceptedListFilters = map[string]bool{
+	"label": true,
+	"name":  true,
 }
 
 func newListCommand(dockerCli command.Cli) *cobra.Command {
-	opts := &listOptions{}
+	options := &listOptions{filter: opts.NewFilterOpt()}
 	cmd := &cobra.Command{
 		Use:     "ls [OPTIONS]",
 		Aliases: []string{"list"},
 		Short:   "List contexts",
 		Args:    cli.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runList(dockerCli, opts)
+			return runList(dockerCli, options)
 		},
 		ValidArgsFunction: completion.NoComplete,
 	}
 
 	flags := cmd.Flags()
-	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
-	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only show context names")
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
+	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only show context names")
+	flags.BoolVar(&options.check, "check", false, "Check if the docker endpoint of each context is reachable")
+	flags.DurationVar(&options.timeout, "timeout", defaultCheckTimeout, "Timeout for checking the docker endpoint of each context (with --check)")
+	flags.VarP(&options.filter, "filter", "f", `Filter output based on conditions provided (e.g. "label=env=prod")`)
 	return cmd
 }
 
@@ -44,6 +62,10 @@ func runList(dockerCli command.Cli, opts *listOptions) error {
 	if opts.format == "" {
 		opts.format = formatter.TableFormatKey
 	}
+	filter := opts.filter.Value()
+	if err := filter.Validate(acceptedListFilters); err != nil {
+		return err
+	}
 	contextMap, err := dockerCli.ContextStore().List()
 	if err != nil {
 		return err
@@ -78,6 +100,7 @@ func runList(dockerCli command.Cli, opts *listOptions) error {
 			Name:           rawMeta.Name,
 			Current:        isCurrent,
 			Description:    meta.Description,
+			Labels:         meta.Labels,
 			DockerEndpoint: dockerEndpoint.Host,
 			Error:          errMsg,
 		}
@@ -98,9 +121,15 @@ func runList(dockerCli command.Cli, opts *listOptions) error {
 			Error:   errMsg,
 		})
 	}
+	if filter.Len() > 0 {
+		contexts = filterContexts(contexts, filter)
+	}
 	sort.Slice(contexts, func(i, j int) bool {
 		return sortorder.NaturalLess(contexts[i].Name, contexts[j].Name)
 	})
+	if opts.check && !opts.quiet {
+		checkEndpoints(dockerCli, contexts, opts.timeout)
+	}
 	if err := format(dockerCli, opts, contexts); err != nil {
 		return err
 	}
@@ -111,10 +140,23 @@ func runList(dockerCli command.Cli, opts *listOptions) error {
 	return nil
 }
 
+// filterContexts returns the contexts matching the "name" and "label" filters.
+func filterContexts(contexts []*formatter.ClientContext, filter filters.Args) []*formatter.ClientContext {
+	var filtered []*formatter.ClientContext
+	for _, c := range contexts {
+		if filter.Match("name", c.Name) && filter.MatchKVList("label", c.Labels) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
 func format(dockerCli command.Cli, opts *listOptions, contexts []*formatter.ClientContext) error {
 	contextCtx := formatter.Context{
 		Output: dockerCli.Out(),
-		Format: formatter.NewClientContextFormat(opts.format, opts.quiet),
+		Format: formatter.NewClientContextCheckFormat(opts.format, opts.quiet, opts.check),
+		Sort:   opts.sort,
+		Wide:   opts.wide,
 	}
 	return formatter.ClientContextWrite(contextCtx, contexts)
 }
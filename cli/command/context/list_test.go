@@ -1,9 +1,11 @@
 package context
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/opts"
 	"gotest.tools/v3/assert"
 	"gotest.tools/v3/golden"
 )
@@ -47,3 +49,60 @@ func TestListError(t *testing.T) {
 	assert.NilError(t, runList(cli, &listOptions{}))
 	golden.Assert(t, cli.OutBuffer().String(), "list-with-error.golden")
 }
+
+func TestListFilter(t *testing.T) {
+	cli := makeFakeCli(t)
+	for name, labels := range map[string]map[string]string{
+		"prod-eu": {"env": "prod", "region": "eu"},
+		"prod-us": {"env": "prod", "region": "us"},
+		"staging": {"env": "staging"},
+	} {
+		assert.NilError(t, RunCreate(cli, &CreateOptions{
+			Name:   name,
+			Labels: labels,
+			Docker: map[string]string{keyHost: "https://someswarmserver.example.com"},
+		}))
+	}
+
+	testCases := []struct {
+		filters  []string
+		expected string
+	}{
+		{filters: []string{"label=env=prod"}, expected: "prod-eu\nprod-us\n"},
+		{filters: []string{"label=env=prod", "label=region=eu"}, expected: "prod-eu\n"},
+		{filters: []string{"label=region"}, expected: "prod-eu\nprod-us\n"},
+		{filters: []string{"name=staging"}, expected: "staging\n"},
+		{filters: []string{"label=env=dev"}, expected: ""},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(strings.Join(tc.filters, ","), func(t *testing.T) {
+			options := &listOptions{filter: opts.NewFilterOpt(), format: "{{.Name}}"}
+			for _, f := range tc.filters {
+				assert.NilError(t, options.filter.Set(f))
+			}
+			cli.OutBuffer().Reset()
+			assert.NilError(t, runList(cli, options))
+			assert.Equal(t, cli.OutBuffer().String(), tc.expected)
+		})
+	}
+}
+
+func TestListFilterInvalid(t *testing.T) {
+	cli := makeFakeCli(t)
+	options := &listOptions{filter: opts.NewFilterOpt()}
+	assert.NilError(t, options.filter.Set("foo=bar"))
+	assert.ErrorContains(t, runList(cli, options), "invalid filter 'foo'")
+}
+
+func TestListLabelsFormat(t *testing.T) {
+	cli := makeFakeCli(t)
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name:   "test",
+		Labels: map[string]string{"region": "eu", "env": "prod"},
+		Docker: map[string]string{keyHost: "https://someswarmserver.example.com"},
+	}))
+	cli.OutBuffer().Reset()
+	assert.NilError(t, runList(cli, &listOptions{format: `{{.Name}}: {{.Labels}} {{.Label "env"}}`}))
+	assert.Equal(t, cli.OutBuffer().String(), "default:  \ntest: env=prod,region=eu prod\n")
+}
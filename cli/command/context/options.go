@@ -43,7 +43,7 @@ var (
 		},
 		{
 			name:        keyHost,
-			description: "Docker endpoint on which to connect",
+			description: "Docker endpoint on which to connect, or a \";\"-separated list of endpoints to try in order",
 		},
 		{
 			name:        keyCA,
@@ -110,20 +110,53 @@ func getDockerEndpoint(dockerCli command.Cli, config map[string]string) (docker.
 	}
 	ep := docker.Endpoint{
 		EndpointMeta: docker.EndpointMeta{
-			Host:          config[keyHost],
 			SkipTLSVerify: skipTLSVerify,
 		},
 		TLSData: tlsData,
 	}
-	// try to resolve a docker client, validating the configuration
+	hosts := parseHosts(config[keyHost])
+	if len(hosts) > 0 {
+		ep.Host = hosts[0]
+	}
+	if len(hosts) > 1 {
+		ep.Hosts = hosts
+	}
+	// try to resolve a docker client for each host, validating the configuration
+	for _, host := range hosts {
+		hostEP := ep
+		hostEP.Host = host
+		if err := validateDockerEndpoint(hostEP); err != nil {
+			return docker.Endpoint{}, err
+		}
+	}
+	if len(hosts) == 0 {
+		if err := validateDockerEndpoint(ep); err != nil {
+			return docker.Endpoint{}, err
+		}
+	}
+	return ep, nil
+}
+
+// parseHosts parses a ";"-separated list of hosts.
+func parseHosts(value string) []string {
+	var hosts []string
+	for _, host := range strings.Split(value, ";") {
+		if host = strings.TrimSpace(host); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+func validateDockerEndpoint(ep docker.Endpoint) error {
 	opts, err := ep.ClientOpts()
 	if err != nil {
-		return docker.Endpoint{}, errors.Wrap(err, "invalid docker endpoint options")
+		return errors.Wrap(err, "invalid docker endpoint options")
 	}
 	if _, err := client.NewClientWithOpts(opts...); err != nil {
-		return docker.Endpoint{}, errors.Wrap(err, "unable to apply docker endpoint options")
+		return errors.Wrap(err, "unable to apply docker endpoint options")
 	}
-	return ep, nil
+	return nil
 }
 
 func getDockerEndpointMetadataAndTLS(dockerCli command.Cli, config map[string]string) (docker.EndpointMeta, *store.EndpointTLSData, error) {
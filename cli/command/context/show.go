@@ -9,21 +9,35 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type showOptions struct {
+	source bool
+}
+
 // newShowCommand creates a new cobra.Command for `docker context sow`
 func newShowCommand(dockerCli command.Cli) *cobra.Command {
+	var opts showOptions
 	cmd := &cobra.Command{
-		Use:   "show",
+		Use:   "show [OPTIONS]",
 		Short: "Print the name of the current context",
 		Args:  cli.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			runShow(dockerCli)
+			runShow(dockerCli, opts)
 			return nil
 		},
 		ValidArgsFunction: completion.NoComplete,
 	}
+	cmd.Flags().BoolVar(&opts.source, "source", false, "Also print where the current context was selected from")
 	return cmd
 }
 
-func runShow(dockerCli command.Cli) {
+func runShow(dockerCli command.Cli, opts showOptions) {
+	if opts.source {
+		source := "unknown"
+		if p, ok := dockerCli.(command.ContextSourceProvider); ok {
+			source = p.CurrentContextSource().String()
+		}
+		fmt.Fprintf(dockerCli.Out(), "%s\t%s\n", dockerCli.CurrentContext(), source)
+		return
+	}
 	fmt.Fprintln(dockerCli.Out(), dockerCli.CurrentContext())
 }
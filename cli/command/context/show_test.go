@@ -3,6 +3,8 @@ package context
 import (
 	"testing"
 
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"gotest.tools/v3/assert"
 	"gotest.tools/v3/golden"
 )
 
@@ -12,6 +14,33 @@ func TestShow(t *testing.T) {
 	cli.SetCurrentContext("current")
 
 	cli.OutBuffer().Reset()
-	runShow(cli)
+	runShow(cli, showOptions{})
 	golden.Assert(t, cli.OutBuffer().String(), "show.golden")
 }
+
+func TestShowSource(t *testing.T) {
+	cli := makeFakeCli(t)
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+	cli.SetCurrentContextSource(command.ContextSource{Type: command.ContextSourceFile, Location: "/project/.dockercontext"})
+
+	cli.OutBuffer().Reset()
+	runShow(cli, showOptions{source: true})
+	assert.Equal(t, cli.OutBuffer().String(), "current\tfile (/project/.dockercontext)\n")
+}
+
+// cliWithoutSource is a command.Cli that does not implement
+// command.ContextSourceProvider.
+type cliWithoutSource struct {
+	command.Cli
+}
+
+func TestShowSourceUnknown(t *testing.T) {
+	cli := makeFakeCli(t)
+	createTestContext(t, cli, "current")
+	cli.SetCurrentContext("current")
+
+	cli.OutBuffer().Reset()
+	runShow(cliWithoutSource{Cli: cli}, showOptions{source: true})
+	assert.Equal(t, cli.OutBuffer().String(), "current\tunknown\n")
+}
@@ -9,15 +9,18 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command/formatter/tabwriter"
 	"github.com/harness-community/docker-cli-v23/cli/context/docker"
 	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	dopts "github.com/harness-community/docker-cli-v23/opts"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 // UpdateOptions are the options used to update a context
 type UpdateOptions struct {
-	Name        string
-	Description string
-	Docker      map[string]string
+	Name           string
+	Description    string
+	Labels         map[string]string
+	LabelsToRemove []string
+	Docker         map[string]string
 }
 
 func longUpdateDescription() string {
@@ -35,18 +38,22 @@ func longUpdateDescription() string {
 
 func newUpdateCommand(dockerCli command.Cli) *cobra.Command {
 	opts := &UpdateOptions{}
+	labels := dopts.NewListOpts(dopts.ValidateLabel)
 	cmd := &cobra.Command{
 		Use:   "update [OPTIONS] CONTEXT",
 		Short: "Update a context",
 		Args:  cli.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.Name = args[0]
+			opts.Labels = dopts.ConvertKVStringsToMap(labels.GetAll())
 			return RunUpdate(dockerCli, opts)
 		},
 		Long: longUpdateDescription(),
 	}
 	flags := cmd.Flags()
 	flags.StringVar(&opts.Description, "description", "", "Description of the context")
+	flags.Var(&labels, "label", "Set or update a label on the context")
+	flags.StringSliceVar(&opts.LabelsToRemove, "label-rm", nil, "Remove a label from the context")
 	flags.String(
 		"default-stack-orchestrator", "",
 		"Default orchestrator for stack operations to use with this context (swarm|kubernetes|all)",
@@ -78,6 +85,19 @@ func RunUpdate(cli command.Cli, o *UpdateOptions) error {
 	if o.Description != "" {
 		dockerContext.Description = o.Description
 	}
+	if len(o.Labels) > 0 || len(o.LabelsToRemove) > 0 {
+		labels := make(map[string]string, len(dockerContext.Labels)+len(o.Labels))
+		for k, v := range dockerContext.Labels {
+			labels[k] = v
+		}
+		for _, k := range o.LabelsToRemove {
+			delete(labels, k)
+		}
+		for k, v := range o.Labels {
+			labels[k] = v
+		}
+		dockerContext.Labels = labels
+	}
 
 	c.Metadata = dockerContext
 
@@ -65,3 +65,22 @@ func TestUpdateInvalidDockerHost(t *testing.T) {
 	})
 	assert.ErrorContains(t, err, "unable to parse docker host")
 }
+
+func TestUpdateLabels(t *testing.T) {
+	cli := makeFakeCli(t)
+	assert.NilError(t, RunCreate(cli, &CreateOptions{
+		Name:   "test",
+		Labels: map[string]string{"env": "staging", "region": "eu", "team": "a"},
+		Docker: map[string]string{},
+	}))
+	assert.NilError(t, RunUpdate(cli, &UpdateOptions{
+		Name:           "test",
+		Labels:         map[string]string{"env": "prod", "tier": "1"},
+		LabelsToRemove: []string{"team", "missing"},
+	}))
+	c, err := cli.ContextStore().GetMetadata("test")
+	assert.NilError(t, err)
+	dc, err := command.GetDockerContext(c)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dc.Labels, map[string]string{"env": "prod", "region": "eu", "tier": "1"})
+}
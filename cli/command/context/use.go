@@ -52,5 +52,11 @@ func RunUse(dockerCli command.Cli, name string) error {
 		fmt.Fprintf(dockerCli.Err(), "Warning: %[1]s environment variable overrides the active context. "+
 			"To use %[2]q, either set the global --context flag, or unset %[1]s environment variable.\n", client.EnvOverrideHost, name)
 	}
+	if p, ok := dockerCli.(command.ContextSourceProvider); ok {
+		if source := p.CurrentContextSource(); source.Type == command.ContextSourceFile {
+			fmt.Fprintf(dockerCli.Err(), "Warning: %s overrides the active context in this directory. "+
+				"To use %q, either set the global --context flag, or remove the file.\n", source.Location, name)
+		}
+	}
 	return nil
 }
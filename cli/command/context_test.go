@@ -24,3 +24,26 @@ func TestDockerContextMetadataKeepAdditionalFields(t *testing.T) {
 	assert.Equal(t, c2.AdditionalFields["foo"], "bar")
 	assert.Equal(t, c2.Description, "test")
 }
+
+func TestDockerContextMetadataLabels(t *testing.T) {
+	c := DockerContext{
+		Description: "test",
+		Labels: map[string]string{
+			"env":    "prod",
+			"region": "eu",
+		},
+	}
+	jsonBytes, err := json.Marshal(c)
+	assert.NilError(t, err)
+	const expected = `{"Description":"test","Labels":{"env":"prod","region":"eu"}}`
+	assert.Equal(t, string(jsonBytes), expected)
+
+	var c2 DockerContext
+	assert.NilError(t, json.Unmarshal(jsonBytes, &c2))
+	assert.DeepEqual(t, c2.Labels, c.Labels)
+	assert.Equal(t, len(c2.AdditionalFields), 0)
+
+	var c3 DockerContext
+	err = json.Unmarshal([]byte(`{"Labels":{"env":1}}`), &c3)
+	assert.ErrorContains(t, err, `context label "env" must be a string`)
+}
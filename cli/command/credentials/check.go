@@ -0,0 +1,154 @@
+package credentials
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
+	"github.com/harness-community/docker-cli-v23/cli/registry/client"
+	"github.com/harness-community/docker-v23/api/types"
+	"github.com/harness-community/docker-v23/errdefs"
+	"github.com/harness-community/docker-v23/registry"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultCheckTimeout = 10 * time.Second
+
+	statusValid   = "valid"
+	statusInvalid = "invalid"
+	statusError   = "error"
+)
+
+type checkOptions struct {
+	format   string
+	insecure bool
+	timeout  time.Duration
+	servers  []string
+}
+
+func newCheckCommand(dockerCli command.Cli) *cobra.Command {
+	var opts checkOptions
+	cmd := &cobra.Command{
+		Use:   "check [OPTIONS] [SERVER...]",
+		Short: "Check stored credentials against their registry",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.servers = args
+			return runCheck(dockerCli, opts)
+		},
+		ValidArgsFunction: completeServers(dockerCli),
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.BoolVar(&opts.insecure, "insecure", false, "Allow communication with an insecure registry")
+	flags.DurationVar(&opts.timeout, "timeout", defaultCheckTimeout, "Timeout for checking the credentials of each registry")
+	return cmd
+}
+
+func runCheck(dockerCli command.Cli, opts checkOptions) error {
+	creds, err := storedCredentials(dockerCli.ConfigFile())
+	if err != nil {
+		return err
+	}
+	if len(opts.servers) > 0 {
+		creds, err = filterCredentials(creds, opts.servers)
+		if err != nil {
+			return err
+		}
+	}
+
+	checkCredentials(dockerCli, creds, opts.insecure, opts.timeout)
+
+	credCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: newFormat(opts.format, false, true),
+		Trunc:  true,
+	}
+	if err := formatWrite(credCtx, creds, true); err != nil {
+		return err
+	}
+	var failed int
+	for _, c := range creds {
+		if c.Status != statusValid {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d stored credentials failed the check", failed, len(creds))
+	}
+	return nil
+}
+
+// filterCredentials returns the credentials stored for the given servers. A
+// server matches the registry the credentials are stored for if it is equal,
+// or has the same hostname.
+func filterCredentials(creds []credential, servers []string) ([]credential, error) {
+	var filtered []credential
+	for _, server := range servers {
+		var found bool
+		for _, c := range creds {
+			if c.ServerAddress == server || (!credentials.IsRepositoryScope(c.ServerAddress) && registry.ConvertToHostname(c.ServerAddress) == registry.ConvertToHostname(server)) {
+				filtered = append(filtered, c)
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("no credentials stored for %s", server)
+		}
+	}
+	return filtered, nil
+}
+
+// checkCredentials concurrently checks the given credentials against their
+// registry, and stores the result in their Status and Error fields. Errors
+// are reported per registry, and never abort the check.
+func checkCredentials(dockerCli command.Cli, creds []credential, insecure bool, timeout time.Duration) {
+	checker, ok := dockerCli.RegistryClient(insecure).(client.CredentialsChecker)
+	if !ok {
+		for i := range creds {
+			creds[i].Status, creds[i].Error = statusError, "checking credentials is not supported by the registry client"
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	for i := range creds {
+		wg.Add(1)
+		go func(c *credential) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			err := checker.CheckCredentials(ctx, c.ServerAddress, types.AuthConfig(c.authConfig))
+			switch {
+			case err == nil:
+				c.Status = statusValid
+			case errdefs.IsUnauthorized(err):
+				c.Status, c.Error = statusInvalid, err.Error()
+			default:
+				c.Status, c.Error = statusError, err.Error()
+			}
+		}(&creds[i])
+	}
+	wg.Wait()
+}
+
+// completeServers completes the arguments of a command with the registries
+// credentials are stored for.
+func completeServers(dockerCli command.Cli) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		creds, err := storedCredentials(dockerCli.ConfigFile())
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		servers := make([]string, 0, len(creds))
+		for _, c := range creds {
+			servers = append(servers, c.ServerAddress)
+		}
+		return servers, cobra.ShellCompDirectiveNoFileComp
+	}
+}
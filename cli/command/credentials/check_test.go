@@ -0,0 +1,76 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/registry/client"
+	"github.com/harness-community/docker-v23/api/types"
+	"github.com/harness-community/docker-v23/errdefs"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type fakeRegistryClient struct {
+	client.RegistryClient
+	checkCredentialsFunc func(serverAddress string, authConfig types.AuthConfig) error
+}
+
+func (c *fakeRegistryClient) CheckCredentials(_ context.Context, serverAddress string, authConfig types.AuthConfig) error {
+	return c.checkCredentialsFunc(serverAddress, authConfig)
+}
+
+func TestCheck(t *testing.T) {
+	cli := newTestCli(t)
+	cli.SetRegistryClient(&fakeRegistryClient{
+		checkCredentialsFunc: func(serverAddress string, authConfig types.AuthConfig) error {
+			switch serverAddress {
+			case "https://index.docker.io/v1/":
+				assert.Check(t, is.Equal(authConfig.Password, "pass"))
+				return nil
+			case "registry.example.com":
+				assert.Check(t, is.Equal(authConfig.IdentityToken, "token"))
+				return errdefs.Unauthorized(errors.New("unauthorized: token expired"))
+			default:
+				return errors.New("connection refused")
+			}
+		},
+	})
+
+	err := runCheck(cli, checkOptions{timeout: time.Second})
+	assert.Check(t, is.Error(err, "2 of 3 stored credentials failed the check"))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), `REGISTRY                      STORE     USERNAME   STATUS    ERROR
+https://index.docker.io/v1/   file      hubuser    valid     
+registry.example.com          file      user       invalid   unauthorized: token expired
+registry.example.com/team-a   file      team-a     error     connection refused
+`))
+}
+
+func TestCheckUnsupported(t *testing.T) {
+	cli := newTestCli(t)
+	cli.SetRegistryClient(struct{ client.RegistryClient }{})
+
+	err := runCheck(cli, checkOptions{timeout: time.Second, servers: []string{"registry.example.com"}, format: "{{.Status}}: {{.Error}}"})
+	assert.Check(t, is.Error(err, "1 of 1 stored credentials failed the check"))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "error: checking credentials is not supported by the…\n"))
+}
+
+func TestCheckServers(t *testing.T) {
+	cli := newTestCli(t)
+	var checked []string
+	cli.SetRegistryClient(&fakeRegistryClient{
+		checkCredentialsFunc: func(serverAddress string, _ types.AuthConfig) error {
+			checked = append(checked, serverAddress)
+			return nil
+		},
+	})
+
+	assert.NilError(t, runCheck(cli, checkOptions{timeout: time.Second, servers: []string{"https://registry.example.com"}, format: "{{.ServerAddress}}: {{.Status}}"}))
+	assert.Check(t, is.DeepEqual(checked, []string{"registry.example.com"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "registry.example.com: valid\n"))
+
+	err := runCheck(cli, checkOptions{timeout: time.Second, servers: []string{"unknown.example.com"}})
+	assert.Check(t, is.Error(err, "no credentials stored for unknown.example.com"))
+}
@@ -0,0 +1,22 @@
+package credentials
+
+import (
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/spf13/cobra"
+)
+
+// NewCredentialsCommand returns the credentials cli subcommand
+func NewCredentialsCommand(dockerCli command.Cli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Manage stored registry credentials",
+		Args:  cli.NoArgs,
+		RunE:  command.ShowHelp(dockerCli.Err()),
+	}
+	cmd.AddCommand(
+		newCheckCommand(dockerCli),
+		newListCommand(dockerCli),
+	)
+	return cmd
+}
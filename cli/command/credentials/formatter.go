@@ -0,0 +1,145 @@
+package credentials
+
+import (
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"github.com/harness-community/docker-cli-v23/cli/config/types"
+)
+
+const (
+	defaultCredentialsTableFormat      = "table {{.ServerAddress}}\t{{.Store}}\t{{.Username}}\t{{.IdentityToken}}"
+	defaultCredentialsCheckTableFormat = "table {{.ServerAddress}}\t{{.Store}}\t{{.Username}}\t{{.Status}}\t{{.Error}}"
+	quietCredentialsFormat             = "{{.ServerAddress}}"
+
+	serverAddressHeader = "REGISTRY"
+	storeHeader         = "STORE"
+	usernameHeader      = "USERNAME"
+	identityTokenHeader = "IDENTITY TOKEN"
+	statusHeader        = "STATUS"
+
+	// fileStoreName is the name shown for credentials that are stored in
+	// the configuration file.
+	fileStoreName = "file"
+
+	maxErrLength = 45
+)
+
+// credential describes the credentials stored for a registry.
+type credential struct {
+	ServerAddress string
+	Store         string
+	Username      string
+	IdentityToken bool
+
+	// Status is the result of checking the credentials against the
+	// registry, and only set by "docker credentials check".
+	Status string
+	Error  string
+
+	authConfig types.AuthConfig
+}
+
+// newFormat returns a Format for rendering credentials, which includes the
+// results of checking the credentials if check is set.
+func newFormat(source string, quiet bool, check bool) formatter.Format {
+	if quiet {
+		return quietCredentialsFormat
+	}
+	switch source {
+	case "", formatter.TableFormatKey:
+		if check {
+			return defaultCredentialsCheckTableFormat
+		}
+		return defaultCredentialsTableFormat
+	}
+	return formatter.Format(source)
+}
+
+// formatWrite writes formatted credentials using the Context. The results of
+// checking the credentials (the Status and Error fields) are only available
+// if checked is set.
+func formatWrite(ctx formatter.Context, creds []credential, checked bool) error {
+	render := func(format func(subContext formatter.SubContext) error) error {
+		for _, c := range creds {
+			var sub formatter.SubContext = &credentialContext{c: c}
+			if checked {
+				sub = &checkedCredentialContext{credentialContext: &credentialContext{c: c}, trunc: ctx.Trunc}
+			}
+			if err := format(sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	header := formatter.SubHeaderContext{
+		"ServerAddress": serverAddressHeader,
+		"Store":         storeHeader,
+		"Username":      usernameHeader,
+		"IdentityToken": identityTokenHeader,
+	}
+	if !checked {
+		credCtx := credentialContext{}
+		credCtx.Header = header
+		return ctx.Write(&credCtx, render)
+	}
+	header["Status"] = statusHeader
+	header["Error"] = formatter.ErrorHeader
+	credCtx := checkedCredentialContext{credentialContext: &credentialContext{}}
+	credCtx.Header = header
+	credCtx.Priorities = formatter.ColumnPriorities{
+		"Error": 1,
+	}
+	return ctx.Write(&credCtx, render)
+}
+
+type credentialContext struct {
+	formatter.HeaderContext
+	c credential
+}
+
+func (c *credentialContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *credentialContext) ServerAddress() string {
+	return c.c.ServerAddress
+}
+
+// Store returns the name of the credentials store or credential helper that
+// holds the credentials, or "file" for the configuration file.
+func (c *credentialContext) Store() string {
+	return c.c.Store
+}
+
+func (c *credentialContext) Username() string {
+	return c.c.Username
+}
+
+// IdentityToken returns whether an identity token is stored instead of a
+// password.
+func (c *credentialContext) IdentityToken() bool {
+	return c.c.IdentityToken
+}
+
+// checkedCredentialContext adds the results of checking the credentials
+// against their registry to a credentialContext.
+type checkedCredentialContext struct {
+	*credentialContext
+	trunc bool
+}
+
+func (c *checkedCredentialContext) MarshalJSON() ([]byte, error) {
+	return formatter.MarshalJSON(c)
+}
+
+func (c *checkedCredentialContext) Status() string {
+	return c.c.Status
+}
+
+// Error returns the (truncated) error that occurred when checking the
+// credentials, if any.
+func (c *checkedCredentialContext) Error() string {
+	if c.trunc {
+		return formatter.Ellipsis(c.c.Error, maxErrLength)
+	}
+	return c.c.Error
+}
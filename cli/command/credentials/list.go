@@ -0,0 +1,89 @@
+package credentials
+
+import (
+	"sort"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/completion"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
+	"github.com/fvbommel/sortorder"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	format string
+	sort   string
+	wide   bool
+	quiet  bool
+}
+
+func newListCommand(dockerCli command.Cli) *cobra.Command {
+	var opts listOptions
+	cmd := &cobra.Command{
+		Use:     "ls [OPTIONS]",
+		Aliases: []string{"list"},
+		Short:   "List the registries credentials are stored for",
+		Args:    cli.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(dockerCli, opts)
+		},
+		ValidArgsFunction: completion.NoComplete,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.wide, "wide", false, flagsHelper.WideHelp)
+	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only show registry addresses")
+	return cmd
+}
+
+func runList(dockerCli command.Cli, opts listOptions) error {
+	creds, err := storedCredentials(dockerCli.ConfigFile())
+	if err != nil {
+		return err
+	}
+	credCtx := formatter.Context{
+		Output: dockerCli.Out(),
+		Format: newFormat(opts.format, opts.quiet, false),
+		Sort:   opts.sort,
+		Wide:   opts.wide,
+	}
+	return formatWrite(credCtx, creds, false)
+}
+
+// storedCredentials returns the credentials stored in all the configured
+// credentials stores and credential helpers, sorted by registry. Entries
+// without credentials, such as the entries kept in the configuration file for
+// credentials held by a credentials store, are omitted.
+func storedCredentials(configFile *configfile.ConfigFile) ([]credential, error) {
+	auths, err := configFile.GetAllCredentials()
+	if err != nil {
+		return nil, err
+	}
+	creds := make([]credential, 0, len(auths))
+	for serverAddress, ac := range auths {
+		if !credentials.HasSecrets(ac) {
+			continue
+		}
+		store := configFile.GetCredentialsStoreName(serverAddress)
+		if store == "" {
+			store = fileStoreName
+		}
+		creds = append(creds, credential{
+			ServerAddress: serverAddress,
+			Store:         store,
+			Username:      ac.Username,
+			IdentityToken: ac.IdentityToken != "",
+			authConfig:    ac,
+		})
+	}
+	sort.Slice(creds, func(i, j int) bool {
+		return sortorder.NaturalLess(creds[i].ServerAddress, creds[j].ServerAddress)
+	})
+	return creds, nil
+}
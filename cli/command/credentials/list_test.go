@@ -0,0 +1,51 @@
+package credentials
+
+import (
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/cli/config/types"
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func newTestCli(t *testing.T) *test.FakeCli {
+	t.Helper()
+	cfg := configfile.New(t.TempDir() + "/config.json")
+	cfg.AuthConfigs = map[string]types.AuthConfig{
+		"https://index.docker.io/v1/": {Username: "hubuser", Password: "pass", ServerAddress: "https://index.docker.io/v1/"},
+		"registry.example.com":        {Username: "user", IdentityToken: "token", ServerAddress: "registry.example.com"},
+		"registry.example.com/team-a": {Username: "team-a", Password: "pass", ServerAddress: "registry.example.com/team-a"},
+		"empty.example.com":           {ServerAddress: "empty.example.com"},
+	}
+	cli := test.NewFakeCli(nil)
+	cli.SetConfigFile(cfg)
+	return cli
+}
+
+func TestList(t *testing.T) {
+	cli := newTestCli(t)
+	assert.NilError(t, runList(cli, listOptions{}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), `REGISTRY                      STORE     USERNAME   IDENTITY TOKEN
+https://index.docker.io/v1/   file      hubuser    false
+registry.example.com          file      user       true
+registry.example.com/team-a   file      team-a     false
+`))
+}
+
+func TestListQuiet(t *testing.T) {
+	cli := newTestCli(t)
+	assert.NilError(t, runList(cli, listOptions{quiet: true}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "https://index.docker.io/v1/\nregistry.example.com\nregistry.example.com/team-a\n"))
+}
+
+func TestListFormat(t *testing.T) {
+	cli := newTestCli(t)
+	assert.NilError(t, runList(cli, listOptions{format: "{{.ServerAddress}} {{.Store}}"}))
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), "https://index.docker.io/v1/ file\nregistry.example.com file\nregistry.example.com/team-a file\n"))
+
+	cli = newTestCli(t)
+	assert.NilError(t, runList(cli, listOptions{format: "json", quiet: false}))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), `{"IdentityToken":true,"ServerAddress":"registry.example.com","Store":"file","Username":"user"}`))
+}
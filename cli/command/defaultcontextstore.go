@@ -131,6 +131,13 @@ func (s *ContextStoreWithDefault) GetMetadata(name string) (store.Metadata, erro
 	return s.Store.GetMetadata(name)
 }
 
+// TLSEncryptionEnabled implements store.TLSEncrypter, if the wrapped store
+// does.
+func (s *ContextStoreWithDefault) TLSEncryptionEnabled() bool {
+	e, ok := s.Store.(store.TLSEncrypter)
+	return ok && e.TLSEncryptionEnabled()
+}
+
 // ResetTLSMaterial is not implemented for default context and fails
 func (s *ContextStoreWithDefault) ResetTLSMaterial(name string, data *store.ContextTLSData) error {
 	if name == DefaultContextName {
@@ -182,6 +189,14 @@ func (s *ContextStoreWithDefault) GetTLSData(contextName, endpointName, fileName
 	return s.Store.GetTLSData(contextName, endpointName, fileName)
 }
 
+// GetRawTLSData implements store.RawTLSReader's GetRawTLSData
+func (s *ContextStoreWithDefault) GetRawTLSData(contextName, endpointName, fileName string) ([]byte, error) {
+	if r, ok := s.Store.(store.RawTLSReader); ok && contextName != DefaultContextName {
+		return r.GetRawTLSData(contextName, endpointName, fileName)
+	}
+	return s.GetTLSData(contextName, endpointName, fileName)
+}
+
 // GetStorageInfo implements store.Store's GetStorageInfo
 func (s *ContextStoreWithDefault) GetStorageInfo(contextName string) store.StorageInfo {
 	if contextName == DefaultContextName {
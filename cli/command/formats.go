@@ -0,0 +1,96 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// formatPresetPrefix is the prefix of values of the --format flag that
+	// refer to a format preset, such as "@ports".
+	formatPresetPrefix = "@"
+
+	// formatHelpKey is the value of the --format flag that lists the format
+	// presets of a command.
+	formatHelpKey = "help"
+)
+
+// ResolveFormat resolves the value of the --format flag of a command, given
+// the name of the command in the "formats" property of the configuration file
+// (such as "ps" or "images"), and the default format of the command, such as
+// the "psFormat" property of the configuration file, which is used if format
+// is empty:
+//
+//   - "@NAME" resolves to the format preset NAME of the command, whether it
+//     is the value of the --format flag or the default format.
+//   - "help" prints the format presets of the command. false is returned, as
+//     the command must not produce any other output.
+//   - other formats, including the empty format, are returned unchanged.
+//
+// false is also returned, with an error, if the format refers to a preset
+// that does not exist.
+func ResolveFormat(dockerCli Cli, cmdName, format, defaultFormat string) (string, bool, error) {
+	if format == "" {
+		if !strings.HasPrefix(defaultFormat, formatPresetPrefix) {
+			return defaultFormat, true, nil
+		}
+		format = defaultFormat
+	}
+	switch {
+	case format == formatHelpKey:
+		return "", false, printFormatPresets(dockerCli, cmdName)
+	case strings.HasPrefix(format, formatPresetPrefix):
+		name := strings.TrimPrefix(format, formatPresetPrefix)
+		preset, ok := dockerCli.ConfigFile().FormatPreset(cmdName, name)
+		if !ok {
+			return "", false, unknownFormatPreset(dockerCli, cmdName, name)
+		}
+		return preset, true, nil
+	default:
+		return format, true, nil
+	}
+}
+
+func unknownFormatPreset(dockerCli Cli, cmdName, name string) error {
+	names := formatPresetNames(dockerCli, cmdName)
+	if len(names) == 0 {
+		return errors.Errorf("unknown format preset %q: no format presets are defined for %q in the configuration file", formatPresetPrefix+name, cmdName)
+	}
+	for i, n := range names {
+		names[i] = formatPresetPrefix + n
+	}
+	return errors.Errorf("unknown format preset %q: available presets are %s", formatPresetPrefix+name, strings.Join(names, ", "))
+}
+
+// printFormatPresets prints the names and formats of the format presets of a
+// command.
+func printFormatPresets(dockerCli Cli, cmdName string) error {
+	names := formatPresetNames(dockerCli, cmdName)
+	if len(names) == 0 {
+		_, err := fmt.Fprintf(dockerCli.Out(), "No format presets are defined for %q in the configuration file\n", cmdName)
+		return err
+	}
+	// Formats can contain tabs and newlines, which would break the table.
+	escape := strings.NewReplacer("\t", `\t`, "\n", `\n`)
+	w := tabwriter.NewWriter(dockerCli.Out(), 0, 1, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tFORMAT")
+	for _, name := range names {
+		format, _ := dockerCli.ConfigFile().FormatPreset(cmdName, name)
+		fmt.Fprintf(w, "%s%s\t%s\n", formatPresetPrefix, name, escape.Replace(format))
+	}
+	return w.Flush()
+}
+
+func formatPresetNames(dockerCli Cli, cmdName string) []string {
+	presets := dockerCli.ConfigFile().Formats[cmdName]
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
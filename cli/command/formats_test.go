@@ -0,0 +1,91 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func newFormatsCli(t *testing.T, formats map[string]map[string]string) (*DockerCli, *bytes.Buffer) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	cli, err := NewDockerCli(WithOutputStream(out))
+	assert.NilError(t, err)
+	cli.configFile = &configfile.ConfigFile{Formats: formats}
+	return cli, out
+}
+
+func TestResolveFormat(t *testing.T) {
+	cli, out := newFormatsCli(t, map[string]map[string]string{
+		"ps":     {"ports": "table {{.Names}}\t{{.Ports}}", "mem": "table {{.Names}}\t{{.Size}}"},
+		"images": {"short": "{{.ID}}"},
+	})
+
+	testCases := []struct {
+		cmdName       string
+		format        string
+		defaultFormat string
+		expected      string
+	}{
+		{cmdName: "ps", format: "", expected: ""},
+		{cmdName: "ps", format: "json", expected: "json"},
+		{cmdName: "ps", format: "table {{.ID}}", expected: "table {{.ID}}"},
+		{cmdName: "ps", format: "@ports", expected: "table {{.Names}}\t{{.Ports}}"},
+		{cmdName: "images", format: "@short", expected: "{{.ID}}"},
+		{cmdName: "ps", format: "", defaultFormat: "table", expected: "table"},
+		{cmdName: "ps", format: "", defaultFormat: "@ports", expected: "table {{.Names}}\t{{.Ports}}"},
+		{cmdName: "ps", format: "json", defaultFormat: "@ports", expected: "json"},
+		{cmdName: "ps", format: "@mem", defaultFormat: "@ports", expected: "table {{.Names}}\t{{.Size}}"},
+	}
+	for _, tc := range testCases {
+		format, ok, err := ResolveFormat(cli, tc.cmdName, tc.format, tc.defaultFormat)
+		assert.NilError(t, err)
+		assert.Check(t, ok)
+		assert.Check(t, is.Equal(format, tc.expected), tc.format)
+	}
+	assert.Check(t, is.Equal(out.String(), ""))
+}
+
+func TestResolveFormatUnknownPreset(t *testing.T) {
+	cli, _ := newFormatsCli(t, map[string]map[string]string{
+		"ps": {"ports": "{{.Ports}}", "mem": "{{.Size}}"},
+	})
+
+	_, ok, err := ResolveFormat(cli, "ps", "@nope", "")
+	assert.Check(t, !ok)
+	assert.Check(t, is.Error(err, `unknown format preset "@nope": available presets are @mem, @ports`))
+
+	// the default format can refer to a preset as well
+	_, ok, err = ResolveFormat(cli, "ps", "", "@nope")
+	assert.Check(t, !ok)
+	assert.Check(t, is.Error(err, `unknown format preset "@nope": available presets are @mem, @ports`))
+
+	// presets are defined per command
+	_, ok, err = ResolveFormat(cli, "images", "@ports", "")
+	assert.Check(t, !ok)
+	assert.Check(t, is.Error(err, `unknown format preset "@ports": no format presets are defined for "images" in the configuration file`))
+}
+
+func TestResolveFormatHelp(t *testing.T) {
+	cli, out := newFormatsCli(t, map[string]map[string]string{
+		"ps": {"ports": "table {{.Names}}\t{{.Ports}}", "mem": "table {{.Names}}\\t{{.Size}}"},
+	})
+
+	_, ok, err := ResolveFormat(cli, "ps", "help", "")
+	assert.NilError(t, err)
+	assert.Check(t, !ok)
+	expected := `NAME     FORMAT
+@mem     table {{.Names}}\t{{.Size}}
+@ports   table {{.Names}}\t{{.Ports}}
+`
+	assert.Check(t, is.Equal(out.String(), expected))
+
+	out.Reset()
+	_, ok, err = ResolveFormat(cli, "images", "help", "")
+	assert.NilError(t, err)
+	assert.Check(t, !ok)
+	assert.Check(t, is.Equal(out.String(), "No format presets are defined for \"images\" in the configuration file\n"))
+}
@@ -0,0 +1,252 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/harness-community/docker-cli-v23/cli/streams"
+	"github.com/harness-community/docker-cli-v23/templates"
+	"github.com/pkg/errors"
+)
+
+// ContextHeader is the header of the column holding the name of the context
+// when merging the output of a command run against multiple contexts.
+const ContextHeader = "CONTEXT"
+
+// Collector records what is written by formatters to an output stream instead
+// of rendering it, so that the output of a command run against multiple
+// contexts can be merged using WriteMerged. Formatters collect their output if
+// the Output of their Context is a Collector, or an output stream writing to
+// a Collector.
+//
+// Text written directly to the Collector (i.e. not through a formatter) is
+// recorded as-is.
+type Collector struct {
+	mu       sync.Mutex
+	sections []*section
+	rows     []SubContext
+}
+
+// section is either raw text, or rows rendered using a format.
+type section struct {
+	raw     []byte
+	kind    Format
+	format  string
+	table   bool
+	columns csvColumns
+	sort    []sortKey
+	wide    bool
+	header  SubContext
+	rows    []SubContext
+}
+
+// NewCollector returns a new Collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Write records p as raw text.
+func (c *Collector) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n := len(c.sections); n > 0 && c.sections[n-1].raw != nil {
+		c.sections[n-1].raw = append(c.sections[n-1].raw, p...)
+	} else {
+		c.sections = append(c.sections, &section{raw: append([]byte{}, p...)})
+	}
+	return len(p), nil
+}
+
+func (c *Collector) addRow(sub SubContext) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rows = append(c.rows, sub)
+}
+
+func (c *Collector) endSection(ctx *Context, header SubContext) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sections = append(c.sections, &section{
+		kind:    ctx.Format,
+		format:  ctx.finalFormat,
+		table:   ctx.Format.IsTable(),
+		columns: ctx.csvColumns,
+		sort:    ctx.sortKeys,
+		wide:    ctx.Wide,
+		header:  header,
+		rows:    c.rows,
+	})
+	c.rows = nil
+}
+
+// collectorFor returns the Collector that out writes to, if any.
+func collectorFor(out io.Writer) (*Collector, bool) {
+	if o, ok := out.(*streams.Out); ok {
+		out = o.Writer()
+	}
+	c, ok := out.(*Collector)
+	return c, ok
+}
+
+// CollectedOutput is the output collected from running a command against a
+// context.
+type CollectedOutput struct {
+	Context   string
+	Collector *Collector
+}
+
+// WriteMerged merges the output collected from running the same command
+// against multiple contexts, and writes it to out. Table formats get an extra
+// CONTEXT column, as do CSV formats, and each object of the JSON and YAML
+// formats gets a "Context" field. Other formats are rendered unchanged, and raw text is only written once if
+// it is identical for all contexts.
+func WriteMerged(out io.Writer, outputs []CollectedOutput) error {
+	var count int
+	for _, o := range outputs {
+		if n := len(o.Collector.sections); n > count {
+			count = n
+		}
+	}
+	for i := 0; i < count; i++ {
+		var err error
+		if first := firstSection(outputs, i); first.raw != nil {
+			err = writeMergedRaw(out, outputs, i)
+		} else {
+			err = writeMergedRows(out, first, outputs, i)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func firstSection(outputs []CollectedOutput, i int) *section {
+	for _, o := range outputs {
+		if i < len(o.Collector.sections) {
+			return o.Collector.sections[i]
+		}
+	}
+	return nil
+}
+
+func writeMergedRaw(out io.Writer, outputs []CollectedOutput, i int) error {
+	var texts [][]byte
+	for _, o := range outputs {
+		if i < len(o.Collector.sections) && o.Collector.sections[i].raw != nil {
+			texts = append(texts, o.Collector.sections[i].raw)
+		}
+	}
+	identical := true
+	for _, t := range texts[1:] {
+		if !bytes.Equal(t, texts[0]) {
+			identical = false
+			break
+		}
+	}
+	if identical {
+		texts = texts[:1]
+	}
+	for _, t := range texts {
+		if _, err := out.Write(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMergedRows(out io.Writer, first *section, outputs []CollectedOutput, i int) error {
+	var rows []SubContext
+	contexts := []string{}
+	for _, o := range outputs {
+		if i >= len(o.Collector.sections) || o.Collector.sections[i].raw != nil {
+			continue
+		}
+		for _, row := range o.Collector.sections[i].rows {
+			rows = append(rows, row)
+			contexts = append(contexts, o.Context)
+		}
+	}
+	if err := sortRows(rows, contexts, first.sort); err != nil {
+		return err
+	}
+	switch {
+	case first.kind.IsYAML():
+		return writeYAML(out, rows, contexts)
+	case first.kind.IsCSV():
+		return writeCSV(out, first.columns, first.header, rows, contexts)
+	}
+
+	tmpl, err := templates.Parse(first.format)
+	if err != nil {
+		return errors.Wrap(err, "template parsing error")
+	}
+	if !isTerminal(out) {
+		templates.NoColor(tmpl)
+	}
+	isJSON := first.format == JSONFormat
+	buffer := bytes.NewBufferString("")
+	for j, row := range rows {
+		if first.table {
+			buffer.WriteString(contexts[j] + "\t")
+		}
+		if isJSON {
+			err = executeJSONWithContext(buffer, tmpl, row, contexts[j])
+		} else {
+			err = tmpl.Execute(buffer, row)
+		}
+		if err != nil {
+			return errors.Wrap(err, "template parsing error")
+		}
+		buffer.WriteString("\n")
+	}
+
+	if !first.table {
+		_, err = buffer.WriteTo(out)
+		return err
+	}
+	table := bytes.NewBufferString(ContextHeader + "\t")
+	tmpl.Funcs(templates.HeaderFunctions)
+	if !isTerminal(out) {
+		templates.NoColor(tmpl)
+	}
+	tmpl.Execute(table, first.header.FullHeader())
+	table.WriteString("\n")
+	buffer.WriteTo(table)
+
+	var width int
+	var priorities []int
+	if !first.wide {
+		width = terminalWidth(out)
+		if p := columnPriorities(first.format, first.header); p != nil {
+			// the CONTEXT column is not truncated
+			priorities = append([]int{0}, p...)
+		}
+	}
+	return writeTable(out, table.Bytes(), width, priorities)
+}
+
+// executeJSONWithContext renders row as a JSON object, adding a "Context"
+// field holding the name of the context it was produced by.
+func executeJSONWithContext(w io.Writer, tmpl *template.Template, row SubContext, contextName string) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, row); err != nil {
+		return err
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(buf.String())), &obj); err != nil {
+		_, err = buf.WriteTo(w)
+		return err
+	}
+	obj["Context"] = contextName
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,163 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/streams"
+	"github.com/harness-community/docker-v23/api/types/volume"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func collectVolumes(t *testing.T, format Format, header string, volumes ...*volume.Volume) *Collector {
+	t.Helper()
+	c := NewCollector()
+	if header != "" {
+		_, err := c.Write([]byte(header))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, VolumeWrite(Context{Output: c, Format: format}, volumes))
+	return c
+}
+
+func TestWriteMerged(t *testing.T) {
+	cases := []struct {
+		doc      string
+		format   Format
+		expected string
+	}{
+		{
+			doc:    "table",
+			format: NewVolumeFormat("table", false),
+			expected: `Volumes:
+CONTEXT   DRIVER    VOLUME NAME
+one       local     foo
+one       local     bar
+two       local     baz
+`,
+		},
+		{
+			doc:    "custom table",
+			format: "table {{.Name}}",
+			expected: `Volumes:
+CONTEXT   VOLUME NAME
+one       foo
+one       bar
+two       baz
+`,
+		},
+		{
+			doc:    "json",
+			format: "json",
+			expected: `Volumes:
+{"Availability":"N/A","Context":"one","Driver":"local","Group":"N/A","Labels":"","Links":"N/A","Mountpoint":"","Name":"foo","Scope":"","Size":"N/A","Status":"N/A"}
+{"Availability":"N/A","Context":"one","Driver":"local","Group":"N/A","Labels":"","Links":"N/A","Mountpoint":"","Name":"bar","Scope":"","Size":"N/A","Status":"N/A"}
+{"Availability":"N/A","Context":"two","Driver":"local","Group":"N/A","Labels":"","Links":"N/A","Mountpoint":"","Name":"baz","Scope":"","Size":"N/A","Status":"N/A"}
+`,
+		},
+		{
+			doc:    "csv",
+			format: "csv {{.Name}}\t{{.Driver}}",
+			expected: `Volumes:
+CONTEXT,VOLUME NAME,DRIVER
+one,foo,local
+one,bar,local
+two,baz,local
+`,
+		},
+		{
+			doc:    "csv all fields",
+			format: "csv",
+			expected: `Volumes:
+CONTEXT,Availability,Driver,Group,Labels,Links,Mountpoint,Name,Scope,Size,Status
+one,N/A,local,N/A,,N/A,,foo,,N/A,N/A
+one,N/A,local,N/A,,N/A,,bar,,N/A,N/A
+two,N/A,local,N/A,,N/A,,baz,,N/A,N/A
+`,
+		},
+		{
+			doc:    "yaml",
+			format: "yaml",
+			expected: `Volumes:
+- Availability: N/A
+  Context: one
+  Driver: local
+  Group: N/A
+  Labels: ""
+  Links: N/A
+  Mountpoint: ""
+  Name: foo
+  Scope: ""
+  Size: N/A
+  Status: N/A
+- Availability: N/A
+  Context: one
+  Driver: local
+  Group: N/A
+  Labels: ""
+  Links: N/A
+  Mountpoint: ""
+  Name: bar
+  Scope: ""
+  Size: N/A
+  Status: N/A
+- Availability: N/A
+  Context: two
+  Driver: local
+  Group: N/A
+  Labels: ""
+  Links: N/A
+  Mountpoint: ""
+  Name: baz
+  Scope: ""
+  Size: N/A
+  Status: N/A
+`,
+		},
+		{
+			doc:    "raw",
+			format: "{{.Name}}",
+			expected: `Volumes:
+foo
+bar
+baz
+`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			outputs := []CollectedOutput{
+				{Context: "one", Collector: collectVolumes(t, tc.format, "Volumes:\n",
+					&volume.Volume{Name: "foo", Driver: "local"},
+					&volume.Volume{Name: "bar", Driver: "local"},
+				)},
+				{Context: "two", Collector: collectVolumes(t, tc.format, "Volumes:\n",
+					&volume.Volume{Name: "baz", Driver: "local"},
+				)},
+			}
+			var out bytes.Buffer
+			assert.NilError(t, WriteMerged(&out, outputs))
+			assert.Check(t, is.Equal(out.String(), tc.expected))
+		})
+	}
+}
+
+func TestWriteMergedDifferentRaw(t *testing.T) {
+	outputs := []CollectedOutput{
+		{Context: "one", Collector: collectVolumes(t, "table {{.Name}}", "one\n")},
+		{Context: "two", Collector: collectVolumes(t, "table {{.Name}}", "two\n", &volume.Volume{Name: "foo"})},
+	}
+	var out bytes.Buffer
+	assert.NilError(t, WriteMerged(&out, outputs))
+	assert.Check(t, is.Equal(out.String(), "one\ntwo\nCONTEXT   VOLUME NAME\ntwo       foo\n"))
+}
+
+func TestCollectorOutputStream(t *testing.T) {
+	c := NewCollector()
+	assert.NilError(t, VolumeWrite(Context{Output: streams.NewOut(c), Format: "{{.Name}}"}, []*volume.Volume{{Name: "foo"}}))
+	assert.Check(t, is.Len(c.sections, 1))
+	assert.Check(t, is.Nil(c.sections[0].raw))
+	assert.Check(t, is.Len(c.sections[0].rows, 1))
+}
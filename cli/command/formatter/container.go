@@ -105,6 +105,15 @@ func NewContainerContext() *ContainerContext {
 		"LocalVolumes": localVolumes,
 		"Networks":     networksHeader,
 	}
+	containerCtx.Priorities = ColumnPriorities{
+		"Command":  1,
+		"Labels":   1,
+		"Mounts":   1,
+		"Networks": 2,
+		"Ports":    2,
+		"Image":    3,
+		"Status":   4,
+	}
 	return &containerCtx
 }
 
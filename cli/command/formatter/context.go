@@ -1,10 +1,24 @@
 package formatter
 
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
 const (
 	// ClientContextTableFormat is the default client context format.
 	ClientContextTableFormat = "table {{.Name}}{{if .Current}} *{{end}}\t{{.Description}}\t{{.DockerEndpoint}}\t{{.Error}}"
 
+	// ClientContextCheckTableFormat is the default client context format when
+	// the docker endpoints of contexts are checked.
+	ClientContextCheckTableFormat = "table {{.Name}}{{if .Current}} *{{end}}\t{{.DockerEndpoint}}\t{{.Reachable}}\t{{.Latency}}\t{{.ServerVersion}}\t{{.APIVersion}}\t{{.Error}}"
+
 	dockerEndpointHeader = "DOCKER ENDPOINT"
+	reachableHeader      = "REACHABLE"
+	latencyHeader        = "LATENCY"
+	serverVersionHeader  = "SERVER VERSION"
+	apiVersionHeader     = "API VERSION"
 	quietContextFormat   = "{{.Name}}"
 
 	maxErrLength = 45
@@ -12,10 +26,19 @@ const (
 
 // NewClientContextFormat returns a Format for rendering using a Context
 func NewClientContextFormat(source string, quiet bool) Format {
+	return NewClientContextCheckFormat(source, quiet, false)
+}
+
+// NewClientContextCheckFormat returns a Format for rendering using a Context,
+// which includes the results of checking the docker endpoints if check is set.
+func NewClientContextCheckFormat(source string, quiet bool, check bool) Format {
 	if quiet {
 		return quietContextFormat
 	}
 	if source == TableFormatKey {
+		if check {
+			return ClientContextCheckTableFormat
+		}
 		return ClientContextTableFormat
 	}
 	return Format(source)
@@ -25,9 +48,22 @@ func NewClientContextFormat(source string, quiet bool) Format {
 type ClientContext struct {
 	Name           string
 	Description    string
+	Labels         map[string]string
 	DockerEndpoint string
 	Current        bool
 	Error          string
+
+	// Check holds the result of checking the docker endpoint of the context.
+	// It is nil if the endpoint was not checked.
+	Check *EndpointCheck
+}
+
+// EndpointCheck is the result of checking a context's docker endpoint.
+type EndpointCheck struct {
+	Reachable     bool
+	Latency       time.Duration
+	ServerVersion string
+	APIVersion    string
 }
 
 // ClientContextWrite writes formatted contexts using the Context
@@ -53,8 +89,18 @@ func newClientContextContext() *clientContextContext {
 	ctx.Header = SubHeaderContext{
 		"Name":           NameHeader,
 		"Description":    DescriptionHeader,
+		"Labels":         LabelsHeader,
 		"DockerEndpoint": dockerEndpointHeader,
 		"Error":          ErrorHeader,
+		"Reachable":      reachableHeader,
+		"Latency":        latencyHeader,
+		"ServerVersion":  serverVersionHeader,
+		"APIVersion":     apiVersionHeader,
+	}
+	ctx.Priorities = ColumnPriorities{
+		"Description":    1,
+		"Error":          1,
+		"DockerEndpoint": 2,
 	}
 	return &ctx
 }
@@ -75,6 +121,25 @@ func (c *clientContextContext) Description() string {
 	return c.c.Description
 }
 
+// Labels returns the context's labels as a comma-separated list of
+// key=value pairs, sorted by key.
+func (c *clientContextContext) Labels() string {
+	if len(c.c.Labels) == 0 {
+		return ""
+	}
+	joinLabels := make([]string, 0, len(c.c.Labels))
+	for k, v := range c.c.Labels {
+		joinLabels = append(joinLabels, k+"="+v)
+	}
+	sort.Strings(joinLabels)
+	return strings.Join(joinLabels, ",")
+}
+
+// Label returns the value of the label with the given name.
+func (c *clientContextContext) Label(name string) string {
+	return c.c.Labels[name]
+}
+
 func (c *clientContextContext) DockerEndpoint() string {
 	return c.c.DockerEndpoint
 }
@@ -85,6 +150,43 @@ func (c *clientContextContext) Error() string {
 	return Ellipsis(c.c.Error, maxErrLength)
 }
 
+// Reachable returns whether the docker endpoint could be reached, or an empty
+// string if the endpoint was not checked.
+func (c *clientContextContext) Reachable() string {
+	switch {
+	case c.c.Check == nil:
+		return ""
+	case c.c.Check.Reachable:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+// Latency returns the round-trip time of pinging the docker endpoint.
+func (c *clientContextContext) Latency() string {
+	if c.c.Check == nil || !c.c.Check.Reachable {
+		return ""
+	}
+	return c.c.Check.Latency.Round(time.Millisecond).String()
+}
+
+// ServerVersion returns the version of the docker engine of the endpoint.
+func (c *clientContextContext) ServerVersion() string {
+	if c.c.Check == nil {
+		return ""
+	}
+	return c.c.Check.ServerVersion
+}
+
+// APIVersion returns the API version of the docker engine of the endpoint.
+func (c *clientContextContext) APIVersion() string {
+	if c.c.Check == nil {
+		return ""
+	}
+	return c.c.Check.APIVersion
+}
+
 // KubernetesEndpoint returns the kubernetes endpoint.
 //
 // Deprecated: support for kubernetes endpoints in contexts has been removed, and this formatting option will always be empty.
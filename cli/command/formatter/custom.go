@@ -37,12 +37,23 @@ func (c SubHeaderContext) Label(name string) string {
 	return h
 }
 
+// ColumnPriorities is a map destined to formatter column priorities (table
+// format). When a table is too wide for the terminal, the columns of the
+// fields with the lowest priority are truncated first. The columns of fields
+// without a priority are never truncated.
+type ColumnPriorities map[string]int
+
 // HeaderContext provides the subContext interface for managing headers
 type HeaderContext struct {
-	Header interface{}
+	Header     interface{}
+	Priorities ColumnPriorities
 }
 
 // FullHeader returns the header as an interface
 func (c *HeaderContext) FullHeader() interface{} {
 	return c.Header
 }
+
+func (c *HeaderContext) columnPriorities() ColumnPriorities {
+	return c.Priorities
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/harness-community/docker-v23/api/types/volume"
 	units "github.com/docker/go-units"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -44,6 +45,7 @@ type DiskUsageContext struct {
 func (ctx *DiskUsageContext) startSubsection(format string) (*template.Template, error) {
 	ctx.buffer = bytes.NewBufferString("")
 	ctx.header = ""
+	ctx.rows = nil
 	ctx.Format = Format(format)
 	ctx.preFormat()
 
@@ -87,6 +89,7 @@ func (ctx *DiskUsageContext) Write() (err error) {
 		return ctx.verboseWrite()
 	}
 	ctx.buffer = bytes.NewBufferString("")
+	ctx.rows = nil
 	ctx.preFormat()
 
 	tmpl, err := ctx.parseFormat()
@@ -131,9 +134,7 @@ func (ctx *DiskUsageContext) Write() (err error) {
 		"Size":        SizeHeader,
 		"Reclaimable": reclaimableHeader,
 	}
-	ctx.postFormat(tmpl, &diskUsageContainersCtx)
-
-	return err
+	return ctx.postFormat(tmpl, &diskUsageContainersCtx)
 }
 
 type diskUsageContext struct {
@@ -194,8 +195,13 @@ func (ctx *DiskUsageContext) verboseWrite() error {
 		duc.BuildCache = append(duc.BuildCache, &buildCacheContext{v: v, trunc: trunc})
 	}
 
-	if ctx.Format == TableFormatKey {
+	switch {
+	case ctx.Format == TableFormatKey:
 		return ctx.verboseWriteTable(duc)
+	case ctx.Format.IsYAML():
+		return writeYAMLValue(ctx.Output, duc)
+	case ctx.Format.IsCSV():
+		return errors.New("the csv format is not supported in verbose mode")
 	}
 
 	ctx.preFormat()
@@ -217,7 +223,9 @@ func (ctx *DiskUsageContext) verboseWriteTable(duc *diskUsageContext) error {
 			return err
 		}
 	}
-	ctx.postFormat(tmpl, newImageContext())
+	if err := ctx.postFormat(tmpl, newImageContext()); err != nil {
+		return err
+	}
 
 	tmpl, err = ctx.startSubsection(defaultDiskUsageContainerTableFormat)
 	if err != nil {
@@ -229,7 +237,9 @@ func (ctx *DiskUsageContext) verboseWriteTable(duc *diskUsageContext) error {
 			return err
 		}
 	}
-	ctx.postFormat(tmpl, NewContainerContext())
+	if err := ctx.postFormat(tmpl, NewContainerContext()); err != nil {
+		return err
+	}
 
 	tmpl, err = ctx.startSubsection(defaultDiskUsageVolumeTableFormat)
 	if err != nil {
@@ -241,7 +251,9 @@ func (ctx *DiskUsageContext) verboseWriteTable(duc *diskUsageContext) error {
 			return err
 		}
 	}
-	ctx.postFormat(tmpl, newVolumeContext())
+	if err := ctx.postFormat(tmpl, newVolumeContext()); err != nil {
+		return err
+	}
 
 	tmpl, err = ctx.startSubsection(defaultDiskUsageBuildCacheTableFormat)
 	if err != nil {
@@ -253,9 +265,7 @@ func (ctx *DiskUsageContext) verboseWriteTable(duc *diskUsageContext) error {
 			return err
 		}
 	}
-	ctx.postFormat(tmpl, newBuildCacheContext())
-
-	return nil
+	return ctx.postFormat(tmpl, newBuildCacheContext())
 }
 
 type diskUsageImagesContext struct {
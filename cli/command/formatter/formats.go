@@ -0,0 +1,247 @@
+package formatter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/harness-community/docker-cli-v23/templates"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// fieldsOf returns the fields of a row, as rendered by the json format.
+func fieldsOf(row SubContext) (map[string]interface{}, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var fields map[string]interface{}
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	for k, v := range fields {
+		fields[k] = normalizeNumbers(v)
+	}
+	return fields, nil
+}
+
+// normalizeNumbers converts the json.Numbers in v to int64 or float64, so
+// that integers are not rendered in exponent notation.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalizeNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalizeNumbers(e)
+		}
+	}
+	return v
+}
+
+// writeYAML renders rows as a YAML sequence of mappings, with the same
+// fields as the json format. If contexts is set, each mapping gets a
+// "Context" field holding the context the row was produced by.
+func writeYAML(out io.Writer, rows []SubContext, contexts []string) error {
+	items := make([]map[string]interface{}, 0, len(rows))
+	for i, row := range rows {
+		fields, err := fieldsOf(row)
+		if err != nil {
+			return err
+		}
+		if contexts != nil {
+			fields["Context"] = contexts[i]
+		}
+		items = append(items, fields)
+	}
+	return writeYAMLValue(out, items)
+}
+
+// writeYAMLValue renders v as YAML, with the same fields as when rendering
+// it as JSON.
+func writeYAMLValue(out io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+	data, err = yaml.Marshal(normalizeNumbers(value))
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
+
+// csvColumns are the templates of the columns of a "csv TEMPLATE" format,
+// which are separated by tabs as in table formats. Each column is rendered
+// separately so that values holding tabs are quoted properly. Colors are
+// never rendered in CSV.
+type csvColumns []*template.Template
+
+func parseCSVColumns(format string) (csvColumns, error) {
+	if format == "" {
+		return nil, nil
+	}
+	var columns csvColumns
+	for _, col := range strings.Split(format, "\t") {
+		tmpl, err := templates.Parse(col)
+		if err != nil {
+			return nil, errors.Wrap(err, "template parsing error")
+		}
+		columns = append(columns, templates.NoColor(tmpl))
+	}
+	return columns, nil
+}
+
+// writeCSV renders rows as CSV, with a header row. If columns is empty, all
+// the fields of the json format are rendered, sorted by name. If contexts is
+// set, a CONTEXT column holding the context each row was produced by is
+// added first.
+func writeCSV(out io.Writer, columns csvColumns, header SubContext, rows []SubContext, contexts []string) error {
+	w := csv.NewWriter(out)
+	if len(columns) == 0 {
+		if err := writeCSVFields(w, header, rows, contexts); err != nil {
+			return err
+		}
+	} else {
+		if err := writeCSVColumns(w, columns, header, rows, contexts); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func writeCSVColumns(w *csv.Writer, columns csvColumns, header SubContext, rows []SubContext, contexts []string) error {
+	render := func(data interface{}, headers bool) ([]string, error) {
+		record := make([]string, 0, len(columns))
+		for _, col := range columns {
+			tmpl := col
+			if headers {
+				// Clone, as Funcs modifies the template, which is used again
+				// to render the rows.
+				var err error
+				if tmpl, err = col.Clone(); err != nil {
+					return nil, err
+				}
+				tmpl = templates.NoColor(tmpl.Funcs(templates.HeaderFunctions))
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return nil, errors.Wrap(err, "template parsing error")
+			}
+			record = append(record, buf.String())
+		}
+		return record, nil
+	}
+
+	var headerRecord []string
+	if header != nil {
+		var err error
+		if headerRecord, err = render(header.FullHeader(), true); err != nil {
+			return err
+		}
+	}
+	if contexts != nil {
+		headerRecord = append([]string{ContextHeader}, headerRecord...)
+	}
+	if err := w.Write(headerRecord); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		record, err := render(row, false)
+		if err != nil {
+			return err
+		}
+		if contexts != nil {
+			record = append([]string{contexts[i]}, record...)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSVFields renders all the fields of the json format of rows. If there
+// are no rows, the column names are taken from the fields of header instead,
+// so that the header row is still written.
+func writeCSVFields(w *csv.Writer, header SubContext, rows []SubContext, contexts []string) error {
+	var names []string
+	if len(rows) == 0 && header != nil {
+		names = fieldNames(header)
+	}
+	if len(rows) == 0 && len(names) == 0 {
+		return nil
+	}
+	records := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		fields, err := fieldsOf(row)
+		if err != nil {
+			return err
+		}
+		if names == nil {
+			for name := range fields {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+		}
+		records = append(records, fields)
+	}
+
+	headerRecord := names
+	if contexts != nil {
+		headerRecord = append([]string{ContextHeader}, names...)
+	}
+	if err := w.Write(headerRecord); err != nil {
+		return err
+	}
+	for i, fields := range records {
+		record := make([]string, 0, len(headerRecord))
+		if contexts != nil {
+			record = append(record, contexts[i])
+		}
+		for _, name := range names {
+			record = append(record, csvValue(fields[name]))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// csvValue renders a field of the json format as a CSV value. Values which
+// are not strings or numbers are rendered as JSON.
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
@@ -0,0 +1,101 @@
+package formatter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-v23/api/types"
+	"github.com/harness-community/docker-v23/api/types/volume"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/golden"
+)
+
+func TestVolumeContextWriteFormats(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Driver: "local", Name: "foobar_baz", Mountpoint: "/data/foobar_baz", Labels: map[string]string{"com.example": "value"}, UsageData: &volume.UsageData{Size: 1024, RefCount: 2}},
+		{Driver: "local", Name: `with,comma "and" quotes`, Mountpoint: "/data/with\ttab"},
+	}
+	cases := []struct {
+		format Format
+		golden string
+	}{
+		{format: "yaml", golden: "volume-context-write-yaml.golden"},
+		{format: "csv", golden: "volume-context-write-csv.golden"},
+		{format: "csv {{.Name}}\t{{.Mountpoint}}\t{{.Labels}}", golden: "volume-context-write-csv-template.golden"},
+		{format: "ndjson", golden: "volume-context-write-ndjson.golden"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(string(tc.format), func(t *testing.T) {
+			out := bytes.NewBufferString("")
+			assert.NilError(t, VolumeWrite(Context{Format: tc.format, Output: out}, volumes))
+			golden.Assert(t, out.String(), tc.golden)
+		})
+	}
+}
+
+// TestContainerContextWriteFormats checks the formats with a pointer-based
+// sub-context that keeps state between fields (see ContainerContext.Size).
+func TestContainerContextWriteFormats(t *testing.T) {
+	created := time.Now().Add(-72 * time.Hour).Unix()
+	containers := []types.Container{
+		{
+			ID: "containerID1", Names: []string{"/foobar_baz"}, Image: "ubuntu", Command: "bash",
+			Created: created, State: "running", Status: "Up 3 days", SizeRw: 10, SizeRootFs: 20,
+			Labels: map[string]string{"com.example": "value"},
+			Ports:  []types.Port{{IP: "0.0.0.0", PrivatePort: 80, PublicPort: 8080, Type: "tcp"}},
+		},
+		{
+			ID: "containerID2", Names: []string{"/with,comma"}, Image: "alpine", Command: `sh -c "echo hi"`,
+			Created: created, State: "exited", Status: "Exited (0) 2 days ago",
+		},
+	}
+	cases := []struct {
+		format Format
+		golden string
+	}{
+		{format: "yaml", golden: "container-context-write-yaml.golden"},
+		{format: "csv", golden: "container-context-write-csv.golden"},
+		{format: "csv {{.Names}}\t{{.Command}}\t{{.Ports}}\t{{.Size}}", golden: "container-context-write-csv-template.golden"},
+		{format: "ndjson", golden: "container-context-write-ndjson.golden"},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(string(tc.format), func(t *testing.T) {
+			out := bytes.NewBufferString("")
+			assert.NilError(t, ContainerWrite(Context{Format: tc.format, Output: out}, containers))
+			// the creation time is rendered in the local time zone
+			expected := strings.ReplaceAll(string(golden.Get(t, tc.golden)), "<CREATED_AT>", time.Unix(created, 0).String())
+			assert.Check(t, is.Equal(out.String(), expected))
+		})
+	}
+}
+
+func TestVolumeContextWriteFormatsEmpty(t *testing.T) {
+	cases := []struct {
+		format   Format
+		expected string
+	}{
+		{format: "yaml", expected: "[]\n"},
+		{format: "csv", expected: "Availability,Driver,Group,Labels,Links,Mountpoint,Name,Scope,Size,Status\n"},
+		{format: "csv {{.Name}}\t{{.Driver}}", expected: "VOLUME NAME,DRIVER\n"},
+		{format: "ndjson", expected: ""},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(string(tc.format), func(t *testing.T) {
+			out := bytes.NewBufferString("")
+			assert.NilError(t, VolumeWrite(Context{Format: tc.format, Output: out}, nil))
+			assert.Check(t, is.Equal(out.String(), tc.expected))
+		})
+	}
+}
+
+func TestCSVTemplateError(t *testing.T) {
+	out := bytes.NewBufferString("")
+	err := VolumeWrite(Context{Format: "csv {{.Name}\t{{.Driver}}", Output: out}, []*volume.Volume{{Name: "foo"}})
+	assert.Check(t, is.ErrorContains(err, "template parsing error"))
+}
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/harness-community/docker-cli-v23/cli/command/formatter/tabwriter"
 	"github.com/harness-community/docker-cli-v23/templates"
 	"github.com/pkg/errors"
 )
@@ -17,6 +16,9 @@ const (
 	RawFormatKey    = "raw"
 	PrettyFormatKey = "pretty"
 	JSONFormatKey   = "json"
+	NDJSONFormatKey = "ndjson"
+	YAMLFormatKey   = "yaml"
+	CSVFormatKey    = "csv"
 
 	DefaultQuietFormat = "{{.ID}}"
 	JSONFormat         = "{{json .}}"
@@ -35,6 +37,23 @@ func (f Format) IsJSON() bool {
 	return string(f) == JSONFormatKey
 }
 
+// IsNDJSON returns true if the format is the ndjson format, which renders
+// each object as JSON on a separate line
+func (f Format) IsNDJSON() bool {
+	return string(f) == NDJSONFormatKey
+}
+
+// IsYAML returns true if the format is the yaml format
+func (f Format) IsYAML() bool {
+	return string(f) == YAMLFormatKey
+}
+
+// IsCSV returns true if the format is a csv-type format, either "csv" or
+// "csv TEMPLATE"
+func (f Format) IsCSV() bool {
+	return string(f) == CSVFormatKey || strings.HasPrefix(string(f), CSVFormatKey+" ")
+}
+
 // Contains returns true if the format contains the substring
 func (f Format) Contains(sub string) bool {
 	return strings.Contains(string(f), sub)
@@ -48,11 +67,23 @@ type Context struct {
 	Format Format
 	// Trunc when set to true will truncate the output of certain fields such as Container ID.
 	Trunc bool
+	// Sort is a comma-separated list of fields to sort the output by, each
+	// optionally followed by ":desc" to sort in descending order.
+	Sort string
+	// Wide when set to true disables truncating the columns of table formats
+	// to fit the width of the terminal.
+	Wide bool
+	// Width is the width that table formats are fitted to. If it is zero, the
+	// width of the terminal is used when Output is a terminal.
+	Width int
 
 	// internal element
 	finalFormat string
 	header      interface{}
 	buffer      *bytes.Buffer
+	csvColumns  csvColumns
+	sortKeys    []sortKey
+	rows        []SubContext
 }
 
 func (c *Context) preFormat() {
@@ -61,8 +92,12 @@ func (c *Context) preFormat() {
 	switch {
 	case c.Format.IsTable():
 		c.finalFormat = c.finalFormat[len(TableFormatKey):]
-	case c.Format.IsJSON():
+	case c.Format.IsJSON(), c.Format.IsNDJSON():
 		c.finalFormat = JSONFormat
+	case c.Format.IsYAML():
+		c.finalFormat = ""
+	case c.Format.IsCSV():
+		c.finalFormat = c.finalFormat[len(CSVFormatKey):]
 	}
 
 	c.finalFormat = strings.Trim(c.finalFormat, " ")
@@ -71,28 +106,85 @@ func (c *Context) preFormat() {
 }
 
 func (c *Context) parseFormat() (*template.Template, error) {
+	if c.Format.IsCSV() {
+		var err error
+		if c.csvColumns, err = parseCSVColumns(c.finalFormat); err != nil {
+			return nil, err
+		}
+	}
 	tmpl, err := templates.Parse(c.finalFormat)
 	if err != nil {
 		return tmpl, errors.Wrap(err, "template parsing error")
 	}
+	if !isTerminal(c.Output) {
+		templates.NoColor(tmpl)
+	}
 	return tmpl, err
 }
 
-func (c *Context) postFormat(tmpl *template.Template, subContext SubContext) {
+func (c *Context) postFormat(tmpl *template.Template, subContext SubContext) error {
+	if collector, ok := collectorFor(c.Output); ok {
+		collector.endSection(c, subContext)
+		return nil
+	}
+	if err := sortRows(c.rows, nil, c.sortKeys); err != nil {
+		return err
+	}
+	switch {
+	case c.Format.IsYAML():
+		return writeYAML(c.Output, c.rows, nil)
+	case c.Format.IsCSV():
+		return writeCSV(c.Output, c.csvColumns, subContext, c.rows, nil)
+	}
+	// rows that were not rendered by contextFormat, so that they could be
+	// sorted
+	for _, row := range c.rows {
+		if err := c.executeRow(tmpl, row); err != nil {
+			return err
+		}
+	}
 	if c.Format.IsTable() {
-		t := tabwriter.NewWriter(c.Output, 10, 1, 3, ' ', 0)
 		buffer := bytes.NewBufferString("")
-		tmpl.Funcs(templates.HeaderFunctions).Execute(buffer, subContext.FullHeader())
-		buffer.WriteTo(t)
-		t.Write([]byte("\n"))
-		c.buffer.WriteTo(t)
-		t.Flush()
-	} else {
-		c.buffer.WriteTo(c.Output)
+		tmpl.Funcs(templates.HeaderFunctions)
+		if !isTerminal(c.Output) {
+			templates.NoColor(tmpl)
+		}
+		tmpl.Execute(buffer, subContext.FullHeader())
+		buffer.WriteString("\n")
+		c.buffer.WriteTo(buffer)
+		return writeTable(c.Output, buffer.Bytes(), c.tableWidth(), columnPriorities(c.finalFormat, subContext))
+	}
+	c.buffer.WriteTo(c.Output)
+	return nil
+}
+
+// tableWidth returns the width that table formats are fitted to, or 0 if
+// they are not fitted.
+func (c *Context) tableWidth() int {
+	switch {
+	case c.Wide:
+		return 0
+	case c.Width > 0:
+		return c.Width
+	default:
+		return terminalWidth(c.Output)
 	}
 }
 
 func (c *Context) contextFormat(tmpl *template.Template, subContext SubContext) error {
+	if collector, ok := collectorFor(c.Output); ok {
+		collector.addRow(subContext)
+		return nil
+	}
+	if c.Format.IsYAML() || c.Format.IsCSV() || len(c.sortKeys) > 0 {
+		// rendered at once by postFormat
+		c.rows = append(c.rows, subContext)
+		return nil
+	}
+	return c.executeRow(tmpl, subContext)
+}
+
+func (c *Context) executeRow(tmpl *template.Template, subContext SubContext) error {
 	if err := tmpl.Execute(c.buffer, subContext); err != nil {
 		return errors.Wrap(err, "template parsing error")
 	}
@@ -109,12 +201,16 @@ type SubFormat func(func(SubContext) error) error
 // Write the template to the buffer using this Context
 func (c *Context) Write(sub SubContext, f SubFormat) error {
 	c.buffer = bytes.NewBufferString("")
+	c.rows = nil
 	c.preFormat()
 
 	tmpl, err := c.parseFormat()
 	if err != nil {
 		return err
 	}
+	if c.sortKeys, err = parseSort(c.Sort, sub); err != nil {
+		return err
+	}
 
 	subFormat := func(subContext SubContext) error {
 		return c.contextFormat(tmpl, subContext)
@@ -123,6 +219,5 @@ func (c *Context) Write(sub SubContext, f SubFormat) error {
 		return err
 	}
 
-	c.postFormat(tmpl, sub)
-	return nil
+	return c.postFormat(tmpl, sub)
 }
@@ -19,6 +19,23 @@ func TestFormat(t *testing.T) {
 	f = Format("other")
 	assert.Assert(t, !f.IsJSON())
 	assert.Assert(t, !f.IsTable())
+
+	f = Format("ndjson")
+	assert.Assert(t, f.IsNDJSON())
+	assert.Assert(t, !f.IsJSON())
+
+	f = Format("yaml")
+	assert.Assert(t, f.IsYAML())
+	assert.Assert(t, !f.IsTable())
+
+	f = Format("csv")
+	assert.Assert(t, f.IsCSV())
+
+	f = Format("csv {{.Name}}")
+	assert.Assert(t, f.IsCSV())
+
+	f = Format("csvfoo")
+	assert.Assert(t, !f.IsCSV())
 }
 
 type fakeSubContext struct {
@@ -209,6 +209,10 @@ func newImageContext() *imageContext {
 		"SharedSize":   sharedSizeHeader,
 		"UniqueSize":   uniqueSizeHeader,
 	}
+	imageCtx.Priorities = ColumnPriorities{
+		"Repository": 1,
+		"Tag":        2,
+	}
 	return &imageCtx
 }
 
@@ -3,6 +3,8 @@ package formatter
 import (
 	"encoding/json"
 	"reflect"
+	"sort"
+	"strings"
 	"unicode"
 
 	"github.com/pkg/errors"
@@ -66,3 +68,53 @@ func marshalForMethod(typ reflect.Method, val reflect.Value) (string, interface{
 	intf := result[0].Interface()
 	return name, intf, nil
 }
+
+// fieldName returns the name of the field of x matching name, which is
+// matched case-insensitively, and whether such a field exists. Fields are the
+// methods of x that are marshalled by MarshalJSON.
+func fieldName(x interface{}, name string) (string, bool) {
+	val := reflect.ValueOf(x)
+	typ := val.Type()
+	for i := 0; i < val.NumMethod(); i++ {
+		method := typ.Method(i)
+		if !strings.EqualFold(method.Name, name) {
+			continue
+		}
+		_, blackListed := unmarshallableNames[method.Name]
+		fn := val.Method(i).Type()
+		if unicode.IsUpper(rune(method.Name[0])) && !blackListed && fn.NumIn() == 0 && fn.NumOut() == 1 {
+			return method.Name, true
+		}
+	}
+	return "", false
+}
+
+// fieldNames returns the names of the fields of x, sorted, without calling
+// the methods they are rendered by. Fields are the methods of x that are
+// marshalled by MarshalJSON.
+func fieldNames(x interface{}) []string {
+	val := reflect.ValueOf(x)
+	typ := val.Type()
+	var names []string
+	for i := 0; i < val.NumMethod(); i++ {
+		method := typ.Method(i)
+		_, blackListed := unmarshallableNames[method.Name]
+		fn := val.Method(i).Type()
+		if unicode.IsUpper(rune(method.Name[0])) && !blackListed && fn.NumIn() == 0 && fn.NumOut() == 1 {
+			names = append(names, method.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// fieldValue returns the value of the field of x with the given name, as
+// returned by fieldName.
+func fieldValue(x interface{}, name string) (interface{}, error) {
+	method := reflect.ValueOf(x).MethodByName(name)
+	if !method.IsValid() {
+		return nil, errors.Errorf("unknown field %q", name)
+	}
+	_, v, err := marshalForMethod(reflect.Method{Name: name}, method)
+	return v, err
+}
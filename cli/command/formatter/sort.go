@@ -0,0 +1,226 @@
+package formatter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/fvbommel/sortorder"
+	"github.com/pkg/errors"
+)
+
+// sortKey is a field to sort rows by.
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// parseSort parses a comma-separated list of fields to sort rows by, each
+// optionally followed by ":asc" or ":desc" (e.g. "size:desc,name"). Fields
+// are validated, and their names resolved, against sub.
+func parseSort(spec string, sub SubContext) ([]sortKey, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	var keys []sortKey
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		var key sortKey
+		if i := strings.LastIndex(field, ":"); i >= 0 {
+			switch order := strings.ToLower(field[i+1:]); order {
+			case "asc":
+			case "desc":
+				key.desc = true
+			default:
+				return nil, errors.Errorf("invalid sort order %q for field %q: must be asc or desc", order, field[:i])
+			}
+			field = field[:i]
+		}
+		name, ok := fieldName(sub, strings.TrimPrefix(field, "."))
+		if !ok {
+			return nil, errors.Errorf("invalid sort field %q", field)
+		}
+		key.field = name
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SortRows sorts rows by the comma-separated fields of spec, which are
+// validated against sub, as done for the Sort field of Context. It is used by
+// commands that must sort their rows before rendering them.
+func SortRows(spec string, sub SubContext, rows []SubContext) error {
+	keys, err := parseSort(spec, sub)
+	if err != nil {
+		return err
+	}
+	return sortRows(rows, nil, keys)
+}
+
+// sortRows sorts rows by keys. Rows that compare equal keep their relative
+// order. If contexts is set, it is reordered along with rows.
+func sortRows(rows []SubContext, contexts []string, keys []sortKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	s := &rowSorter{rows: rows, contexts: contexts, keys: keys, values: make([][]sortValue, len(rows))}
+	for i, row := range rows {
+		for _, key := range keys {
+			v, err := fieldValue(row, key.field)
+			if err != nil {
+				return err
+			}
+			s.values[i] = append(s.values[i], newSortValue(v))
+		}
+	}
+	sort.Stable(s)
+	return nil
+}
+
+type rowSorter struct {
+	rows     []SubContext
+	contexts []string
+	keys     []sortKey
+	values   [][]sortValue
+}
+
+func (s *rowSorter) Len() int {
+	return len(s.rows)
+}
+
+func (s *rowSorter) Swap(i, j int) {
+	s.rows[i], s.rows[j] = s.rows[j], s.rows[i]
+	s.values[i], s.values[j] = s.values[j], s.values[i]
+	if s.contexts != nil {
+		s.contexts[i], s.contexts[j] = s.contexts[j], s.contexts[i]
+	}
+}
+
+func (s *rowSorter) Less(i, j int) bool {
+	for k, key := range s.keys {
+		a, b := s.values[i][k], s.values[j][k]
+		if key.desc {
+			a, b = b, a
+		}
+		if a.less(b) {
+			return true
+		}
+		if b.less(a) {
+			return false
+		}
+	}
+	return false
+}
+
+type sortKind int
+
+const (
+	sortString sortKind = iota
+	sortNumber
+	sortSize
+	sortDuration
+	sortTime
+)
+
+// sortValue is the value of a field, which is compared semantically if it is
+// a number, a size (e.g. "1.5GB"), a duration (e.g. "About an hour ago"), or
+// a timestamp, and using a natural sort order otherwise.
+type sortValue struct {
+	kind sortKind
+	num  float64
+	str  string
+}
+
+func (v sortValue) less(o sortValue) bool {
+	if v.kind != sortString && v.kind == o.kind {
+		return v.num < o.num
+	}
+	return sortorder.NaturalLess(v.str, o.str)
+}
+
+var (
+	// sizeRe matches sizes as formatted by units.HumanSize and
+	// units.BytesSize, possibly followed by other information, such as in
+	// "1.2MB (virtual 5GB)".
+	sizeRe = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s?([kKMGTPEZY]i?)?B(?:\s|$)`)
+
+	// durationRe matches durations as formatted by units.HumanDuration,
+	// possibly as part of the status of a container (e.g. "Up 2 hours", or
+	// "Exited (0) 5 minutes ago").
+	durationRe = regexp.MustCompile(`^(?:Up |(?:Exited|Restarting) \(-?\d+\) )?(Less than a|About an?|\d+) (second|minute|hour|day|week|month|year)s?(?: ago)?(?:\s\(.*\))?$`)
+
+	durationUnits = map[string]time.Duration{
+		"second": time.Second,
+		"minute": time.Minute,
+		"hour":   time.Hour,
+		"day":    24 * time.Hour,
+		"week":   7 * 24 * time.Hour,
+		"month":  30 * 24 * time.Hour,
+		"year":   365 * 24 * time.Hour,
+	}
+
+	timeLayouts = []string{
+		"2006-01-02 15:04:05 -0700 MST",
+		time.RFC3339Nano,
+	}
+)
+
+func newSortValue(x interface{}) sortValue {
+	switch x := x.(type) {
+	case int:
+		return sortValue{kind: sortNumber, num: float64(x), str: strconv.Itoa(x)}
+	case int64:
+		return sortValue{kind: sortNumber, num: float64(x), str: strconv.FormatInt(x, 10)}
+	case uint64:
+		return sortValue{kind: sortNumber, num: float64(x), str: strconv.FormatUint(x, 10)}
+	case float64:
+		return sortValue{kind: sortNumber, num: x, str: strconv.FormatFloat(x, 'f', -1, 64)}
+	case time.Time:
+		return sortValue{kind: sortTime, num: float64(x.UnixNano()), str: x.String()}
+	case string:
+		return parseSortValue(x)
+	}
+	return parseSortValue(fmt.Sprint(x))
+}
+
+func parseSortValue(s string) sortValue {
+	v := sortValue{str: s}
+	if n, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64); err == nil {
+		v.kind, v.num = sortNumber, n
+		return v
+	}
+	if m := sizeRe.FindStringSubmatch(s); m != nil {
+		parse := units.FromHumanSize
+		if strings.HasSuffix(m[2], "i") {
+			parse = units.RAMInBytes
+		}
+		if n, err := parse(m[1] + m[2] + "B"); err == nil {
+			v.kind, v.num = sortSize, float64(n)
+			return v
+		}
+	}
+	if m := durationRe.FindStringSubmatch(s); m != nil {
+		var n float64
+		switch m[1] {
+		case "Less than a":
+			n = 0
+		case "About a", "About an":
+			n = 1
+		default:
+			n, _ = strconv.ParseFloat(m[1], 64)
+		}
+		v.kind, v.num = sortDuration, n*float64(durationUnits[m[2]])
+		return v
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			v.kind, v.num = sortTime, float64(t.UnixNano())
+			return v
+		}
+	}
+	return v
+}
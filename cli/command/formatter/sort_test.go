@@ -0,0 +1,210 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/harness-community/docker-v23/api/types/volume"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type sortSubContext struct {
+	HeaderContext
+	name  string
+	value string
+}
+
+func (c *sortSubContext) Name() string {
+	return c.name
+}
+
+func (c *sortSubContext) Value() string {
+	return c.value
+}
+
+func TestSortRows(t *testing.T) {
+	cases := []struct {
+		doc      string
+		spec     string
+		rows     []*sortSubContext
+		expected []string
+	}{
+		{
+			doc:  "natural order",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "volume-10"},
+				{name: "b", value: "volume-2"},
+				{name: "c", value: "volume-1"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "numbers",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "10"},
+				{name: "b", value: "9"},
+				{name: "c", value: "100"},
+			},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			doc:  "percentages",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "10.5%"},
+				{name: "b", value: "9.25%"},
+				{name: "c", value: "0.00%"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "sizes",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "1.5GB"},
+				{name: "b", value: "900kB"},
+				{name: "c", value: "12MB (virtual 2GB)"},
+			},
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			doc:  "binary sizes",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "1GiB / 2GiB"},
+				{name: "b", value: "512MiB / 2GiB"},
+				{name: "c", value: "0B / 0B"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "durations",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "3 weeks ago"},
+				{name: "b", value: "About an hour ago"},
+				{name: "c", value: "10 minutes ago"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "container status",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "Up 2 days"},
+				{name: "b", value: "Exited (0) 5 hours ago"},
+				{name: "c", value: "Up Less than a second"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "timestamps",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "2023-01-02 15:04:05 +0000 UTC"},
+				{name: "b", value: "2022-12-31 23:00:00 -0500 EST"},
+				{name: "c", value: "2023-01-01 00:00:00 +0000 UTC"},
+			},
+			expected: []string{"c", "b", "a"},
+		},
+		{
+			doc:  "descending",
+			spec: "value:desc",
+			rows: []*sortSubContext{
+				{name: "a", value: "1kB"},
+				{name: "b", value: "1MB"},
+				{name: "c", value: "1B"},
+			},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			doc:  "stable",
+			spec: "value",
+			rows: []*sortSubContext{
+				{name: "a", value: "N/A"},
+				{name: "b", value: "1kB"},
+				{name: "c", value: "N/A"},
+			},
+			expected: []string{"b", "a", "c"},
+		},
+		{
+			doc:  "multiple fields",
+			spec: "Value:desc,name",
+			rows: []*sortSubContext{
+				{name: "a", value: "1"},
+				{name: "c", value: "2"},
+				{name: "b", value: "2"},
+			},
+			expected: []string{"b", "c", "a"},
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			keys, err := parseSort(tc.spec, &sortSubContext{})
+			assert.NilError(t, err)
+			rows := make([]SubContext, 0, len(tc.rows))
+			for _, row := range tc.rows {
+				rows = append(rows, row)
+			}
+			assert.NilError(t, sortRows(rows, nil, keys))
+			names := make([]string, 0, len(rows))
+			for _, row := range rows {
+				names = append(names, row.(*sortSubContext).name)
+			}
+			assert.Check(t, is.DeepEqual(names, tc.expected))
+		})
+	}
+}
+
+func TestParseSortErrors(t *testing.T) {
+	_, err := parseSort("unknown", &sortSubContext{})
+	assert.Check(t, is.Error(err, `invalid sort field "unknown"`))
+
+	_, err = parseSort("name:up", &sortSubContext{})
+	assert.Check(t, is.Error(err, `invalid sort order "up" for field "name": must be asc or desc`))
+
+	_, err = parseSort("FullHeader", &sortSubContext{})
+	assert.Check(t, is.Error(err, `invalid sort field "FullHeader"`))
+}
+
+func TestContextSort(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Name: "b", Driver: "local"},
+		{Name: "a", Driver: "other"},
+		{Name: "c", Driver: "local"},
+	}
+	out := bytes.NewBufferString("")
+	err := VolumeWrite(Context{Format: "table {{.Driver}}\t{{.Name}}", Sort: "driver,name:desc", Output: out}, volumes)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(out.String(), `DRIVER    VOLUME NAME
+local     c
+local     b
+other     a
+`))
+
+	err = VolumeWrite(Context{Format: "{{.Name}}", Sort: "nope", Output: out}, volumes)
+	assert.Check(t, is.Error(err, `invalid sort field "nope"`))
+}
+
+func TestWriteMergedSort(t *testing.T) {
+	collect := func(volumes ...*volume.Volume) *Collector {
+		c := NewCollector()
+		assert.NilError(t, VolumeWrite(Context{Output: c, Format: "table {{.Name}}", Sort: "name"}, volumes))
+		return c
+	}
+	outputs := []CollectedOutput{
+		{Context: "one", Collector: collect(&volume.Volume{Name: "foo"}, &volume.Volume{Name: "bar"})},
+		{Context: "two", Collector: collect(&volume.Volume{Name: "baz"})},
+	}
+	var out bytes.Buffer
+	assert.NilError(t, WriteMerged(&out, outputs))
+	assert.Check(t, is.Equal(out.String(), `CONTEXT   VOLUME NAME
+one       bar
+two       baz
+one       foo
+`))
+}
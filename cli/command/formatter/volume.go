@@ -72,6 +72,11 @@ func newVolumeContext() *volumeContext {
 		"Size":         SizeHeader,
 		"Status":       statusHeader,
 	}
+	volumeCtx.Priorities = ColumnPriorities{
+		"Labels":     1,
+		"Mountpoint": 1,
+		"Name":       2,
+	}
 	return &volumeCtx
 }
 
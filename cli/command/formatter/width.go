@@ -0,0 +1,185 @@
+package formatter
+
+import (
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter/tabwriter"
+	"github.com/harness-community/docker-cli-v23/cli/streams"
+)
+
+const (
+	// tableMinWidth and tablePadding are the minimal width of the columns of
+	// table formats, and the padding added to their content.
+	tableMinWidth = 10
+	tablePadding  = 3
+)
+
+// columnFieldRe matches the first field referenced by the template of a
+// column, e.g. "Ports" in "{{.Ports}}", or in "{{json .Ports}}".
+var columnFieldRe = regexp.MustCompile(`{{[^}]*?\.([A-Za-z]\w*)`)
+
+// escapeCodeRe matches the escape codes rendered by the "color" template
+// function, which do not occupy any horizontal position.
+var escapeCodeRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// coloredCellRe splits a cell into the escape codes it starts and ends with,
+// and its content.
+var coloredCellRe = regexp.MustCompile(`(?s)^((?:\x1b\[[0-9;]*m)*)(.*?)((?:\x1b\[[0-9;]*m)*)$`)
+
+// prioritizedSubContext is implemented by SubContexts embedding a
+// HeaderContext.
+type prioritizedSubContext interface {
+	columnPriorities() ColumnPriorities
+}
+
+// terminalWidth returns the width of out if it is a terminal, and 0
+// otherwise.
+func terminalWidth(out io.Writer) int {
+	if !isTerminal(out) {
+		return 0
+	}
+	_, width := out.(*streams.Out).GetTtySize()
+	return int(width)
+}
+
+// isTerminal returns whether out is a terminal. Colors are only rendered to
+// terminals.
+func isTerminal(out io.Writer) bool {
+	o, ok := out.(*streams.Out)
+	return ok && o.IsTerminal()
+}
+
+// columnPriorities returns the priorities of the columns of the table format
+// tableFormat, by the first field each column references.
+func columnPriorities(tableFormat string, sub SubContext) []int {
+	p, ok := sub.(prioritizedSubContext)
+	if !ok || len(p.columnPriorities()) == 0 {
+		return nil
+	}
+	fields := p.columnPriorities()
+	columns := strings.Split(tableFormat, "\t")
+	priorities := make([]int, len(columns))
+	for i, column := range columns {
+		if m := columnFieldRe.FindStringSubmatch(column); m != nil {
+			priorities[i] = fields[m[1]]
+		}
+	}
+	return priorities
+}
+
+// writeTable aligns the tab-separated cells of table, and writes it to out.
+// If width is set, the columns which have a priority are truncated, lowest
+// priority first, until the table fits within width.
+func writeTable(out io.Writer, table []byte, width int, priorities []int) error {
+	if width > 0 && len(priorities) > 0 {
+		table = fitTable(table, width, priorities)
+	}
+	t := tabwriter.NewWriter(out, tableMinWidth, 1, tablePadding, ' ', 0)
+	if _, err := t.Write(table); err != nil {
+		return err
+	}
+	return t.Flush()
+}
+
+// fitTable truncates the cells of table so that it fits within width once
+// aligned. Columns are not truncated to less than the width of their header.
+func fitTable(table []byte, width int, priorities []int) []byte {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSuffix(string(table), "\n"), "\n") {
+		rows = append(rows, strings.Split(line, "\t"))
+	}
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+	// columnWidth returns the width of column i once aligned, if its content
+	// is w wide. The last column is not aligned.
+	columnWidth := func(i, w int) int {
+		switch {
+		case i == len(widths)-1:
+			return w
+		case w+tablePadding < tableMinWidth:
+			return tableMinWidth
+		default:
+			return w + tablePadding
+		}
+	}
+	excess := -width
+	for i, w := range widths {
+		excess += columnWidth(i, w)
+	}
+	if excess <= 0 {
+		return table
+	}
+
+	var order []int
+	for i := range widths {
+		if i < len(priorities) && priorities[i] > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return priorities[order[a]] < priorities[order[b]]
+	})
+	limits := append([]int{}, widths...)
+	for _, i := range order {
+		if excess <= 0 {
+			break
+		}
+		minWidth := 0
+		if i < len(rows[0]) {
+			minWidth = displayWidth(rows[0][i])
+		}
+		if limits[i] <= minWidth {
+			continue
+		}
+		before := columnWidth(i, limits[i])
+		limits[i] -= excess
+		if limits[i] < minWidth {
+			limits[i] = minWidth
+		}
+		excess -= before - columnWidth(i, limits[i])
+	}
+
+	var buf strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			if i > 0 {
+				buf.WriteString("\t")
+			}
+			if limits[i] < widths[i] {
+				cell = truncateCell(cell, limits[i])
+			}
+			buf.WriteString(cell)
+		}
+		buf.WriteString("\n")
+	}
+	return []byte(buf.String())
+}
+
+// truncateCell truncates the content of cell to limit, keeping the escape
+// codes it is wrapped in so that colors are reset.
+func truncateCell(cell string, limit int) string {
+	m := coloredCellRe.FindStringSubmatch(cell)
+	return m[1] + Ellipsis(m[2], limit) + m[3]
+}
+
+// displayWidth returns the number of horizontal positions s occupies,
+// ignoring escape codes.
+func displayWidth(s string) int {
+	var w int
+	for _, r := range escapeCodeRe.ReplaceAllString(s, "") {
+		w += charWidth(r)
+	}
+	return w
+}
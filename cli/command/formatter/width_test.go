@@ -0,0 +1,132 @@
+package formatter
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/harness-community/docker-v23/api/types/volume"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFitTable(t *testing.T) {
+	const table = "NAME\tDESCRIPTION\tPATH\n" +
+		"foo\tthe foo plugin, which does foo things\t/usr/local/lib/docker/cli-plugins/docker-foo\n" +
+		"bar\tbar\t/usr/lib/docker/cli-plugins/docker-bar\n"
+	cases := []struct {
+		doc        string
+		width      int
+		priorities []int
+		expected   string
+	}{
+		{
+			doc:        "fits",
+			width:      200,
+			priorities: []int{0, 1, 2},
+			expected:   table,
+		},
+		{
+			doc:        "lowest priority first",
+			width:      80,
+			priorities: []int{0, 1, 2},
+			expected: "NAME\tDESCRIPTION\tPATH\n" +
+				"foo\tthe foo plugin, which …\t/usr/local/lib/docker/cli-plugins/docker-foo\n" +
+				"bar\tbar\t/usr/lib/docker/cli-plugins/docker-bar\n",
+		},
+		{
+			doc:        "down to the width of the header",
+			width:      50,
+			priorities: []int{0, 1, 2},
+			expected: "NAME\tDESCRIPTION\tPATH\n" +
+				"foo\tthe foo pl…\t/usr/local/lib/docker/cli…\n" +
+				"bar\tbar\t/usr/lib/docker/cli-plugi…\n",
+		},
+		{
+			doc:        "columns without priority",
+			width:      50,
+			priorities: []int{0, 1},
+			expected: "NAME\tDESCRIPTION\tPATH\n" +
+				"foo\tthe foo pl…\t/usr/local/lib/docker/cli-plugins/docker-foo\n" +
+				"bar\tbar\t/usr/lib/docker/cli-plugins/docker-bar\n",
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			assert.Check(t, is.Equal(string(fitTable([]byte(table), tc.width, tc.priorities)), tc.expected))
+		})
+	}
+}
+
+func TestFitTableColors(t *testing.T) {
+	const table = "\x1b[39mNAME\x1b[0m\tPATH\n" +
+		"\x1b[31mfoo\x1b[0m\t\x1b[32m/usr/local/lib/docker/cli-plugins/docker-foo\x1b[0m\n"
+	// escape codes do not count towards the width of cells, and are kept when
+	// cells are truncated
+	assert.Check(t, is.Equal(string(fitTable([]byte(table), 54, []int{0, 1})), table))
+	assert.Check(t, is.Equal(string(fitTable([]byte(table), 30, []int{0, 1})),
+		"\x1b[39mNAME\x1b[0m\tPATH\n"+
+			"\x1b[31mfoo\x1b[0m\t\x1b[32m/usr/local/lib/dock…\x1b[0m\n"))
+}
+
+func TestContextWidth(t *testing.T) {
+	volumes := []*volume.Volume{
+		{Driver: "local", Name: "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"},
+		{Driver: "local", Name: "data"},
+	}
+	cases := []struct {
+		doc      string
+		context  Context
+		expected string
+	}{
+		{
+			doc:     "fitted",
+			context: Context{Format: NewVolumeFormat("table", false), Width: 40},
+			expected: `DRIVER    VOLUME NAME
+local     0123456789abcdef0123456789abc…
+local     data
+`,
+		},
+		{
+			doc:     "custom format",
+			context: Context{Format: "table {{.Name}}\t{{.Driver}}", Width: 40},
+			expected: `VOLUME NAME                       DRIVER
+0123456789abcdef0123456789abcd…   local
+data                              local
+`,
+		},
+		{
+			doc:     "wide",
+			context: Context{Format: NewVolumeFormat("table", false), Width: 40, Wide: true},
+			expected: `DRIVER    VOLUME NAME
+local     0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+local     data
+`,
+		},
+		{
+			doc:     "not a terminal",
+			context: Context{Format: NewVolumeFormat("table", false)},
+			expected: `DRIVER    VOLUME NAME
+local     0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef
+local     data
+`,
+		},
+		{
+			doc:     "no colors if not a terminal",
+			context: Context{Format: `table {{.Name | color "red"}}\t{{.Driver}}`, Width: 40},
+			expected: `VOLUME NAME                       DRIVER
+0123456789abcdef0123456789abcd…   local
+data                              local
+`,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			out := bytes.NewBufferString("")
+			tc.context.Output = out
+			assert.NilError(t, VolumeWrite(tc.context, volumes))
+			assert.Check(t, is.Equal(out.String(), tc.expected))
+		})
+	}
+}
@@ -57,6 +57,10 @@ func HistoryWrite(ctx formatter.Context, human bool, histories []image.HistoryRe
 		"Size":         formatter.SizeHeader,
 		"Comment":      commentHeader,
 	}
+	historyCtx.Priorities = formatter.ColumnPriorities{
+		"Comment":   1,
+		"CreatedBy": 2,
+	}
 	return ctx.Write(historyCtx, render)
 }
 
@@ -17,6 +17,8 @@ type historyOptions struct {
 	quiet   bool
 	noTrunc bool
 	format  string
+	sort    string
+	wide    bool
 }
 
 // NewHistoryCommand creates a new `docker history` command
@@ -42,6 +44,8 @@ func NewHistoryCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&opts.quiet, "quiet", "q", false, "Only show image IDs")
 	flags.BoolVar(&opts.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.StringVar(&opts.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.wide, "wide", false, flagsHelper.WideHelp)
 
 	return cmd
 }
@@ -62,6 +66,8 @@ func runHistory(dockerCli command.Cli, opts historyOptions) error {
 	historyCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewHistoryFormat(format, opts.quiet, opts.human),
+		Sort:   opts.sort,
+		Wide:   opts.wide || opts.noTrunc,
 		Trunc:  !opts.noTrunc,
 	}
 	return HistoryWrite(historyCtx, opts.human, history)
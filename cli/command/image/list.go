@@ -20,6 +20,8 @@ type imagesOptions struct {
 	noTrunc     bool
 	showDigests bool
 	format      string
+	sort        string
+	wide        bool
 	filter      opts.FilterOpt
 }
 
@@ -38,8 +40,9 @@ func NewImagesCommand(dockerCli command.Cli) *cobra.Command {
 			return runImages(dockerCli, options)
 		},
 		Annotations: map[string]string{
-			"category-top": "7",
-			"aliases":      "docker image ls, docker image list, docker images",
+			"category-top":                 "7",
+			"aliases":                      "docker image ls, docker image list, docker images",
+			command.MultiContextAnnotation: "",
 		},
 	}
 
@@ -49,7 +52,9 @@ func NewImagesCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVarP(&options.all, "all", "a", false, "Show all images (default hides intermediate images)")
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Don't truncate output")
 	flags.BoolVar(&options.showDigests, "digests", false, "Show digests")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
@@ -63,6 +68,15 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runImages(dockerCli command.Cli, options imagesOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().ImagesFormat) > 0 && !options.quiet {
+		defaultFormat = dockerCli.ConfigFile().ImagesFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "images", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	ctx := context.Background()
 
 	filters := options.filter.Value()
@@ -80,19 +94,12 @@ func runImages(dockerCli command.Cli, options imagesOptions) error {
 		return err
 	}
 
-	format := options.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().ImagesFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().ImagesFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	imageCtx := formatter.ImageContext{
 		Context: formatter.Context{
 			Output: dockerCli.Out(),
 			Format: formatter.NewImageFormat(format, options.quiet, options.showDigests),
+			Sort:   options.sort,
+			Wide:   options.wide || options.noTrunc,
 			Trunc:  !options.noTrunc,
 		},
 		Digest: options.showDigests,
@@ -186,7 +186,7 @@ func trustedPull(ctx context.Context, cli command.Cli, imgRefAndAuth trust.Image
 		if err != nil {
 			return err
 		}
-		updatedImgRefAndAuth, err := trust.GetImageReferencesAndAuth(ctx, nil, AuthResolver(cli), trustedRef.String())
+		updatedImgRefAndAuth, err := trust.GetImageReferencesAndRepositoryAuth(ctx, nil, RepositoryAuthResolver(cli), trustedRef.String())
 		if err != nil {
 			return err
 		}
@@ -290,7 +290,7 @@ func imagePullPrivileged(ctx context.Context, cli command.Cli, imgRefAndAuth tru
 
 // TrustedReference returns the canonical trusted reference for an image reference
 func TrustedReference(ctx context.Context, cli command.Cli, ref reference.NamedTagged, rs registry.Service) (reference.Canonical, error) {
-	imgRefAndAuth, err := trust.GetImageReferencesAndAuth(ctx, rs, AuthResolver(cli), ref.String())
+	imgRefAndAuth, err := trust.GetImageReferencesAndRepositoryAuth(ctx, rs, RepositoryAuthResolver(cli), ref.String())
 	if err != nil {
 		return nil, err
 	}
@@ -345,3 +345,11 @@ func AuthResolver(cli command.Cli) func(ctx context.Context, index *registrytype
 		return command.ResolveAuthConfig(ctx, cli, index)
 	}
 }
+
+// RepositoryAuthResolver returns an auth resolver function from a
+// command.Cli, which uses credentials scoped to the repository, if any.
+func RepositoryAuthResolver(cli command.Cli) func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+	return func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+		return command.ResolveRepositoryAuthConfig(ctx, cli, repoInfo)
+	}
+}
@@ -0,0 +1,97 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	"github.com/harness-community/docker-v23/errdefs"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// MultiContextAnnotation is the annotation of read-only commands that can be
+// run against multiple contexts at once.
+const MultiContextAnnotation = "multi-context"
+
+// IsContextSelector returns true if contextName selects multiple contexts,
+// either as a comma-separated list of context names, or using a label
+// selector (see ResolveContextSelector).
+func IsContextSelector(contextName string) bool {
+	return strings.ContainsAny(contextName, ",=")
+}
+
+// SupportsMultiContext returns true if cmd, or one of its parents, can be run
+// against multiple contexts at once.
+func SupportsMultiContext(cmd *cobra.Command) bool {
+	for curr := cmd; curr != nil; curr = curr.Parent() {
+		if _, ok := curr.Annotations[MultiContextAnnotation]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveContextSelector returns the names of the contexts selected by a
+// comma-separated list of context names and label selectors. A label
+// selector has the form "label=<key>" or "label=<key>=<value>", and selects
+// all contexts having that label (with that value). Names are returned in
+// the order they were selected, without duplicates.
+func ResolveContextSelector(s store.Lister, selector string) ([]string, error) {
+	contexts, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		names []string
+		seen  = map[string]struct{}{}
+	)
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+	for _, sel := range strings.Split(selector, ",") {
+		sel = strings.TrimSpace(sel)
+		switch {
+		case sel == "":
+			return nil, errors.Errorf("invalid context selector %q: empty context name", selector)
+		case strings.HasPrefix(sel, "label="):
+			key, value, hasValue := strings.Cut(strings.TrimPrefix(sel, "label="), "=")
+			if key == "" {
+				return nil, errors.Errorf("invalid context selector %q: empty label", sel)
+			}
+			var matched bool
+			for _, c := range contexts {
+				dc, err := GetDockerContext(c)
+				if err != nil {
+					return nil, err
+				}
+				if v, ok := dc.Labels[key]; ok && (!hasValue || v == value) {
+					add(c.Name)
+					matched = true
+				}
+			}
+			if !matched {
+				return nil, errdefs.NotFound(errors.Errorf("no context matches %s", sel))
+			}
+		case strings.Contains(sel, "="):
+			return nil, errors.Errorf("invalid context selector %q: expected a context name, or label=<key>[=<value>]", sel)
+		default:
+			if !containsContext(contexts, sel) {
+				return nil, errdefs.NotFound(errors.Errorf("context %q does not exist", sel))
+			}
+			add(sel)
+		}
+	}
+	return names, nil
+}
+
+func containsContext(contexts []store.Metadata, name string) bool {
+	for _, c := range contexts {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
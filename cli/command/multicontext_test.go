@@ -0,0 +1,64 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	"github.com/harness-community/docker-v23/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestIsContextSelector(t *testing.T) {
+	assert.Check(t, !IsContextSelector(""))
+	assert.Check(t, !IsContextSelector("my-context"))
+	assert.Check(t, IsContextSelector("a,b"))
+	assert.Check(t, IsContextSelector("label=env"))
+}
+
+func TestResolveContextSelector(t *testing.T) {
+	s := store.New(t.TempDir(), DefaultContextStoreConfig())
+	for name, labels := range map[string]map[string]string{
+		"prod-1":  {"env": "prod"},
+		"prod-2":  {"env": "prod", "region": "eu"},
+		"staging": {"env": "staging", "region": "eu"},
+		"plain":   nil,
+	} {
+		assert.NilError(t, s.CreateOrUpdate(store.Metadata{
+			Name:     name,
+			Metadata: DockerContext{Labels: labels},
+		}))
+	}
+
+	tests := []struct {
+		selector string
+		expected []string
+		err      string
+	}{
+		{selector: "plain,staging", expected: []string{"plain", "staging"}},
+		{selector: "staging, plain ,staging", expected: []string{"staging", "plain"}},
+		{selector: "label=env=prod", expected: []string{"prod-1", "prod-2"}},
+		{selector: "label=region", expected: []string{"prod-2", "staging"}},
+		{selector: "plain,label=region=eu", expected: []string{"plain", "prod-2", "staging"}},
+		{selector: "plain,unknown", err: `context "unknown" does not exist`},
+		{selector: "label=env=dev", err: "no context matches label=env=dev"},
+		{selector: "plain,", err: "empty context name"},
+		{selector: "label=", err: "empty label"},
+		{selector: "env=prod", err: "expected a context name, or label=<key>[=<value>]"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.selector, func(t *testing.T) {
+			names, err := ResolveContextSelector(s, tc.selector)
+			if tc.err != "" {
+				assert.Check(t, is.ErrorContains(err, tc.err))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(names, tc.expected))
+		})
+	}
+
+	_, err := ResolveContextSelector(s, "plain,unknown")
+	assert.Check(t, is.ErrorType(err, errdefs.IsNotFound))
+}
@@ -56,6 +56,10 @@ func FormatWrite(ctx formatter.Context, networks []types.NetworkResource) error
 		"Labels":    formatter.LabelsHeader,
 		"CreatedAt": formatter.CreatedAtHeader,
 	}
+	networkCtx.Priorities = formatter.ColumnPriorities{
+		"Labels": 1,
+		"Name":   2,
+	}
 	return ctx.Write(&networkCtx, render)
 }
 
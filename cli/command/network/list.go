@@ -19,6 +19,8 @@ type listOptions struct {
 	quiet   bool
 	noTrunc bool
 	format  string
+	sort    string
+	wide    bool
 	filter  opts.FilterOpt
 }
 
@@ -33,19 +35,31 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(dockerCli, options)
 		},
+		Annotations:       map[string]string{command.MultiContextAnnotation: ""},
 		ValidArgsFunction: completion.NoComplete,
 	}
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display network IDs")
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Do not truncate the output")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", `Provide filter values (e.g. "driver=bridge")`)
 
 	return cmd
 }
 
 func runList(dockerCli command.Cli, options listOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().NetworksFormat) > 0 && !options.quiet {
+		defaultFormat = dockerCli.ConfigFile().NetworksFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "networks", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	listOptions := types.NetworkListOptions{Filters: options.filter.Value()}
 	networkResources, err := client.NetworkList(context.Background(), listOptions)
@@ -53,15 +67,6 @@ func runList(dockerCli command.Cli, options listOptions) error {
 		return err
 	}
 
-	format := options.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().NetworksFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().NetworksFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	sort.Slice(networkResources, func(i, j int) bool {
 		return sortorder.NaturalLess(networkResources[i].Name, networkResources[j].Name)
 	})
@@ -69,6 +74,8 @@ func runList(dockerCli command.Cli, options listOptions) error {
 	networksCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(format, options.quiet),
+		Sort:   options.sort,
+		Wide:   options.wide || options.noTrunc,
 		Trunc:  !options.noTrunc,
 	}
 	return FormatWrite(networksCtx, networkResources)
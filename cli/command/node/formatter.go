@@ -121,6 +121,9 @@ func FormatWrite(ctx formatter.Context, nodes []swarm.Node, info types.Info) err
 		"EngineVersion": engineVersionHeader,
 		"TLSStatus":     tlsStatusHeader,
 	}
+	nodeCtx.Priorities = formatter.ColumnPriorities{
+		"Hostname": 1,
+	}
 	return ctx.Write(&nodeCtx, render)
 }
 
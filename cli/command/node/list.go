@@ -18,6 +18,8 @@ import (
 type listOptions struct {
 	quiet  bool
 	format string
+	sort   string
+	wide   bool
 	filter opts.FilterOpt
 }
 
@@ -36,13 +38,24 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display IDs")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
 }
 
 func runList(dockerCli command.Cli, options listOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().NodesFormat) > 0 && !options.quiet {
+		defaultFormat = dockerCli.ConfigFile().NodesFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "nodes", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -62,17 +75,11 @@ func runList(dockerCli command.Cli, options listOptions) error {
 		}
 	}
 
-	format := options.format
-	if len(format) == 0 {
-		format = formatter.TableFormatKey
-		if len(dockerCli.ConfigFile().NodesFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().NodesFormat
-		}
-	}
-
 	nodesCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(format, options.quiet),
+		Sort:   options.sort,
+		Wide:   options.wide,
 	}
 	sort.Slice(nodes, func(i, j int) bool {
 		return sortorder.NaturalLess(nodes[i].Description.Hostname, nodes[j].Description.Hostname)
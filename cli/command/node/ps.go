@@ -9,6 +9,7 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command/completion"
 	"github.com/harness-community/docker-cli-v23/cli/command/idresolver"
 	"github.com/harness-community/docker-cli-v23/cli/command/task"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
 	"github.com/harness-community/docker-cli-v23/opts"
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/harness-community/docker-v23/api/types/swarm"
@@ -22,6 +23,8 @@ type psOptions struct {
 	noTrunc   bool
 	quiet     bool
 	format    string
+	sort      string
+	wide      bool
 	filter    opts.FilterOpt
 }
 
@@ -48,12 +51,19 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&options.noResolve, "no-resolve", false, "Do not map IDs to Names")
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 	flags.StringVar(&options.format, "format", "", "Pretty-print tasks using a Go template")
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display task IDs")
 
 	return cmd
 }
 
 func runPs(dockerCli command.Cli, options psOptions) error {
+	format, ok, err := command.ResolveFormat(dockerCli, "tasks", options.format, task.DefaultFormat(dockerCli.ConfigFile(), options.quiet))
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -87,13 +97,8 @@ func runPs(dockerCli command.Cli, options psOptions) error {
 		tasks = append(tasks, nodeTasks...)
 	}
 
-	format := options.format
-	if len(format) == 0 {
-		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
-	}
-
 	if len(errs) == 0 || len(tasks) != 0 {
-		if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format); err != nil {
+		if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.wide, options.quiet, format, options.sort); err != nil {
 			errs = append(errs, err.Error())
 		}
 	}
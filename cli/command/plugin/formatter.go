@@ -51,6 +51,11 @@ func FormatWrite(ctx formatter.Context, plugins []*types.Plugin) error {
 		"Enabled":         enabledHeader,
 		"PluginReference": formatter.ImageHeader,
 	}
+	pluginCtx.Priorities = formatter.ColumnPriorities{
+		"Description":     1,
+		"PluginReference": 2,
+		"Name":            3,
+	}
 	return ctx.Write(&pluginCtx, render)
 }
 
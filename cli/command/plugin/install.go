@@ -110,7 +110,7 @@ func buildPullConfig(ctx context.Context, dockerCli command.Cli, opts pluginOpti
 		remote = reference.FamiliarString(trusted)
 	}
 
-	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
+	authConfig := command.ResolveRepositoryAuthConfig(ctx, dockerCli, repoInfo)
 
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
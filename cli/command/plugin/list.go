@@ -18,6 +18,8 @@ type listOptions struct {
 	quiet   bool
 	noTrunc bool
 	format  string
+	sort    string
+	wide    bool
 	filter  opts.FilterOpt
 }
 
@@ -39,13 +41,24 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display plugin IDs")
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Don't truncate output")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", `Provide filter values (e.g. "enabled=true")`)
 
 	return cmd
 }
 
 func runList(dockerCli command.Cli, options listOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().PluginsFormat) > 0 && !options.quiet {
+		defaultFormat = dockerCli.ConfigFile().PluginsFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "plugins", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	plugins, err := dockerCli.Client().PluginList(context.Background(), options.filter.Value())
 	if err != nil {
 		return err
@@ -55,18 +68,11 @@ func runList(dockerCli command.Cli, options listOptions) error {
 		return sortorder.NaturalLess(plugins[i].Name, plugins[j].Name)
 	})
 
-	format := options.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().PluginsFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().PluginsFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	pluginsCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(format, options.quiet),
+		Sort:   options.sort,
+		Wide:   options.wide || options.noTrunc,
 		Trunc:  !options.noTrunc,
 	}
 	return FormatWrite(pluginsCtx, plugins)
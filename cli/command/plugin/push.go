@@ -54,7 +54,7 @@ func runPush(dockerCli command.Cli, opts pushOptions) error {
 	if err != nil {
 		return err
 	}
-	authConfig := command.ResolveAuthConfig(ctx, dockerCli, repoInfo.Index)
+	authConfig := command.ResolveRepositoryAuthConfig(ctx, dockerCli, repoInfo)
 
 	encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 	if err != nil {
@@ -72,6 +72,22 @@ func ResolveAuthConfig(_ context.Context, cli Cli, index *registrytypes.IndexInf
 	return types.AuthConfig(a)
 }
 
+// ResolveRepositoryAuthConfig returns auth-config for the given repository
+// from the credential-store. Unlike ResolveAuthConfig, it uses credentials
+// scoped to a path the repository is under (such as
+// "registry.example.com/team-a"), if any, and falls back to the credentials
+// for the registry otherwise. It returns an empty AuthConfig if no
+// credentials were found.
+func ResolveRepositoryAuthConfig(_ context.Context, cli Cli, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+	configKey := repoInfo.Index.Name
+	if repoInfo.Index.Official {
+		configKey = registry.IndexServer
+	}
+
+	a, _ := cli.ConfigFile().GetRepositoryAuthConfig(repoInfo.Name.Name(), configKey)
+	return types.AuthConfig(a)
+}
+
 // GetDefaultAuthConfig gets the default auth config given a serverAddress
 // If credentials for given serverAddress exists in the credential store, the configuration will be populated with values in it
 func GetDefaultAuthConfig(cli Cli, checkCredStore bool, serverAddress string, isDefaultRegistry bool) (types.AuthConfig, error) {
@@ -194,5 +210,5 @@ func resolveAuthConfigFromImage(ctx context.Context, cli Cli, image string) (typ
 	if err != nil {
 		return types.AuthConfig{}, err
 	}
-	return ResolveAuthConfig(ctx, cli, repoInfo.Index), nil
+	return ResolveRepositoryAuthConfig(ctx, cli, repoInfo), nil
 }
@@ -46,6 +46,9 @@ func SearchWrite(ctx formatter.Context, results []registrytypes.SearchResult) er
 		"IsOfficial":  officialHeader,
 		"IsAutomated": automatedHeader,
 	}
+	searchCtx.Priorities = formatter.ColumnPriorities{
+		"Description": 1,
+	}
 	return ctx.Write(&searchCtx, render)
 }
 
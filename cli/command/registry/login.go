@@ -9,6 +9,7 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli"
 	"github.com/harness-community/docker-cli-v23/cli/command"
 	"github.com/harness-community/docker-cli-v23/cli/command/completion"
+	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
 	configtypes "github.com/harness-community/docker-cli-v23/cli/config/types"
 	"github.com/harness-community/docker-v23/api/types"
 	registrytypes "github.com/harness-community/docker-v23/api/types/registry"
@@ -29,6 +30,7 @@ type loginOptions struct {
 	user          string
 	password      string
 	passwordStdin bool
+	device        bool
 }
 
 // NewLoginCommand creates a new `docker login` command
@@ -57,6 +59,7 @@ func NewLoginCommand(dockerCli command.Cli) *cobra.Command {
 	flags.StringVarP(&opts.user, "username", "u", "", "Username")
 	flags.StringVarP(&opts.password, "password", "p", "", "Password")
 	flags.BoolVarP(&opts.passwordStdin, "password-stdin", "", false, "Take the password from stdin")
+	flags.BoolVar(&opts.device, "device", false, "Log in by authorizing this device in a browser (OAuth2 device authorization grant)")
 
 	return cmd
 }
@@ -85,6 +88,10 @@ func verifyloginOptions(dockerCli command.Cli, opts *loginOptions) error {
 		}
 	}
 
+	if opts.device && (opts.user != "" || opts.password != "" || opts.passwordStdin) {
+		return errors.New("--device cannot be combined with --username, --password, or --password-stdin")
+	}
+
 	if opts.passwordStdin {
 		if opts.user == "" {
 			return errors.New("Must provide --username with --password-stdin")
@@ -103,7 +110,6 @@ func verifyloginOptions(dockerCli command.Cli, opts *loginOptions) error {
 
 func runLogin(dockerCli command.Cli, opts loginOptions) error { //nolint:gocyclo
 	ctx := context.Background()
-	clnt := dockerCli.Client()
 	if err := verifyloginOptions(dockerCli, &opts); err != nil {
 		return err
 	}
@@ -117,23 +123,23 @@ func runLogin(dockerCli command.Cli, opts loginOptions) error { //nolint:gocyclo
 		serverAddress = registry.IndexServer
 	}
 
-	isDefaultRegistry := serverAddress == registry.IndexServer
-	authConfig, err := command.GetDefaultAuthConfig(dockerCli, opts.user == "" && opts.password == "", serverAddress, isDefaultRegistry)
-	if err == nil && authConfig.Username != "" && authConfig.Password != "" {
-		response, err = loginWithCredStoreCreds(ctx, dockerCli, &authConfig)
-	}
-	if err != nil || authConfig.Username == "" || authConfig.Password == "" {
-		err = command.ConfigureAuth(dockerCli, opts.user, opts.password, &authConfig, isDefaultRegistry)
+	// Credentials can be scoped to the repositories under a path of a
+	// registry, in which case we log in to the registry itself.
+	scope, serverAddress := splitLoginScope(serverAddress)
+
+	var (
+		authConfig        types.AuthConfig
+		err               error
+		isDefaultRegistry = serverAddress == registry.IndexServer
+	)
+	if opts.device {
+		authConfig, err = loginWithDeviceCode(ctx, dockerCli, serverAddress)
 		if err != nil {
 			return err
 		}
-
-		response, err = clnt.RegistryLogin(ctx, authConfig)
-		if err != nil && client.IsErrConnectionFailed(err) {
-			// If the server isn't responding (yet) attempt to login purely client side
-			response, err = loginClientSide(ctx, authConfig)
-		}
-		// If we (still) have an error, give up
+		response.Status = "Login Succeeded"
+	} else {
+		authConfig, response, err = loginWithPassword(ctx, dockerCli, opts, scope, serverAddress, isDefaultRegistry)
 		if err != nil {
 			return err
 		}
@@ -143,7 +149,10 @@ func runLogin(dockerCli command.Cli, opts loginOptions) error { //nolint:gocyclo
 		authConfig.IdentityToken = response.IdentityToken
 	}
 
-	creds := dockerCli.ConfigFile().GetCredentialsStore(serverAddress)
+	if scope != "" {
+		authConfig.ServerAddress = scope
+	}
+	creds := dockerCli.ConfigFile().GetCredentialsStore(authConfig.ServerAddress)
 
 	store, isDefault := creds.(isFileStore)
 	// Display a warning if we're storing the users password (not a token)
@@ -164,6 +173,68 @@ func runLogin(dockerCli command.Cli, opts loginOptions) error { //nolint:gocyclo
 	return nil
 }
 
+// splitLoginScope splits a server address that scopes credentials to the
+// repositories under a path of a registry, such as "registry.example.com/team-a",
+// into the scope and the address of the registry to log in to. The scope is
+// empty for addresses that are not scoped.
+func splitLoginScope(serverAddress string) (scope, registryAddress string) {
+	if !credentials.IsRepositoryScope(serverAddress) {
+		return "", serverAddress
+	}
+	scope = strings.TrimSuffix(serverAddress, "/")
+	hostname, path, _ := strings.Cut(scope, "/")
+	if hostname == registry.IndexHostname || hostname == registry.IndexName {
+		// Repositories on Docker Hub are named "docker.io/<namespace>/<name>".
+		return registry.IndexName + "/" + path, registry.IndexServer
+	}
+	return scope, hostname
+}
+
+// getScopedAuthConfig returns the credentials stored for the given scope, if
+// any and checkCredStore is true, with the ServerAddress set to the registry
+// to log in to.
+func getScopedAuthConfig(dockerCli command.Cli, checkCredStore bool, scope, serverAddress string) (types.AuthConfig, error) {
+	if !checkCredStore {
+		return types.AuthConfig{ServerAddress: serverAddress}, nil
+	}
+	authconfig, err := dockerCli.ConfigFile().GetAuthConfig(scope)
+	authconfig.ServerAddress = serverAddress
+	authconfig.IdentityToken = ""
+	return types.AuthConfig(authconfig), err
+}
+
+// loginWithPassword logs in to the registry at serverAddress with the stored
+// credentials, if any, or with the username and password given in opts, or
+// prompted for.
+func loginWithPassword(ctx context.Context, dockerCli command.Cli, opts loginOptions, scope, serverAddress string, isDefaultRegistry bool) (types.AuthConfig, registrytypes.AuthenticateOKBody, error) {
+	var (
+		authConfig types.AuthConfig
+		response   registrytypes.AuthenticateOKBody
+		err        error
+	)
+	if scope != "" {
+		authConfig, err = getScopedAuthConfig(dockerCli, opts.user == "" && opts.password == "", scope, serverAddress)
+	} else {
+		authConfig, err = command.GetDefaultAuthConfig(dockerCli, opts.user == "" && opts.password == "", serverAddress, isDefaultRegistry)
+	}
+	if err == nil && authConfig.Username != "" && authConfig.Password != "" {
+		response, err = loginWithCredStoreCreds(ctx, dockerCli, &authConfig)
+	}
+	if err != nil || authConfig.Username == "" || authConfig.Password == "" {
+		err = command.ConfigureAuth(dockerCli, opts.user, opts.password, &authConfig, isDefaultRegistry)
+		if err != nil {
+			return authConfig, response, err
+		}
+
+		response, err = dockerCli.Client().RegistryLogin(ctx, authConfig)
+		if err != nil && client.IsErrConnectionFailed(err) {
+			// If the server isn't responding (yet) attempt to login purely client side
+			response, err = loginClientSide(ctx, authConfig)
+		}
+	}
+	return authConfig, response, err
+}
+
 func loginWithCredStoreCreds(ctx context.Context, dockerCli command.Cli, authConfig *types.AuthConfig) (registrytypes.AuthenticateOKBody, error) {
 	fmt.Fprintf(dockerCli.Out(), "Authenticating with existing credentials...\n")
 	cliClient := dockerCli.Client()
@@ -0,0 +1,315 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-v23/api/types"
+	"github.com/harness-community/docker-v23/registry"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// deviceCodeGrantType is the grant type of the OAuth2 device authorization
+	// grant (RFC 8628).
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval is the interval, in seconds, at which the
+	// token endpoint is polled if the authorization server does not specify
+	// one.
+	defaultDevicePollInterval = 5
+)
+
+// devicePollIntervalUnit is the unit of the polling intervals sent by the
+// authorization server. It is a var for unit testing.
+var devicePollIntervalUnit = time.Second
+
+// authServerMetadataPaths are the well-known paths, relative to the issuer of
+// the auth realm, at which the metadata of the authorization server is looked
+// up (RFC 8414, and OpenID Connect Discovery).
+var authServerMetadataPaths = []string{
+	"/.well-known/oauth-authorization-server",
+	"/.well-known/openid-configuration",
+}
+
+// authServer describes the endpoints of the authorization server of a
+// registry that are used for the device authorization grant.
+type authServer struct {
+	Service                     string `json:"-"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+	TokenEndpoint               string `json:"token_endpoint"`
+}
+
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// loginWithDeviceCode logs in to the registry at serverAddress using the
+// OAuth2 device authorization grant against the auth realm of the registry.
+// It prints the URL to open and the code to enter to authorize the login, and
+// waits for the login to be authorized. The refresh token issued by the
+// authorization server is returned as the IdentityToken of the AuthConfig.
+func loginWithDeviceCode(ctx context.Context, dockerCli command.Cli, serverAddress string) (types.AuthConfig, error) {
+	httpClient := &http.Client{
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+		Timeout:   30 * time.Second,
+	}
+	server, err := discoverAuthServer(ctx, httpClient, serverAddress)
+	if err != nil {
+		return types.AuthConfig{}, err
+	}
+
+	auth, err := requestDeviceCode(ctx, httpClient, server)
+	if err != nil {
+		return types.AuthConfig{}, err
+	}
+	if auth.VerificationURIComplete != "" {
+		fmt.Fprintf(dockerCli.Out(), "To log in, open the following URL in a browser:\n\n    %s\n\nand confirm the code: %s\n\n", auth.VerificationURIComplete, auth.UserCode)
+	} else {
+		fmt.Fprintf(dockerCli.Out(), "To log in, open the following URL in a browser:\n\n    %s\n\nand enter the code: %s\n\n", auth.VerificationURI, auth.UserCode)
+	}
+	fmt.Fprintln(dockerCli.Out(), "Waiting for authorization...")
+
+	refreshToken, err := pollDeviceToken(ctx, httpClient, server, auth)
+	if err != nil {
+		return types.AuthConfig{}, err
+	}
+	return types.AuthConfig{
+		ServerAddress: serverAddress,
+		IdentityToken: refreshToken,
+	}, nil
+}
+
+// discoverAuthServer looks up the auth realm of the registry at serverAddress,
+// and the endpoints of its authorization server.
+func discoverAuthServer(ctx context.Context, httpClient *http.Client, serverAddress string) (authServer, error) {
+	realm, service, err := lookupAuthRealm(ctx, serverAddress)
+	if err != nil {
+		return authServer{}, err
+	}
+	realmURL, err := url.Parse(realm)
+	if err != nil {
+		return authServer{}, errors.Wrapf(err, "invalid auth realm %q", realm)
+	}
+
+	var server authServer
+	for _, p := range authServerMetadataPaths {
+		metadataURL := url.URL{Scheme: realmURL.Scheme, Host: realmURL.Host, Path: p}
+		var metadata authServer
+		if err := getJSON(ctx, httpClient, metadataURL.String(), &metadata); err != nil {
+			logrus.Debugf("failed to get authorization server metadata from %s: %v", metadataURL.String(), err)
+			continue
+		}
+		if metadata.DeviceAuthorizationEndpoint != "" {
+			server = metadata
+			break
+		}
+	}
+	if server.DeviceAuthorizationEndpoint == "" {
+		return authServer{}, errors.Errorf("the authorization server of %s does not support device login", serverAddress)
+	}
+	if server.TokenEndpoint == "" {
+		server.TokenEndpoint = realm
+	}
+	server.Service = service
+	return server, nil
+}
+
+// lookupAuthRealm pings the registry at serverAddress, and returns the realm
+// and service of its bearer token authentication challenge.
+func lookupAuthRealm(ctx context.Context, serverAddress string) (realm, service string, err error) {
+	registryService, err := registry.NewService(registry.ServiceOptions{})
+	if err != nil {
+		return "", "", err
+	}
+	endpoints, err := registryService.LookupPushEndpoints(registry.ConvertToHostname(serverAddress))
+	if err != nil {
+		return "", "", err
+	}
+	for _, endpoint := range endpoints {
+		var challenges []challenge.Challenge
+		challenges, err = pingRegistry(ctx, endpoint)
+		if err != nil {
+			logrus.Debugf("failed to ping registry endpoint %s: %v", endpoint.URL, err)
+			continue
+		}
+		for _, c := range challenges {
+			if strings.EqualFold(c.Scheme, "bearer") && c.Parameters["realm"] != "" {
+				return c.Parameters["realm"], c.Parameters["service"], nil
+			}
+		}
+		return "", "", errors.Errorf("registry %s does not support token authentication", serverAddress)
+	}
+	return "", "", errors.Wrapf(err, "failed to connect to registry %s", serverAddress)
+}
+
+func pingRegistry(ctx context.Context, endpoint registry.APIEndpoint) ([]challenge.Challenge, error) {
+	pingClient := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: endpoint.TLSConfig,
+		},
+		Timeout: 15 * time.Second,
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(endpoint.URL.String(), "/")+"/v2/", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", command.UserAgent())
+	resp, err := pingClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return challenge.ResponseChallenges(resp), nil
+}
+
+func requestDeviceCode(ctx context.Context, httpClient *http.Client, server authServer) (deviceAuthorizationResponse, error) {
+	form := url.Values{"client_id": {registry.AuthClientID}}
+	if server.Service != "" {
+		form.Set("service", server.Service)
+	}
+	var auth deviceAuthorizationResponse
+	resp, err := postForm(ctx, httpClient, server.DeviceAuthorizationEndpoint, form)
+	if err != nil {
+		return auth, errors.Wrap(err, "failed to request a device code")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return auth, errors.Errorf("failed to request a device code: %s", readTokenError(resp))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&auth); err != nil {
+		return auth, errors.Wrap(err, "invalid device authorization response")
+	}
+	if auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationURI == "" {
+		return auth, errors.New("invalid device authorization response: missing device code, user code, or verification URI")
+	}
+	return auth, nil
+}
+
+// pollDeviceToken polls the token endpoint of the authorization server until
+// the login is authorized, denied, or the device code expires, and returns
+// the refresh token issued.
+func pollDeviceToken(ctx context.Context, httpClient *http.Client, server authServer, auth deviceAuthorizationResponse) (string, error) {
+	interval := auth.Interval
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	var expired <-chan time.Time
+	if auth.ExpiresIn > 0 {
+		timer := time.NewTimer(time.Duration(auth.ExpiresIn) * devicePollIntervalUnit)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	form := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {auth.DeviceCode},
+		"client_id":   {registry.AuthClientID},
+	}
+	if server.Service != "" {
+		form.Set("service", server.Service)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-expired:
+			return "", errors.New("the device code expired before the login was authorized")
+		case <-time.After(time.Duration(interval) * devicePollIntervalUnit):
+		}
+
+		resp, err := postForm(ctx, httpClient, server.TokenEndpoint, form)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to request a token")
+		}
+		var token deviceTokenResponse
+		err = json.NewDecoder(resp.Body).Decode(&token)
+		resp.Body.Close()
+		if err != nil {
+			return "", errors.Wrap(err, "invalid token response")
+		}
+
+		switch token.Error {
+		case "":
+			if token.RefreshToken == "" {
+				return "", errors.New("the authorization server did not issue a refresh token")
+			}
+			return token.RefreshToken, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5
+		case "access_denied":
+			return "", errors.New("the login was denied")
+		case "expired_token":
+			return "", errors.New("the device code expired before the login was authorized")
+		default:
+			return "", errors.Errorf("failed to request a token: %s", tokenErrorString(token.Error, token.ErrorDescription))
+		}
+	}
+}
+
+func postForm(ctx context.Context, httpClient *http.Client, endpoint string, form url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", command.UserAgent())
+	return httpClient.Do(req)
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", command.UserAgent())
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status: %s", resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// readTokenError returns the OAuth2 error in the body of resp, or its status
+// if the body does not contain one.
+func readTokenError(resp *http.Response) string {
+	var token deviceTokenResponse
+	if body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024)); err == nil && json.Unmarshal(body, &token) == nil && token.Error != "" {
+		return tokenErrorString(token.Error, token.ErrorDescription)
+	}
+	return resp.Status
+}
+
+func tokenErrorString(code, description string) string {
+	if description == "" {
+		return code
+	}
+	return code + ": " + description
+}
@@ -0,0 +1,130 @@
+package registry
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/internal/test"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+// fakeAuthServer is a stand-in for a registry, and the authorization server
+// of its auth realm, that supports the device authorization grant.
+type fakeAuthServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	polls    int
+	pending  int
+	denied   bool
+	noDevice bool
+}
+
+func newFakeAuthServer(t *testing.T) *fakeAuthServer {
+	t.Helper()
+	s := &fakeAuthServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="`+s.URL+`/token",service="registry.test"`)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		metadata := map[string]string{"token_endpoint": s.URL + "/token"}
+		if !s.noDevice {
+			metadata["device_authorization_endpoint"] = s.URL + "/device/code"
+		}
+		_ = json.NewEncoder(w).Encode(metadata)
+	})
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		assert.Check(t, is.Equal(r.FormValue("client_id"), "docker"))
+		assert.Check(t, is.Equal(r.FormValue("service"), "registry.test"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"device_code":      "the-device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": s.URL + "/device",
+			"expires_in":       60,
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Check(t, is.Equal(r.FormValue("grant_type"), deviceCodeGrantType))
+		assert.Check(t, is.Equal(r.FormValue("device_code"), "the-device-code"))
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.polls++
+		switch {
+		case s.denied:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "access_denied"})
+		case s.polls <= s.pending:
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "refresh_token": "the-refresh-token"})
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newDeviceLoginTestCli(t *testing.T) *test.FakeCli {
+	t.Helper()
+	devicePollIntervalUnit = time.Millisecond
+	t.Cleanup(func() { devicePollIntervalUnit = time.Second })
+
+	cli := test.NewFakeCli(&fakeClient{})
+	cli.ConfigFile().Filename = fs.NewFile(t, "test-run-login-device").Path()
+	return cli
+}
+
+func TestRunLoginDevice(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.pending = 2
+	cli := newDeviceLoginTestCli(t)
+
+	// the test server listens on 127.0.0.1, which is an insecure registry
+	serverAddress := server.Listener.Addr().String()
+	assert.NilError(t, runLogin(cli, loginOptions{serverAddress: serverAddress, device: true}))
+	assert.Check(t, is.Equal(server.polls, 3))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), server.URL+"/device"))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "ABCD-EFGH"))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "Login Succeeded"))
+
+	authConfig, err := cli.ConfigFile().GetAuthConfig(serverAddress)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(authConfig.IdentityToken, "the-refresh-token"))
+	assert.Check(t, is.Equal(authConfig.Password, ""))
+}
+
+func TestRunLoginDeviceDenied(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.denied = true
+	cli := newDeviceLoginTestCli(t)
+
+	err := runLogin(cli, loginOptions{serverAddress: server.Listener.Addr().String(), device: true})
+	assert.Check(t, is.Error(err, "the login was denied"))
+	assert.Check(t, is.Len(cli.ConfigFile().AuthConfigs, 0))
+}
+
+func TestRunLoginDeviceNotSupported(t *testing.T) {
+	server := newFakeAuthServer(t)
+	server.noDevice = true
+	cli := newDeviceLoginTestCli(t)
+
+	serverAddress := server.Listener.Addr().String()
+	err := runLogin(cli, loginOptions{serverAddress: serverAddress, device: true})
+	assert.Check(t, is.Error(err, "the authorization server of "+serverAddress+" does not support device login"))
+}
+
+func TestRunLoginDeviceWithPassword(t *testing.T) {
+	cli := newDeviceLoginTestCli(t)
+	err := runLogin(cli, loginOptions{serverAddress: "registry.example.com", device: true, user: "user", password: "pass"})
+	assert.Check(t, is.Error(err, "--device cannot be combined with --username, --password, or --password-stdin"))
+}
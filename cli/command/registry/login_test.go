@@ -182,3 +182,51 @@ func TestRunLogin(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitLoginScope(t *testing.T) {
+	testCases := []struct {
+		serverAddress   string
+		expectedScope   string
+		expectedAddress string
+	}{
+		{serverAddress: "registry.example.com", expectedAddress: "registry.example.com"},
+		{serverAddress: "https://registry.example.com/v2/", expectedAddress: "https://registry.example.com/v2/"},
+		{serverAddress: "registry.example.com/team-a", expectedScope: "registry.example.com/team-a", expectedAddress: "registry.example.com"},
+		{serverAddress: "registry.example.com/team-a/", expectedScope: "registry.example.com/team-a", expectedAddress: "registry.example.com"},
+		{serverAddress: "index.docker.io/myorg", expectedScope: "docker.io/myorg", expectedAddress: "https://index.docker.io/v1/"},
+		{serverAddress: "docker.io/myorg", expectedScope: "docker.io/myorg", expectedAddress: "https://index.docker.io/v1/"},
+	}
+	for _, tc := range testCases {
+		scope, address := splitLoginScope(tc.serverAddress)
+		assert.Check(t, is.Equal(scope, tc.expectedScope), tc.serverAddress)
+		assert.Check(t, is.Equal(address, tc.expectedAddress), tc.serverAddress)
+	}
+}
+
+func TestRunLoginScoped(t *testing.T) {
+	tmpFile := fs.NewFile(t, "test-run-login-scoped")
+	cli := test.NewFakeCli(&fakeClient{})
+	configfile := cli.ConfigFile()
+	configfile.Filename = tmpFile.Path()
+
+	assert.NilError(t, runLogin(cli, loginOptions{serverAddress: "reg1", user: "u1", password: "p1"}))
+	assert.NilError(t, runLogin(cli, loginOptions{serverAddress: "reg1/team-a/", user: "u2", password: "p2"}))
+
+	expected := map[string]configtypes.AuthConfig{
+		"reg1":        {ServerAddress: "reg1", Username: "u1", Password: "p1"},
+		"reg1/team-a": {ServerAddress: "reg1/team-a", Username: "u2", Password: "p2"},
+	}
+	for addr, ac := range expected {
+		actual, err := configfile.GetAuthConfig(addr)
+		assert.NilError(t, err)
+		assert.Check(t, is.DeepEqual(actual, ac))
+	}
+
+	// logging out of the scope keeps the credentials for the registry
+	assert.NilError(t, runLogout(cli, "reg1/team-a"))
+	assert.Check(t, is.Contains(cli.OutBuffer().String(), "Removing login credentials for reg1/team-a\n"))
+	assert.Check(t, is.Len(configfile.AuthConfigs, 1))
+	actual, err := configfile.GetAuthConfig("reg1")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, expected["reg1"]))
+}
@@ -44,7 +44,12 @@ func runLogout(dockerCli command.Cli, serverAddress string) error {
 		regsToLogout    = []string{serverAddress}
 		hostnameAddress = serverAddress
 	)
-	if !isDefaultRegistry {
+	if scope, _ := splitLoginScope(serverAddress); scope != "" {
+		// only remove the credentials scoped to this path, and keep the
+		// credentials for the registry.
+		regsToLogout = []string{scope}
+		hostnameAddress = scope
+	} else if !isDefaultRegistry {
 		hostnameAddress = registry.ConvertToHostname(serverAddress)
 		// the tries below are kept for backward compatibility where a user could have
 		// saved the registry in one of the following format.
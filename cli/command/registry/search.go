@@ -6,6 +6,7 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli"
 	"github.com/harness-community/docker-cli-v23/cli/command"
 	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
 	"github.com/harness-community/docker-cli-v23/opts"
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/harness-community/docker-v23/registry"
@@ -14,8 +15,10 @@ import (
 
 type searchOptions struct {
 	format  string
+	sort    string
 	term    string
 	noTrunc bool
+	wide    bool
 	limit   int
 	filter  opts.FilterOpt
 }
@@ -43,6 +46,8 @@ func NewSearchCommand(dockerCli command.Cli) *cobra.Command {
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 	flags.IntVar(&options.limit, "limit", 0, "Max number of search results")
 	flags.StringVar(&options.format, "format", "", "Pretty-print search using a Go template")
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 
 	return cmd
 }
@@ -80,7 +85,9 @@ func runSearch(dockerCli command.Cli, options searchOptions) error {
 	searchCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewSearchFormat(options.format),
+		Sort:   options.sort,
 		Trunc:  !options.noTrunc,
+		Wide:   options.wide || options.noTrunc,
 	}
 	return SearchWrite(searchCtx, results)
 }
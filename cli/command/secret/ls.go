@@ -17,6 +17,8 @@ import (
 type listOptions struct {
 	quiet  bool
 	format string
+	sort   string
+	wide   bool
 	filter opts.FilterOpt
 }
 
@@ -38,13 +40,24 @@ func newSecretListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display IDs")
-	flags.StringVarP(&options.format, "format", "", "", flagsHelper.FormatHelp)
+	flags.StringVarP(&options.format, "format", "", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
 }
 
 func runSecretList(dockerCli command.Cli, options listOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().SecretFormat) > 0 && !options.quiet {
+		defaultFormat = dockerCli.ConfigFile().SecretFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "secret", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -52,15 +65,6 @@ func runSecretList(dockerCli command.Cli, options listOptions) error {
 	if err != nil {
 		return err
 	}
-	format := options.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().SecretFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().SecretFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	sort.Slice(secrets, func(i, j int) bool {
 		return sortorder.NaturalLess(secrets[i].Spec.Name, secrets[j].Spec.Name)
 	})
@@ -68,6 +72,8 @@ func runSecretList(dockerCli command.Cli, options listOptions) error {
 	secretCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewFormat(format, options.quiet),
+		Sort:   options.sort,
+		Wide:   options.wide,
 	}
 	return FormatWrite(secretCtx, secrets)
 }
@@ -629,6 +629,11 @@ func ListFormatWrite(ctx formatter.Context, services []swarm.Service) error {
 		"Image":    formatter.ImageHeader,
 		"Ports":    formatter.PortsHeader,
 	}
+	serviceCtx.Priorities = formatter.ColumnPriorities{
+		"Ports": 1,
+		"Image": 2,
+		"Name":  3,
+	}
 	return ctx.Write(&serviceCtx, render)
 }
 
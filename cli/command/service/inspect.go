@@ -53,6 +53,10 @@ func runInspect(dockerCli command.Cli, opts inspectOptions) error {
 	if opts.pretty {
 		opts.format = "pretty"
 	}
+	format, ok, err := command.ResolveFormat(dockerCli, "serviceInspect", opts.format, dockerCli.ConfigFile().ServiceInspectFormat)
+	if !ok {
+		return err
+	}
 
 	getRef := func(ref string) (interface{}, []byte, error) {
 		// Service inspect shows defaults values in empty fields.
@@ -71,12 +75,9 @@ func runInspect(dockerCli command.Cli, opts inspectOptions) error {
 		return nil, nil, errors.Errorf("Error: no such network: %s", ref)
 	}
 
-	f := opts.format
+	f := format
 	if len(f) == 0 {
 		f = "raw"
-		if len(dockerCli.ConfigFile().ServiceInspectFormat) > 0 {
-			f = dockerCli.ConfigFile().ServiceInspectFormat
-		}
 	}
 
 	// check if the user is trying to apply a template to the pretty format, which
@@ -19,6 +19,8 @@ import (
 type listOptions struct {
 	quiet  bool
 	format string
+	sort   string
+	wide   bool
 	filter opts.FilterOpt
 }
 
@@ -38,17 +40,27 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display IDs")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
 }
 
 func runList(dockerCli command.Cli, opts listOptions) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().ServicesFormat) > 0 && !opts.quiet {
+		defaultFormat = dockerCli.ConfigFile().ServicesFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "services", opts.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	var (
 		apiClient = dockerCli.Client()
 		ctx       = context.Background()
-		err       error
 	)
 
 	listOpts := types.ServiceListOptions{
@@ -84,18 +96,11 @@ func runList(dockerCli command.Cli, opts listOptions) error {
 		}
 	}
 
-	format := opts.format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().ServicesFormat) > 0 && !opts.quiet {
-			format = dockerCli.ConfigFile().ServicesFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	servicesCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: NewListFormat(format, opts.quiet),
+		Sort:   opts.sort,
+		Wide:   opts.wide,
 	}
 	return ListFormatWrite(servicesCtx, services)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/harness-community/docker-cli-v23/cli/command/idresolver"
 	"github.com/harness-community/docker-cli-v23/cli/command/node"
 	"github.com/harness-community/docker-cli-v23/cli/command/task"
+	flagsHelper "github.com/harness-community/docker-cli-v23/cli/flags"
 	"github.com/harness-community/docker-cli-v23/opts"
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/harness-community/docker-v23/api/types/filters"
@@ -23,6 +24,8 @@ type psOptions struct {
 	noResolve bool
 	noTrunc   bool
 	format    string
+	sort      string
+	wide      bool
 	filter    opts.FilterOpt
 }
 
@@ -46,12 +49,19 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&options.noTrunc, "no-trunc", false, "Do not truncate output")
 	flags.BoolVar(&options.noResolve, "no-resolve", false, "Do not map IDs to Names")
 	flags.StringVar(&options.format, "format", "", "Pretty-print tasks using a Go template")
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", "Filter output based on conditions provided")
 
 	return cmd
 }
 
 func runPS(dockerCli command.Cli, options psOptions) error {
+	format, ok, err := command.ResolveFormat(dockerCli, "tasks", options.format, task.DefaultFormat(dockerCli.ConfigFile(), options.quiet))
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	ctx := context.Background()
 
@@ -67,15 +77,10 @@ func runPS(dockerCli command.Cli, options psOptions) error {
 	if err != nil {
 		return err
 	}
-
-	format := options.format
-	if len(format) == 0 {
-		format = task.DefaultFormat(dockerCli.ConfigFile(), options.quiet)
-	}
 	if options.quiet {
 		options.noTrunc = true
 	}
-	if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.quiet, format); err != nil {
+	if err := task.Print(ctx, dockerCli, tasks, idresolver.New(client, options.noResolve), !options.noTrunc, options.wide, options.quiet, format, options.sort); err != nil {
 		return err
 	}
 	if len(notfound) != 0 {
@@ -57,7 +57,7 @@ func trustedResolveDigest(ctx context.Context, cli command.Cli, ref reference.Na
 		return nil, err
 	}
 
-	authConfig := command.ResolveAuthConfig(ctx, cli, repoInfo.Index)
+	authConfig := command.ResolveRepositoryAuthConfig(ctx, cli, repoInfo)
 
 	notaryRepo, err := trust.GetNotaryRepository(cli.In(), cli.Out(), command.UserAgent(), repoInfo, &authConfig, "pull")
 	if err != nil {
@@ -30,6 +30,8 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 
 	flags := cmd.Flags()
 	flags.StringVar(&opts.Format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.Sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.Wide, "wide", false, flagsHelper.WideHelp)
 	return cmd
 }
 
@@ -52,6 +54,8 @@ func format(dockerCli command.Cli, opts options.List, stacks []*formatter.Stack)
 	stackCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: format,
+		Sort:   opts.Sort,
+		Wide:   opts.Wide,
 	}
 	sort.Slice(stacks, func(i, j int) bool {
 		return sortorder.NaturalLess(stacks[i].Name, stacks[j].Name) ||
@@ -20,6 +20,8 @@ type Config struct {
 // List holds docker stack ls options
 type List struct {
 	Format        string
+	Sort          string
+	Wide          bool
 	AllNamespaces bool
 }
 
@@ -31,6 +33,8 @@ type PS struct {
 	NoResolve bool
 	Quiet     bool
 	Format    string
+	Sort      string
+	Wide      bool
 }
 
 // Remove holds docker stack remove options
@@ -42,6 +46,8 @@ type Remove struct {
 type Services struct {
 	Quiet     bool
 	Format    string
+	Sort      string
+	Wide      bool
 	Filter    opts.FilterOpt
 	Namespace string
 }
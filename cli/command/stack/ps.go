@@ -34,7 +34,9 @@ func newPsCommand(dockerCli command.Cli) *cobra.Command {
 	flags.BoolVar(&opts.NoResolve, "no-resolve", false, "Do not map IDs to Names")
 	flags.VarP(&opts.Filter, "filter", "f", "Filter output based on conditions provided")
 	flags.BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display task IDs")
-	flags.StringVar(&opts.Format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.Format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&opts.Sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.Wide, "wide", false, flagsHelper.WideHelp)
 	return cmd
 }
 
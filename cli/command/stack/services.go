@@ -38,13 +38,24 @@ func newServicesCommand(dockerCli command.Cli) *cobra.Command {
 	}
 	flags := cmd.Flags()
 	flags.BoolVarP(&opts.Quiet, "quiet", "q", false, "Only display IDs")
-	flags.StringVar(&opts.Format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&opts.Format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&opts.Sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&opts.Wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&opts.Filter, "filter", "f", "Filter output based on conditions provided")
 	return cmd
 }
 
 // RunServices performs a stack services against the specified swarm cluster
 func RunServices(dockerCli command.Cli, flags *pflag.FlagSet, opts options.Services) error {
+	defaultFormat := formatter.TableFormatKey
+	if len(dockerCli.ConfigFile().ServicesFormat) > 0 && !opts.Quiet {
+		defaultFormat = dockerCli.ConfigFile().ServicesFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "services", opts.Format, defaultFormat)
+	if !ok {
+		return err
+	}
+	opts.Format = format
 	services, err := GetServices(dockerCli, flags, opts)
 	if err != nil {
 		return err
@@ -67,18 +78,11 @@ func formatWrite(dockerCli command.Cli, services []swarmtypes.Service, opts opti
 		return sortorder.NaturalLess(services[i].Spec.Name, services[j].Spec.Name)
 	})
 
-	format := opts.Format
-	if len(format) == 0 {
-		if len(dockerCli.ConfigFile().ServicesFormat) > 0 && !opts.Quiet {
-			format = dockerCli.ConfigFile().ServicesFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
-	}
-
 	servicesCtx := formatter.Context{
 		Output: dockerCli.Out(),
-		Format: service.NewListFormat(format, opts.Quiet),
+		Format: service.NewListFormat(opts.Format, opts.Quiet),
+		Sort:   opts.Sort,
+		Wide:   opts.Wide,
 	}
 	return service.ListFormatWrite(servicesCtx, services)
 }
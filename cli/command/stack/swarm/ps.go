@@ -13,6 +13,11 @@ import (
 
 // RunPS is the swarm implementation of docker stack ps
 func RunPS(dockerCli command.Cli, opts options.PS) error {
+	format, ok, err := command.ResolveFormat(dockerCli, "tasks", opts.Format, task.DefaultFormat(dockerCli.ConfigFile(), opts.Quiet))
+	if !ok {
+		return err
+	}
+
 	filter := getStackFilterFromOpt(opts.Namespace, opts.Filter)
 
 	ctx := context.Background()
@@ -26,10 +31,5 @@ func RunPS(dockerCli command.Cli, opts options.PS) error {
 		return fmt.Errorf("nothing found in stack: %s", opts.Namespace)
 	}
 
-	format := opts.Format
-	if len(format) == 0 {
-		format = task.DefaultFormat(dockerCli.ConfigFile(), opts.Quiet)
-	}
-
-	return task.Print(ctx, dockerCli, tasks, idresolver.New(client, opts.NoResolve), !opts.NoTrunc, opts.Quiet, format)
+	return task.Print(ctx, dockerCli, tasks, idresolver.New(client, opts.NoResolve), !opts.NoTrunc, opts.Wide, opts.Quiet, format, opts.Sort)
 }
@@ -28,7 +28,10 @@ func newDiskUsageCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runDiskUsage(dockerCli, opts)
 		},
-		Annotations:       map[string]string{"version": "1.25"},
+		Annotations: map[string]string{
+			"version":                      "1.25",
+			command.MultiContextAnnotation: "",
+		},
 		ValidArgsFunction: completion.NoComplete,
 	}
 
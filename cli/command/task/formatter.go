@@ -63,6 +63,11 @@ func FormatWrite(ctx formatter.Context, tasks []swarm.Task, names map[string]str
 		"Error":        formatter.ErrorHeader,
 		"Ports":        formatter.PortsHeader,
 	}
+	taskCtx.Priorities = formatter.ColumnPriorities{
+		"Error": 1,
+		"Ports": 1,
+		"Image": 2,
+	}
 	return ctx.Write(&taskCtx, render)
 }
 
@@ -34,7 +34,11 @@ func (t tasksSortable) Less(i, j int) bool {
 // Print task information in a format.
 // Besides this, command `docker node ps <node>`
 // and `docker stack ps` will call this, too.
-func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resolver *idresolver.IDResolver, trunc, quiet bool, format string) error {
+//
+// Table columns are truncated to fit the width of the terminal, unless wide or
+// trunc is false. If sortSpec is set, the slots of the tasks are sorted by the given fields of
+// their most recent task, which is followed by the previous tasks of the slot.
+func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resolver *idresolver.IDResolver, trunc, wide, quiet bool, format, sortSpec string) error {
 	tasks, err := generateTaskNames(ctx, tasks, resolver)
 	if err != nil {
 		return err
@@ -52,6 +56,21 @@ func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resol
 		Output: dockerCli.Out(),
 		Format: NewTaskFormat(format, quiet),
 		Trunc:  trunc,
+		Wide:   wide || !trunc,
+	}
+
+	for _, task := range tasks {
+		nodeValue, err := resolver.Resolve(ctx, swarm.Node{}, task.NodeID)
+		if err != nil {
+			return err
+		}
+		nodes[task.ID] = nodeValue
+	}
+
+	if sortSpec != "" {
+		if tasks, err = sortSlots(tasks, nodes, trunc, sortSpec); err != nil {
+			return err
+		}
 	}
 
 	var indent string
@@ -67,17 +86,35 @@ func Print(ctx context.Context, dockerCli command.Cli, tasks []swarm.Task, resol
 			names[task.ID] = task.Name
 		}
 		prevName = task.Name
-
-		nodeValue, err := resolver.Resolve(ctx, swarm.Node{}, task.NodeID)
-		if err != nil {
-			return err
-		}
-		nodes[task.ID] = nodeValue
 	}
 
 	return FormatWrite(tasksCtx, tasks, names, nodes)
 }
 
+// sortSlots sorts the slots of tasks, which must be sorted using
+// tasksSortable, by the given fields of their most recent task. The previous
+// tasks of each slot stay after its most recent task.
+func sortSlots(tasks []swarm.Task, nodes map[string]string, trunc bool, sortSpec string) ([]swarm.Task, error) {
+	var (
+		latest []formatter.SubContext
+		slots  = map[string][]swarm.Task{}
+	)
+	for _, task := range tasks {
+		if _, ok := slots[task.Name]; !ok {
+			latest = append(latest, &taskContext{trunc: trunc, task: task, name: task.Name, node: nodes[task.ID]})
+		}
+		slots[task.Name] = append(slots[task.Name], task)
+	}
+	if err := formatter.SortRows(sortSpec, &taskContext{}, latest); err != nil {
+		return nil, err
+	}
+	sorted := make([]swarm.Task, 0, len(tasks))
+	for _, sub := range latest {
+		sorted = append(sorted, slots[sub.(*taskContext).task.Name]...)
+	}
+	return sorted, nil
+}
+
 // generateTaskNames generates names for the given tasks, and returns a copy of
 // the slice with the 'Name' field set.
 //
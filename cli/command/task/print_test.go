@@ -12,6 +12,7 @@ import (
 	"github.com/harness-community/docker-v23/api/types"
 	"github.com/harness-community/docker-v23/api/types/swarm"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/golden"
 )
 
@@ -45,11 +46,37 @@ func TestTaskPrintSorted(t *testing.T) {
 		),
 	}
 
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, false), false, false, formatter.TableFormatKey)
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, false), false, false, false, formatter.TableFormatKey, "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-sorted.golden")
 }
 
+func TestTaskPrintSortedBySortOption(t *testing.T) {
+	createdAt := func(d time.Duration) func(*swarm.Task) {
+		return func(task *swarm.Task) { task.CreatedAt = time.Now().Add(-d) }
+	}
+	apiClient := &fakeClient{}
+	cli := test.NewFakeCli(apiClient)
+	tasks := []swarm.Task{
+		*Task(TaskID("id-a0"), TaskServiceID("svc"), TaskSlot(1), TaskNodeID("node-z"), createdAt(2*time.Hour)),
+		*Task(TaskID("id-a1"), TaskServiceID("svc"), TaskSlot(1), TaskNodeID("node-b"), createdAt(time.Hour)),
+		*Task(TaskID("id-b1"), TaskServiceID("svc"), TaskSlot(2), TaskNodeID("node-a"), createdAt(time.Hour)),
+	}
+	// slots are sorted by their most recent task, which is followed by the
+	// previous tasks of the slot
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, true), false, false, false, "table {{.ID}}\t{{.Name}}\t{{.Node}}", "node")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(cli.OutBuffer().String(), `ID        NAME        NODE
+id-b1     svc.2       node-a
+id-a1     svc.1       node-b
+id-a0      \_ svc.1   node-z
+`))
+
+	cli.OutBuffer().Reset()
+	err = Print(context.Background(), cli, tasks, idresolver.New(apiClient, true), false, false, false, formatter.TableFormatKey, "nope")
+	assert.Check(t, is.Error(err, `invalid sort field "nope"`))
+}
+
 func TestTaskPrintWithQuietOption(t *testing.T) {
 	quiet := true
 	trunc := false
@@ -57,7 +84,7 @@ func TestTaskPrintWithQuietOption(t *testing.T) {
 	apiClient := &fakeClient{}
 	cli := test.NewFakeCli(apiClient)
 	tasks := []swarm.Task{*Task(TaskID("id-foo"))}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, formatter.TableFormatKey)
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, formatter.TableFormatKey, "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-quiet-option.golden")
 }
@@ -71,7 +98,7 @@ func TestTaskPrintWithNoTruncOption(t *testing.T) {
 	tasks := []swarm.Task{
 		*Task(TaskID("id-foo-yov6omdek8fg3k5stosyp2m50")),
 	}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, "{{ .ID }}")
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, "{{ .ID }}", "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-no-trunc-option.golden")
 }
@@ -85,7 +112,7 @@ func TestTaskPrintWithGlobalService(t *testing.T) {
 	tasks := []swarm.Task{
 		*Task(TaskServiceID("service-id-foo"), TaskNodeID("node-id-bar"), TaskSlot(0)),
 	}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, "{{ .Name }}")
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, "{{ .Name }}", "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-global-service.golden")
 }
@@ -99,7 +126,7 @@ func TestTaskPrintWithReplicatedService(t *testing.T) {
 	tasks := []swarm.Task{
 		*Task(TaskServiceID("service-id-foo"), TaskSlot(1)),
 	}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, "{{ .Name }}")
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, "{{ .Name }}", "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-replicated-service.golden")
 }
@@ -135,7 +162,7 @@ func TestTaskPrintWithIndentation(t *testing.T) {
 			WithStatus(TaskState(swarm.TaskStateFailed), Timestamp(time.Now().Add(-2*time.Hour))),
 		),
 	}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, formatter.TableFormatKey)
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, formatter.TableFormatKey, "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-indentation.golden")
 }
@@ -156,7 +183,7 @@ func TestTaskPrintWithResolution(t *testing.T) {
 	tasks := []swarm.Task{
 		*Task(TaskServiceID("service-id-foo"), TaskSlot(1)),
 	}
-	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, quiet, "{{ .Name }} {{ .Node }}")
+	err := Print(context.Background(), cli, tasks, idresolver.New(apiClient, noResolve), trunc, false, quiet, "{{ .Name }} {{ .Node }}", "")
 	assert.NilError(t, err)
 	golden.Assert(t, cli.OutBuffer().String(), "task-print-with-resolution.golden")
 }
@@ -43,7 +43,7 @@ func newSignCommand(dockerCli command.Cli) *cobra.Command {
 func runSignImage(cli command.Cli, options signOptions) error {
 	imageName := options.imageName
 	ctx := context.Background()
-	imgRefAndAuth, err := trust.GetImageReferencesAndAuth(ctx, nil, image.AuthResolver(cli), imageName)
+	imgRefAndAuth, err := trust.GetImageReferencesAndRepositoryAuth(ctx, nil, image.RepositoryAuthResolver(cli), imageName)
 	if err != nil {
 		return err
 	}
@@ -92,7 +92,7 @@ func runSignImage(cli command.Cli, options signOptions) error {
 			}
 			fmt.Fprintf(cli.Err(), "Signing and pushing trust data for local image %s, may overwrite remote trust data\n", imageName)
 
-			authConfig := command.ResolveAuthConfig(ctx, cli, imgRefAndAuth.RepoInfo().Index)
+			authConfig := command.ResolveRepositoryAuthConfig(ctx, cli, imgRefAndAuth.RepoInfo())
 			encodedAuth, err := command.EncodeAuthToBase64(authConfig)
 			if err != nil {
 				return err
@@ -21,6 +21,8 @@ const (
 type listOptions struct {
 	quiet   bool
 	format  string
+	sort    string
+	wide    bool
 	cluster bool
 	filter  opts.FilterOpt
 }
@@ -36,12 +38,15 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runList(dockerCli, options)
 		},
+		Annotations:       map[string]string{command.MultiContextAnnotation: ""},
 		ValidArgsFunction: completion.NoComplete,
 	}
 
 	flags := cmd.Flags()
 	flags.BoolVarP(&options.quiet, "quiet", "q", false, "Only display volume names")
-	flags.StringVar(&options.format, "format", "", flagsHelper.FormatHelp)
+	flags.StringVar(&options.format, "format", "", flagsHelper.FormatPresetHelp)
+	flags.StringVar(&options.sort, "sort", "", flagsHelper.SortHelp)
+	flags.BoolVar(&options.wide, "wide", false, flagsHelper.WideHelp)
 	flags.VarP(&options.filter, "filter", "f", `Provide filter values (e.g. "dangling=true")`)
 	flags.BoolVar(&options.cluster, "cluster", false, "Display only cluster volumes, and use cluster volume list formatting")
 	flags.SetAnnotation("cluster", "version", []string{"1.42"})
@@ -51,19 +56,23 @@ func newListCommand(dockerCli command.Cli) *cobra.Command {
 }
 
 func runList(dockerCli command.Cli, options listOptions) error {
+	var defaultFormat string
+	if len(dockerCli.ConfigFile().VolumesFormat) > 0 && !options.quiet && !options.cluster {
+		defaultFormat = dockerCli.ConfigFile().VolumesFormat
+	}
+	format, ok, err := command.ResolveFormat(dockerCli, "volumes", options.format, defaultFormat)
+	if !ok {
+		return err
+	}
+
 	client := dockerCli.Client()
 	volumes, err := client.VolumeList(context.Background(), options.filter.Value())
 	if err != nil {
 		return err
 	}
 
-	format := options.format
 	if len(format) == 0 && !options.cluster {
-		if len(dockerCli.ConfigFile().VolumesFormat) > 0 && !options.quiet {
-			format = dockerCli.ConfigFile().VolumesFormat
-		} else {
-			format = formatter.TableFormatKey
-		}
+		format = formatter.TableFormatKey
 	} else if options.cluster {
 		// TODO(dperny): write server-side filter for cluster volumes. For this
 		// proof of concept, we'll just filter out non-cluster volumes here
@@ -91,6 +100,8 @@ func runList(dockerCli command.Cli, options listOptions) error {
 	volumeCtx := formatter.Context{
 		Output: dockerCli.Out(),
 		Format: formatter.NewVolumeFormat(format, options.quiet),
+		Sort:   options.sort,
+		Wide:   options.wide,
 	}
 	return formatter.VolumeWrite(volumeCtx, volumes.Volumes)
 }
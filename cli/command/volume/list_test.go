@@ -127,6 +127,64 @@ func TestVolumeListSortOrder(t *testing.T) {
 	golden.Assert(t, cli.OutBuffer().String(), "volume-list-sort.golden")
 }
 
+func TestVolumeListSortFlag(t *testing.T) {
+	cli := test.NewFakeCli(&fakeClient{
+		volumeListFunc: func(filter filters.Args) (volume.ListResponse, error) {
+			return volume.ListResponse{
+				Volumes: []*volume.Volume{
+					{Name: "small", UsageData: &volume.UsageData{Size: 900 * 1000}},
+					{Name: "large", UsageData: &volume.UsageData{Size: 2 * 1000 * 1000 * 1000}},
+					{Name: "medium", UsageData: &volume.UsageData{Size: 30 * 1000 * 1000}},
+				},
+			}, nil
+		},
+	})
+	cmd := newListCommand(cli)
+	cmd.Flags().Set("format", "{{ .Name }} {{ .Size }}")
+	cmd.Flags().Set("sort", "size:desc")
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, cli.OutBuffer().String(), "large 2GB\nmedium 30MB\nsmall 900kB\n")
+}
+
+func TestVolumeListWithFormatPreset(t *testing.T) {
+	newCli := func() *test.FakeCli {
+		cli := test.NewFakeCli(&fakeClient{
+			volumeListFunc: func(filter filters.Args) (volume.ListResponse, error) {
+				return volume.ListResponse{
+					Volumes: []*volume.Volume{
+						Volume(VolumeName("foo"), VolumeDriver("bar")),
+						Volume(VolumeName("baz")),
+					},
+				}, nil
+			},
+		})
+		cli.SetConfigFile(&configfile.ConfigFile{
+			VolumesFormat: "{{ .Name }} {{ .Driver }}",
+			Formats: map[string]map[string]string{
+				"volumes": {"names": "{{ .Name }}"},
+			},
+		})
+		return cli
+	}
+
+	cli := newCli()
+	cmd := newListCommand(cli)
+	cmd.Flags().Set("format", "@names")
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, cli.OutBuffer().String(), "baz\nfoo\n")
+
+	cli = newCli()
+	cmd = newListCommand(cli)
+	cmd.Flags().Set("format", "@nope")
+	assert.Error(t, cmd.Execute(), `unknown format preset "@nope": available presets are @names`)
+
+	cli = newCli()
+	cmd = newListCommand(cli)
+	cmd.Flags().Set("format", "help")
+	assert.NilError(t, cmd.Execute())
+	assert.Equal(t, cli.OutBuffer().String(), "NAME     FORMAT\n@names   {{ .Name }}\n")
+}
+
 func TestClusterVolumeList(t *testing.T) {
 	cli := test.NewFakeCli(&fakeClient{
 		volumeListFunc: func(filter filters.Args) (volume.ListResponse, error) {
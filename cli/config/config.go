@@ -1,6 +1,7 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
 	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
+	"github.com/harness-community/docker-cli-v23/cli/config/schema"
 	"github.com/harness-community/docker-cli-v23/cli/config/types"
 	"github.com/harness-community/docker-v23/pkg/homedir"
 	"github.com/pkg/errors"
@@ -23,6 +25,10 @@ const (
 	contextsDir    = "contexts"
 )
 
+// ProjectConfigFileName is the name of the project configuration file, which
+// is looked up in the current directory and its parents.
+const ProjectConfigFileName = ".dockercli.json"
+
 var (
 	initConfigDir = new(sync.Once)
 	configDir     string
@@ -96,15 +102,18 @@ func LoadFromReader(configData io.Reader) (*configfile.ConfigFile, error) {
 
 // Load reads the configuration files in the given directory, and sets up
 // the auth config information and returns values.
+//
+// The configuration file in the given directory is merged with the system
+// and project configuration files, if present (see LoadLayers).
 // FIXME: use the internal golang config parser
 func Load(configDir string) (*configfile.ConfigFile, error) {
-	cfg, _, err := load(configDir)
+	cfg, _, _, err := load(configDir, false)
 	return cfg, err
 }
 
 // TODO remove this temporary hack, which is used to warn about the deprecated ~/.dockercfg file
 // so we can remove the bool return value and collapse this back into `Load`
-func load(configDir string) (*configfile.ConfigFile, bool, error) {
+func load(configDir string, strict bool) (*configfile.ConfigFile, []string, bool, error) {
 	printLegacyFileWarning := false
 
 	if configDir == "" {
@@ -115,39 +124,138 @@ func load(configDir string) (*configfile.ConfigFile, bool, error) {
 	configFile := configfile.New(filename)
 
 	// Try happy path first - latest config file
-	if file, err := os.Open(filename); err == nil {
-		defer file.Close()
-		err = configFile.LoadFromReader(file)
-		if err != nil {
-			err = errors.Wrap(err, filename)
+	data, err := os.ReadFile(filename)
+	if err == nil {
+		if err := configFile.LoadFromReader(bytes.NewReader(data)); err != nil {
+			return configFile, nil, printLegacyFileWarning, errors.Wrap(err, filename)
 		}
-		return configFile, printLegacyFileWarning, err
 	} else if !os.IsNotExist(err) {
 		// if file is there but we can't stat it for any reason other
 		// than it doesn't exist then stop
-		return configFile, printLegacyFileWarning, errors.Wrap(err, filename)
+		return configFile, nil, printLegacyFileWarning, errors.Wrap(err, filename)
+	} else if _, err := os.Stat(filepath.Join(getHomeDir(), oldConfigfile)); err == nil {
+		// Can't find latest config file so check for the old one
+		printLegacyFileWarning = true
 	}
 
-	// Can't find latest config file so check for the old one
-	filename = filepath.Join(getHomeDir(), oldConfigfile)
-	if _, err := os.Stat(filename); err == nil {
-		printLegacyFileWarning = true
+	layered, warnings, err := loadLayers(filename, data, strict)
+	if err != nil {
+		return configFile, warnings, printLegacyFileWarning, err
+	}
+	return layered, warnings, printLegacyFileWarning, nil
+}
+
+// LoadLayers returns the configuration obtained by merging, from lowest to
+// highest precedence, the system configuration file (if present), the given
+// user configuration, and the project configuration file found in the
+// current directory or its parents (if any). Saving the configuration only
+// writes to the user configuration file.
+//
+// System and project configuration files that cannot be loaded are ignored,
+// and reported as warnings. Problems found when validating the configuration
+// files against the schema of the configuration file, such as unknown
+// properties, are reported as warnings as well.
+func LoadLayers(userFilename string, userData []byte) (*configfile.ConfigFile, []string, error) {
+	return loadLayers(userFilename, userData, false)
+}
+
+// loadLayers implements LoadLayers. In strict mode, an error is returned if
+// the configuration files do not match the schema of the configuration file.
+func loadLayers(userFilename string, userData []byte, strict bool) (*configfile.ConfigFile, []string, error) {
+	var warnings, problems []string
+	validate := func(filename string, data []byte) {
+		p, err := schema.Validate(data)
+		if err != nil {
+			// the file is known to be valid JSON at this point
+			p = []string{err.Error()}
+		}
+		for _, problem := range p {
+			problems = append(problems, filename+": "+problem)
+		}
+	}
+	readLayer := func(name, filename string) (configfile.Layer, bool) {
+		data, err := os.ReadFile(filename)
+		if err == nil {
+			err = configfile.New(filename).LoadFromReader(bytes.NewReader(data))
+		}
+		if err != nil {
+			if !os.IsNotExist(err) {
+				warnings = append(warnings, fmt.Sprintf("ignoring %s configuration file %s: %v", name, filename, err))
+			}
+			return configfile.Layer{}, false
+		}
+		validate(filename, data)
+		return configfile.Layer{Name: name, Filename: filename, Data: data}, true
+	}
+
+	var layers []configfile.Layer
+	if l, ok := readLayer(configfile.LayerSystem, systemConfigFile); ok {
+		layers = append(layers, l)
+	}
+	validate(userFilename, userData)
+	layers = append(layers, configfile.Layer{Name: configfile.LayerUser, Filename: userFilename, Data: userData})
+	if wd, err := os.Getwd(); err == nil {
+		if filename := findProjectConfigFile(wd); filename != "" {
+			if l, ok := readLayer(configfile.LayerProject, filename); ok {
+				layers = append(layers, l)
+			}
+		}
+	}
+
+	if strict && len(problems) > 0 {
+		return nil, warnings, errors.Errorf("invalid configuration:\n%s", strings.Join(problems, "\n"))
+	}
+	configFile, layerWarnings, err := configfile.NewLayered(layers)
+	return configFile, append(append(warnings, problems...), layerWarnings...), err
+}
+
+// findProjectConfigFile looks for a project configuration file in dir and its
+// parents, and returns its path, or an empty string if none was found.
+func findProjectConfigFile(dir string) string {
+	for {
+		filename := filepath.Join(dir, ProjectConfigFileName)
+		if fi, err := os.Stat(filename); err == nil && fi.Mode().IsRegular() {
+			return filename
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
 	}
-	return configFile, printLegacyFileWarning, nil
 }
 
 // LoadDefaultConfigFile attempts to load the default config file and returns
 // an initialized ConfigFile struct if none is found.
 func LoadDefaultConfigFile(stderr io.Writer) *configfile.ConfigFile {
-	configFile, printLegacyFileWarning, err := load(Dir())
+	configFile, _ := loadDefaultConfigFile(stderr, false)
+	return configFile
+}
+
+// LoadDefaultConfigFileStrict is like LoadDefaultConfigFile, but returns an
+// error if the configuration file cannot be loaded, or if the configuration
+// files do not match the schema of the configuration file, for example
+// because they contain unknown properties.
+func LoadDefaultConfigFileStrict(stderr io.Writer) (*configfile.ConfigFile, error) {
+	return loadDefaultConfigFile(stderr, true)
+}
+
+func loadDefaultConfigFile(stderr io.Writer, strict bool) (*configfile.ConfigFile, error) {
+	configFile, warnings, printLegacyFileWarning, err := load(Dir(), strict)
 	if err != nil {
+		if strict {
+			return nil, err
+		}
 		fmt.Fprintf(stderr, "WARNING: Error loading config file: %v\n", err)
 	}
+	for _, w := range warnings {
+		fmt.Fprintf(stderr, "WARNING: %s\n", w)
+	}
 	if printLegacyFileWarning {
 		_, _ = fmt.Fprintln(stderr, "WARNING: Support for the legacy ~/.dockercfg configuration file and file-format has been removed and the configuration file will be ignored")
 	}
 	if !configFile.ContainsAuth() {
 		configFile.CredentialsStore = credentials.DetectDefaultStore(configFile.CredentialsStore)
 	}
-	return configFile
+	return configFile, nil
 }
@@ -412,3 +412,76 @@ func TestConfigPath(t *testing.T) {
 
 	SetDir(oldDir)
 }
+
+func TestLoadLayers(t *testing.T) {
+	dir := setupConfigDir(t)
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, ConfigFileName), []byte(`{"psFormat": "user"}`), 0o600))
+
+	systemDir := fs.NewDir(t, t.Name(), fs.WithFile("cli-config.json", `{"psFormat": "system", "imagesFormat": "system", "detachKeys": "ctrl-x"}`))
+	oldSystemConfigFile := systemConfigFile
+	systemConfigFile = systemDir.Join("cli-config.json")
+	defer func() { systemConfigFile = oldSystemConfigFile }()
+
+	projectDir := fs.NewDir(t, t.Name(),
+		fs.WithFile(ProjectConfigFileName, `{"imagesFormat": "project", "credsStore": "evil"}`),
+		fs.WithDir("sub"),
+	)
+	env.ChangeWorkingDir(t, projectDir.Join("sub"))
+
+	buffer := new(bytes.Buffer)
+	configFile := LoadDefaultConfigFile(buffer)
+	assert.Check(t, is.Equal(buffer.String(), fmt.Sprintf("WARNING: %q cannot be set in project configuration file %s, and is ignored\n", "credsStore", projectDir.Join(ProjectConfigFileName))))
+	assert.Check(t, is.Equal(configFile.Filename, filepath.Join(dir, ConfigFileName)))
+	assert.Check(t, is.Equal(configFile.PsFormat, "user"))
+	assert.Check(t, is.Equal(configFile.ImagesFormat, "project"))
+	assert.Check(t, is.Equal(configFile.DetachKeys, "ctrl-x"))
+	assert.Check(t, is.Equal(configFile.Origins()["imagesFormat"].Layer, configfile.LayerProject))
+
+	// saving only writes to the user configuration file
+	configFile.StatsFormat = "table {{.Name}}"
+	assert.NilError(t, configFile.Save())
+	data, err := os.ReadFile(filepath.Join(dir, ConfigFileName))
+	assert.NilError(t, err)
+	assert.Check(t, !strings.Contains(string(data), "ctrl-x"))
+	assert.Check(t, !strings.Contains(string(data), "project"))
+	assert.Check(t, is.Contains(string(data), `"statsFormat": "table {{.Name}}"`))
+}
+
+func TestLoadLayersInvalidSystemConfig(t *testing.T) {
+	setupConfigDir(t)
+	systemDir := fs.NewDir(t, t.Name(), fs.WithFile("cli-config.json", `{"psFormat": `))
+	oldSystemConfigFile := systemConfigFile
+	systemConfigFile = systemDir.Join("cli-config.json")
+	defer func() { systemConfigFile = oldSystemConfigFile }()
+
+	configFile, warnings, err := LoadLayers("config.json", nil)
+	assert.NilError(t, err)
+	assert.Check(t, configFile.Layers == nil)
+	assert.Assert(t, is.Len(warnings, 1))
+	assert.Check(t, is.Contains(warnings[0], "ignoring system configuration file "+systemConfigFile))
+}
+
+func TestLoadDefaultConfigFileSchemaWarnings(t *testing.T) {
+	dir := setupConfigDir(t)
+	filename := filepath.Join(dir, ConfigFileName)
+	assert.NilError(t, os.WriteFile(filename, []byte(`{"psFormt": "{{.ID}}", "imagesFormat": "{{.ID"}`), 0o600))
+
+	buffer := new(bytes.Buffer)
+	configFile := LoadDefaultConfigFile(buffer)
+	assert.Check(t, is.Equal(configFile.ImagesFormat, "{{.ID"))
+	assert.Check(t, is.Equal(buffer.String(), ""+
+		"WARNING: "+filename+": imagesFormat: invalid format: template: :1: unclosed action\n"+
+		"WARNING: "+filename+`: unknown property "psFormt", did you mean "psFormat"?`+"\n"))
+
+	buffer.Reset()
+	_, err := LoadDefaultConfigFileStrict(buffer)
+	assert.Check(t, is.Error(err, "invalid configuration:\n"+
+		filename+": imagesFormat: invalid format: template: :1: unclosed action\n"+
+		filename+`: unknown property "psFormt", did you mean "psFormat"?`))
+	assert.Check(t, is.Equal(buffer.String(), ""))
+
+	assert.NilError(t, os.WriteFile(filename, []byte(`{"psFormat": "{{.ID}}"}`), 0o600))
+	configFile, err = LoadDefaultConfigFileStrict(buffer)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(configFile.PsFormat, "{{.ID}}"))
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
 	"github.com/harness-community/docker-cli-v23/cli/config/types"
+	"github.com/harness-community/docker-cli-v23/internal/lockfile"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
@@ -42,6 +43,8 @@ type ConfigFile struct {
 	CLIPluginsExtraDirs  []string                     `json:"cliPluginsExtraDirs,omitempty"`
 	Plugins              map[string]map[string]string `json:"plugins,omitempty"`
 	Aliases              map[string]string            `json:"aliases,omitempty"`
+	Formats              map[string]map[string]string `json:"formats,omitempty"`
+	Layers               *Layers                      `json:"-"` // Note: for internal use only; see NewLayered
 }
 
 // ProxyConfig contains proxy configuration settings
@@ -128,6 +131,12 @@ func (configFile *ConfigFile) SaveToWriter(writer io.Writer) error {
 	if err != nil {
 		return err
 	}
+	if configFile.Layers != nil {
+		// Only write the values that belong to the user layer.
+		if data, err = configFile.Layers.userLayerData(data); err != nil {
+			return err
+		}
+	}
 	_, err = writer.Write(data)
 	return err
 }
@@ -142,6 +151,21 @@ func (configFile *ConfigFile) Save() (retErr error) {
 	if err := os.MkdirAll(dir, 0o700); err != nil {
 		return err
 	}
+
+	// Handle situation where the configfile is a symlink
+	cfgFile := configFile.Filename
+	if f, err := os.Readlink(cfgFile); err == nil {
+		cfgFile = f
+	}
+
+	// Serialize concurrent writers, so that the rename below never races
+	// with another process replacing the same file.
+	lock, err := lockfile.Lock(cfgFile + ".lock")
+	if err != nil {
+		return errors.Wrap(err, "failed to lock config file")
+	}
+	defer lock.Unlock()
+
 	temp, err := os.CreateTemp(dir, filepath.Base(configFile.Filename))
 	if err != nil {
 		return err
@@ -164,12 +188,6 @@ func (configFile *ConfigFile) Save() (retErr error) {
 		return errors.Wrap(err, "error closing temp file")
 	}
 
-	// Handle situation where the configfile is a symlink
-	cfgFile := configFile.Filename
-	if f, err := os.Readlink(cfgFile); err == nil {
-		cfgFile = f
-	}
-
 	// Try copying the current config file (if any) ownership and permissions
 	copyFilePermissions(cfgFile, temp.Name())
 	return os.Rename(temp.Name(), cfgFile)
@@ -251,10 +269,21 @@ func decodeAuth(authStr string) (string, string, error) {
 // GetCredentialsStore returns a new credentials store from the settings in the
 // configuration file
 func (configFile *ConfigFile) GetCredentialsStore(registryHostname string) credentials.Store {
-	if helper := getConfiguredCredentialStore(configFile, registryHostname); helper != "" {
+	switch helper := getConfiguredCredentialStore(configFile, registryHostname); helper {
+	case "":
+		return credentials.NewFileStore(configFile)
+	case credentials.EncryptedFileStoreName:
+		return credentials.NewEncryptedFileStore(configFile)
+	default:
 		return newNativeStore(configFile, helper)
 	}
-	return credentials.NewFileStore(configFile)
+}
+
+// GetCredentialsStoreName returns the name of the credentials store, or
+// credential helper, used for the given registry, or an empty string if
+// credentials are stored in the configuration file.
+func (configFile *ConfigFile) GetCredentialsStoreName(registryHostname string) string {
+	return getConfiguredCredentialStore(configFile, registryHostname)
 }
 
 // var for unit testing.
@@ -267,14 +296,69 @@ func (configFile *ConfigFile) GetAuthConfig(registryHostname string) (types.Auth
 	return configFile.GetCredentialsStore(registryHostname).Get(registryHostname)
 }
 
+// GetRepositoryAuthConfig returns the credentials for the given repository,
+// such as "registry.example.com/team-a/app". Credentials can be scoped to the
+// repositories under a path of a registry, such as "registry.example.com/team-a",
+// in which case the credentials of the longest path the repository is under,
+// as found in "auths" or "credHelpers", are used. The credentials for
+// serverAddress (the hostname of the registry, or the address of the index
+// server for Docker Hub) are used otherwise.
+func (configFile *ConfigFile) GetRepositoryAuthConfig(repository, serverAddress string) (types.AuthConfig, error) {
+	if scope := configFile.repositoryScope(repository); scope != "" {
+		authConfig, err := configFile.GetAuthConfig(scope)
+		if err != nil || credentials.HasSecrets(authConfig) {
+			return authConfig, err
+		}
+	}
+	return configFile.GetAuthConfig(serverAddress)
+}
+
+// repositoryScope returns the longest path, among the keys of "auths" and
+// "credHelpers", that the given repository is under, or an empty string if
+// no credentials are scoped to a path of the repository.
+func (configFile *ConfigFile) repositoryScope(repository string) string {
+	var scope string
+	match := func(k string) {
+		if credentials.IsRepositoryScope(k) && isUnderScope(repository, k) && len(k) > len(scope) {
+			scope = k
+		}
+	}
+	for k := range configFile.AuthConfigs {
+		match(k)
+	}
+	for k := range configFile.CredentialHelpers {
+		match(k)
+	}
+	return scope
+}
+
+// isUnderScope returns true if name equals scope, or is a path under scope.
+func isUnderScope(name, scope string) bool {
+	scope = strings.TrimSuffix(scope, "/")
+	return name == scope || strings.HasPrefix(name, scope+"/")
+}
+
 // getConfiguredCredentialStore returns the credential helper configured for the
 // given registry, the default credsStore, or the empty string if neither are
-// configured.
+// configured. For credentials scoped to a path of a registry (see
+// GetRepositoryAuthConfig), the helper configured for the longest matching
+// path, or for the registry, is returned.
 func getConfiguredCredentialStore(c *ConfigFile, registryHostname string) string {
 	if c.CredentialHelpers != nil && registryHostname != "" {
 		if helper, exists := c.CredentialHelpers[registryHostname]; exists {
 			return helper
 		}
+		if credentials.IsRepositoryScope(registryHostname) {
+			var match string
+			for k := range c.CredentialHelpers {
+				if isUnderScope(registryHostname, k) && len(k) > len(match) {
+					match = k
+				}
+			}
+			if match != "" {
+				return c.CredentialHelpers[match]
+			}
+		}
 	}
 	return c.CredentialsStore
 }
@@ -326,6 +410,16 @@ func (configFile *ConfigFile) PluginConfig(pluginname, option string) (string, b
 	return value, ok
 }
 
+// FormatPreset retrieves the format preset with the given name for the given
+// command, such as "ps" or "images".
+func (configFile *ConfigFile) FormatPreset(command, name string) (string, bool) {
+	if configFile.Formats == nil {
+		return "", false
+	}
+	format, ok := configFile.Formats[command][name]
+	return format, ok
+}
+
 // SetPluginConfig sets the option to the given value for the given
 // plugin. Passing a value of "" will remove the option. If removing
 // the final config item for a given plugin then also cleans up the
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/harness-community/docker-cli-v23/cli/config/credentials"
@@ -322,6 +325,60 @@ func TestGetAllCredentialsCredHelper(t *testing.T) {
 	assert.Check(t, is.Equal(0, testCredHelper.(*mockNativeStore).GetAllCallCount))
 }
 
+func TestGetRepositoryAuthConfig(t *testing.T) {
+	configFile := New("filename")
+	configFile.AuthConfigs = map[string]types.AuthConfig{
+		"registry.example.com":              {Username: "registry_user", Password: "pass"},
+		"registry.example.com/team-a":       {Username: "team_a_user", Password: "pass"},
+		"registry.example.com/team-a/app-b": {Username: "app_b_user", Password: "pass"},
+		"registry.example.com/team-c":       {Email: "placeholder@example.com"},
+	}
+
+	testCases := []struct {
+		repository       string
+		expectedUsername string
+	}{
+		{repository: "registry.example.com/other/app", expectedUsername: "registry_user"},
+		{repository: "registry.example.com/team-a/app", expectedUsername: "team_a_user"},
+		{repository: "registry.example.com/team-a/app-b", expectedUsername: "app_b_user"},
+		{repository: "registry.example.com/team-a/app-b/sub", expectedUsername: "app_b_user"},
+		{repository: "registry.example.com/team-a/app-bc", expectedUsername: "team_a_user"},
+		{repository: "registry.example.com/team-ab/app", expectedUsername: "registry_user"},
+		// scopes without credentials fall back to the registry
+		{repository: "registry.example.com/team-c/app", expectedUsername: "registry_user"},
+	}
+	for _, tc := range testCases {
+		authConfig, err := configFile.GetRepositoryAuthConfig(tc.repository, "registry.example.com")
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(authConfig.Username, tc.expectedUsername), tc.repository)
+	}
+}
+
+func TestGetRepositoryAuthConfigCredHelper(t *testing.T) {
+	configFile := New("filename")
+	configFile.CredentialHelpers = map[string]string{
+		"registry.example.com/team-a": "team-a-helper",
+	}
+	teamAHelper := NewMockNativeStore(map[string]types.AuthConfig{
+		"registry.example.com/team-a": {Username: "team_a_user", Password: "pass"},
+	}, nil)
+
+	tmpNewNativeStore := newNativeStore
+	defer func() { newNativeStore = tmpNewNativeStore }()
+	newNativeStore = func(configFile *ConfigFile, helperSuffix string) credentials.Store {
+		assert.Check(t, is.Equal(helperSuffix, "team-a-helper"))
+		return teamAHelper
+	}
+
+	authConfig, err := configFile.GetRepositoryAuthConfig("registry.example.com/team-a/app", "registry.example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(authConfig.Username, "team_a_user"))
+
+	assert.Check(t, is.Equal(getConfiguredCredentialStore(configFile, "registry.example.com/team-a/app"), "team-a-helper"))
+	assert.Check(t, is.Equal(getConfiguredCredentialStore(configFile, "registry.example.com/team-b"), ""))
+	assert.Check(t, is.Equal(getConfiguredCredentialStore(configFile, "registry.example.com"), ""))
+}
+
 func TestGetAllCredentialsFileStoreAndCredHelper(t *testing.T) {
 	const (
 		testFileStoreRegistryHostname  = "example.com"
@@ -484,6 +541,7 @@ func TestLoadFromReaderWithUsernamePassword(t *testing.T) {
 func TestSave(t *testing.T) {
 	configFile := New("test-save")
 	defer os.Remove("test-save")
+	defer os.Remove("test-save.lock")
 	err := configFile.Save()
 	assert.NilError(t, err)
 	cfg, err := os.ReadFile("test-save")
@@ -496,6 +554,7 @@ func TestSave(t *testing.T) {
 func TestSaveCustomHTTPHeaders(t *testing.T) {
 	configFile := New(t.Name())
 	defer os.Remove(t.Name())
+	defer os.Remove(t.Name() + ".lock")
 	configFile.HTTPHeaders["CUSTOM-HEADER"] = "custom-value"
 	configFile.HTTPHeaders["User-Agent"] = "user-agent 1"
 	configFile.HTTPHeaders["user-agent"] = "user-agent 2"
@@ -538,9 +597,50 @@ func TestSaveWithSymlink(t *testing.T) {
 	assert.Check(t, is.Equal(string(cfg), "{\n	\"auths\": {}\n}"))
 }
 
+func TestSaveConcurrent(t *testing.T) {
+	dir := fs.NewDir(t, t.Name())
+	defer dir.Remove()
+	cfgFile := dir.Join("config.json")
+
+	const workers = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			configFile := New(cfgFile)
+			configFile.PsFormat = fmt.Sprintf("format-%d", i)
+			errs <- configFile.Save()
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NilError(t, err)
+	}
+
+	// The result must be one of the written files in its entirety, and no
+	// temporary files must be left behind.
+	data, err := os.ReadFile(cfgFile)
+	assert.NilError(t, err)
+	var cfg ConfigFile
+	assert.NilError(t, json.Unmarshal(data, &cfg))
+	assert.Check(t, strings.HasPrefix(cfg.PsFormat, "format-"))
+
+	entries, err := os.ReadDir(dir.Path())
+	assert.NilError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.Check(t, is.DeepEqual(names, []string{"config.json", "config.json.lock"}))
+}
+
 func TestPluginConfig(t *testing.T) {
 	configFile := New("test-plugin")
 	defer os.Remove("test-plugin")
+	defer os.Remove("test-plugin.lock")
 
 	// Populate some initial values
 	configFile.SetPluginConfig("plugin1", "data1", "some string")
@@ -560,6 +660,7 @@ func TestPluginConfig(t *testing.T) {
 	// preserved through a load/save cycle.
 	configFile = New("test-plugin2")
 	defer os.Remove("test-plugin2")
+	defer os.Remove("test-plugin2.lock")
 	assert.NilError(t, configFile.LoadFromReader(bytes.NewReader(cfg)))
 	err = configFile.Save()
 	assert.NilError(t, err)
@@ -602,3 +703,28 @@ func TestPluginConfig(t *testing.T) {
 	assert.NilError(t, err)
 	golden.Assert(t, string(cfg), "plugin-config-2.golden")
 }
+
+func TestGetAllCredentialsEncryptedFileStore(t *testing.T) {
+	t.Setenv(credentials.EnvCredentialsKeyFile, "")
+	t.Setenv(credentials.EnvCredentialsPassphrase, "passphrase")
+	dir := fs.NewDir(t, t.Name())
+	configFile := New(dir.Join("config.json"))
+	configFile.CredentialsStore = credentials.EncryptedFileStoreName
+	exampleAuth := types.AuthConfig{
+		Username:      "user",
+		Password:      "pass",
+		ServerAddress: "example.com",
+	}
+	configFile.AuthConfigs["example.com"] = exampleAuth
+
+	authConfigs, err := configFile.GetAllCredentials()
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(map[string]types.AuthConfig{"example.com": exampleAuth}, authConfigs))
+
+	// plain text credentials are moved to the encrypted file
+	data, err := os.ReadFile(dir.Join("config.json"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Contains(string(data), `"example.com": {}`))
+	_, err = os.Stat(dir.Join("credentials.enc"))
+	assert.Check(t, err)
+}
@@ -0,0 +1,377 @@
+package configfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/types"
+	"github.com/pkg/errors"
+)
+
+// Names of the layers of a layered configuration, from lowest to highest
+// precedence.
+const (
+	LayerSystem  = "system"
+	LayerUser    = "user"
+	LayerProject = "project"
+)
+
+// mergeDepth defines how the value of each property is merged with the value
+// of the same property in lower layers. Properties that are not listed here
+// (psFormat, detachKeys, credsStore, pruneFilters, cliPluginsExtraDirs, ...)
+// are replaced as a whole. Maps are merged key by key, up to the given depth:
+//
+//   - auths, HttpHeaders, credHelpers, aliases: per key.
+//   - proxies: per daemon; the proxy configuration of a daemon is replaced as
+//     a whole.
+//   - plugins: per plugin, then per option.
+//   - formats: per command, then per preset.
+var mergeDepth = map[string]int{
+	"auths":       1,
+	"HttpHeaders": 1,
+	"credHelpers": 1,
+	"proxies":     1,
+	"aliases":     1,
+	"plugins":     2,
+	"formats":     2,
+}
+
+// projectProperties are the properties that can be set in the project layer.
+// As project configuration files are picked up from the working directory,
+// they are not trusted to set properties that affect credentials, network
+// traffic, or which binaries are executed. For the same reason, shell aliases
+// (starting with "!") cannot be set in the project layer.
+var projectProperties = map[string]struct{}{
+	"psFormat":             {},
+	"imagesFormat":         {},
+	"networksFormat":       {},
+	"pluginsFormat":        {},
+	"volumesFormat":        {},
+	"statsFormat":          {},
+	"serviceInspectFormat": {},
+	"servicesFormat":       {},
+	"tasksFormat":          {},
+	"secretFormat":         {},
+	"configFormat":         {},
+	"nodesFormat":          {},
+	"detachKeys":           {},
+	"pruneFilters":         {},
+	"currentContext":       {},
+	"aliases":              {},
+	"formats":              {},
+}
+
+// Layer is a configuration file that takes part in a layered configuration.
+type Layer struct {
+	// Name is the name of the layer (LayerSystem, LayerUser, or LayerProject).
+	Name string
+	// Filename is the path of the configuration file of the layer.
+	Filename string
+	// Data is the content of the configuration file. It is empty if the
+	// file does not exist.
+	Data []byte
+}
+
+// Origin describes where a configuration value comes from.
+type Origin struct {
+	Layer    string
+	Filename string
+}
+
+func (o Origin) String() string {
+	return o.Layer + ":" + o.Filename
+}
+
+type layer struct {
+	Origin
+	data map[string]interface{}
+}
+
+// Layers holds the layers a layered configuration was loaded from.
+type Layers struct {
+	lower   []layer
+	user    layer
+	upper   []layer
+	origins map[string]Origin
+}
+
+// NewLayered returns the configuration obtained by merging the given layers,
+// ordered from lowest to highest precedence, according to the merge rules of
+// each property. Exactly one of the layers must be the user layer: its
+// filename is the filename of the configuration, and it is the only layer
+// that is written when saving the configuration.
+//
+// Properties of the project layer that cannot be set at the project level are
+// ignored, and reported as warnings.
+func NewLayered(configLayers []Layer) (*ConfigFile, []string, error) {
+	var (
+		l        Layers
+		warnings []string
+		hasUser  bool
+	)
+	for _, cl := range configLayers {
+		data, err := decodeLayer(cl)
+		if err != nil {
+			return nil, warnings, err
+		}
+		if cl.Name == LayerProject {
+			for _, k := range sortedKeys(data) {
+				if _, ok := projectProperties[k]; !ok {
+					warnings = append(warnings, fmt.Sprintf("%q cannot be set in project configuration file %s, and is ignored", k, cl.Filename))
+					delete(data, k)
+				}
+			}
+			if aliases, ok := data["aliases"].(map[string]interface{}); ok {
+				for _, k := range sortedKeys(aliases) {
+					if v, _ := aliases[k].(string); strings.HasPrefix(v, "!") {
+						warnings = append(warnings, fmt.Sprintf("shell alias %q cannot be set in project configuration file %s, and is ignored", k, cl.Filename))
+						delete(aliases, k)
+					}
+				}
+			}
+		}
+		ly := layer{Origin: Origin{Layer: cl.Name, Filename: cl.Filename}, data: data}
+		switch {
+		case cl.Name == LayerUser:
+			if hasUser {
+				return nil, warnings, errors.New("a layered configuration can only have one user layer")
+			}
+			hasUser = true
+			l.user = ly
+		case hasUser:
+			l.upper = append(l.upper, ly)
+		default:
+			l.lower = append(l.lower, ly)
+		}
+	}
+	if !hasUser {
+		return nil, warnings, errors.New("a layered configuration must have a user layer")
+	}
+
+	merged := map[string]interface{}{}
+	l.origins = map[string]Origin{}
+	for _, ly := range l.all() {
+		mergeLayer(merged, ly.data, ly.Origin, l.origins)
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, warnings, err
+	}
+	configFile := New(l.user.Filename)
+	if err := configFile.LoadFromReader(bytes.NewReader(data)); err != nil {
+		return nil, warnings, err
+	}
+	if len(l.lower) > 0 || len(l.upper) > 0 {
+		configFile.Layers = &l
+	}
+	return configFile, warnings, nil
+}
+
+// decodeLayer decodes the content of a layer, making sure it is a valid
+// configuration file on its own.
+func decodeLayer(cl Layer) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	if len(bytes.TrimSpace(cl.Data)) == 0 {
+		return data, nil
+	}
+	if err := New(cl.Filename).LoadFromReader(bytes.NewReader(cl.Data)); err != nil {
+		return nil, errors.Wrap(err, cl.Filename)
+	}
+	if err := json.Unmarshal(cl.Data, &data); err != nil {
+		return nil, errors.Wrap(err, cl.Filename)
+	}
+	return data, nil
+}
+
+func (l *Layers) all() []layer {
+	all := make([]layer, 0, len(l.lower)+len(l.upper)+1)
+	all = append(all, l.lower...)
+	all = append(all, l.user)
+	return append(all, l.upper...)
+}
+
+func mergeLayer(dst, src map[string]interface{}, origin Origin, origins map[string]Origin) {
+	for k, v := range src {
+		mergeValue(dst, k, v, mergeDepth[k], k, origin, origins)
+	}
+}
+
+func mergeValue(dst map[string]interface{}, key string, value interface{}, depth int, path string, origin Origin, origins map[string]Origin) {
+	src, isMap := value.(map[string]interface{})
+	if depth == 0 || !isMap {
+		for p := range origins {
+			if strings.HasPrefix(p, path+".") {
+				delete(origins, p)
+			}
+		}
+		dst[key] = value
+		origins[path] = origin
+		return
+	}
+	sub, ok := dst[key].(map[string]interface{})
+	if !ok {
+		sub = map[string]interface{}{}
+		dst[key] = sub
+		delete(origins, path)
+	}
+	for k, v := range src {
+		mergeValue(sub, k, v, depth-1, path+"."+k, origin, origins)
+	}
+}
+
+// Origins returns where each value of a layered configuration comes from,
+// keyed by the path of the value. Paths of values of properties that are
+// merged key by key include the key, for example "aliases.builder" or
+// "plugins.myplugin.option".
+func (configFile *ConfigFile) Origins() map[string]Origin {
+	if configFile.Layers != nil {
+		return configFile.Layers.origins
+	}
+
+	// All values come from the configuration file itself.
+	var buf bytes.Buffer
+	if err := configFile.SaveToWriter(&buf); err != nil {
+		return nil
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &data); err != nil {
+		return nil
+	}
+	origins := map[string]Origin{}
+	mergeLayer(map[string]interface{}{}, data, Origin{Layer: LayerUser, Filename: configFile.Filename}, origins)
+	return origins
+}
+
+// Sources returns the origins of the layers that set the value at the given
+// path, ordered from lowest to highest precedence. Layers setting a parent or
+// a child of the path, such as "proxies.default" for the path
+// "proxies.default.httpProxy", are included.
+func (configFile *ConfigFile) Sources(path string) []Origin {
+	if configFile.Layers == nil {
+		if origin, ok := lookupOrigin(configFile.Origins(), path); ok {
+			return []Origin{origin}
+		}
+		return nil
+	}
+	var sources []Origin
+	for _, ly := range configFile.Layers.all() {
+		origins := map[string]Origin{}
+		mergeLayer(map[string]interface{}{}, ly.data, ly.Origin, origins)
+		if origin, ok := lookupOrigin(origins, path); ok {
+			sources = append(sources, origin)
+		}
+	}
+	return sources
+}
+
+func lookupOrigin(origins map[string]Origin, path string) (Origin, bool) {
+	for p, origin := range origins {
+		if p == path || strings.HasPrefix(path, p+".") || strings.HasPrefix(p, path+".") {
+			return origin, true
+		}
+	}
+	return Origin{}, false
+}
+
+// userLayerData returns the content to write to the user layer of a layered
+// configuration, given the full content of the configuration. Values that
+// are inherited from lower layers, or shadowed by upper layers, are only
+// written if they were changed.
+func (l *Layers) userLayerData(data []byte) ([]byte, error) {
+	var current map[string]interface{}
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, err
+	}
+	lower := map[string]interface{}{}
+	upper := map[string]interface{}{}
+	discard := map[string]Origin{}
+	for _, ly := range l.lower {
+		mergeLayer(lower, ly.data, ly.Origin, discard)
+	}
+	for _, ly := range l.upper {
+		mergeLayer(upper, ly.data, ly.Origin, discard)
+	}
+
+	user := reconcile(current, lower, upper, l.user.data, -1)
+	l.user.data = user
+	data, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode into a ConfigFile, so that properties are written in the usual
+	// order.
+	var cfg ConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AuthConfigs == nil {
+		cfg.AuthConfigs = map[string]types.AuthConfig{}
+	}
+	return json.MarshalIndent(&cfg, "", "\t")
+}
+
+// reconcile returns the values to write to the user layer for the given
+// current, lower, upper and original user values of a map. A negative depth
+// is used for the top-level map, for which the depth of each property is
+// looked up in mergeDepth.
+func reconcile(current, lower, upper, user map[string]interface{}, depth int) map[string]interface{} {
+	result := map[string]interface{}{}
+	keys := map[string]struct{}{}
+	for _, m := range []map[string]interface{}{current, user} {
+		for k := range m {
+			keys[k] = struct{}{}
+		}
+	}
+	for k := range keys {
+		d := depth
+		if depth < 0 {
+			d = mergeDepth[k]
+		}
+		cur, inCurrent := current[k]
+		orig, inUser := user[k]
+		if d > 0 {
+			curMap, ok1 := cur.(map[string]interface{})
+			if ok1 {
+				lowerMap, _ := lower[k].(map[string]interface{})
+				upperMap, _ := upper[k].(map[string]interface{})
+				origMap, _ := orig.(map[string]interface{})
+				if sub := reconcile(curMap, lowerMap, upperMap, origMap, d-1); len(sub) > 0 || inUser {
+					result[k] = sub
+				}
+				continue
+			}
+		}
+		if upperValue, shadowed := upper[k]; shadowed {
+			// Values shadowed by upper layers are only written if they
+			// were explicitly changed.
+			if inCurrent && !reflect.DeepEqual(cur, upperValue) {
+				result[k] = cur
+			} else if inUser {
+				result[k] = orig
+			}
+			continue
+		}
+		if !inCurrent {
+			continue
+		}
+		if lowerValue, inLower := lower[k]; inLower && !inUser && reflect.DeepEqual(cur, lowerValue) {
+			continue
+		}
+		result[k] = cur
+	}
+	return result
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,188 @@
+package configfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+const (
+	testSystemConfig = `{
+	"psFormat": "table {{.ID}}",
+	"detachKeys": "ctrl-x",
+	"aliases": {"builder": "buildx"},
+	"proxies": {"default": {"httpProxy": "http://system:3128", "noProxy": "localhost"}},
+	"plugins": {"myplugin": {"a": "system", "b": "system"}},
+	"formats": {"ps": {"ports": "{{.Ports}}", "mem": "{{.Size}}"}},
+	"credHelpers": {"registry.example.com": "system-helper"}
+}`
+	testUserConfig = `{
+	"psFormat": "table {{.Names}}",
+	"proxies": {"default": {"httpsProxy": "https://user:3128"}},
+	"plugins": {"myplugin": {"b": "user"}}
+}`
+	testProjectConfig = `{
+	"imagesFormat": "table {{.Repository}}",
+	"aliases": {"compose": "stack", "clean": "!docker system prune -f"},
+	"formats": {"ps": {"mem": "{{.Names}}"}, "images": {"short": "{{.ID}}"}},
+	"credsStore": "evil",
+	"cliPluginsExtraDirs": ["./plugins"]
+}`
+)
+
+func testLayers(dir string) []Layer {
+	return []Layer{
+		{Name: LayerSystem, Filename: "/etc/docker/cli-config.json", Data: []byte(testSystemConfig)},
+		{Name: LayerUser, Filename: filepath.Join(dir, "config.json"), Data: []byte(testUserConfig)},
+		{Name: LayerProject, Filename: "/project/.dockercli.json", Data: []byte(testProjectConfig)},
+	}
+}
+
+func TestNewLayered(t *testing.T) {
+	configFile, warnings, err := NewLayered(testLayers("/home/user/.docker"))
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(warnings, []string{
+		`"cliPluginsExtraDirs" cannot be set in project configuration file /project/.dockercli.json, and is ignored`,
+		`"credsStore" cannot be set in project configuration file /project/.dockercli.json, and is ignored`,
+		`shell alias "clean" cannot be set in project configuration file /project/.dockercli.json, and is ignored`,
+	}))
+
+	assert.Check(t, is.Equal(configFile.Filename, "/home/user/.docker/config.json"))
+	assert.Check(t, is.Equal(configFile.PsFormat, "table {{.Names}}"))
+	assert.Check(t, is.Equal(configFile.ImagesFormat, "table {{.Repository}}"))
+	assert.Check(t, is.Equal(configFile.DetachKeys, "ctrl-x"))
+	assert.Check(t, is.Equal(configFile.CredentialsStore, ""))
+	assert.Check(t, is.Len(configFile.CLIPluginsExtraDirs, 0))
+	assert.Check(t, is.DeepEqual(configFile.Aliases, map[string]string{"builder": "buildx", "compose": "stack"}))
+	assert.Check(t, is.DeepEqual(configFile.Proxies, map[string]ProxyConfig{"default": {HTTPSProxy: "https://user:3128"}}))
+	assert.Check(t, is.DeepEqual(configFile.Plugins, map[string]map[string]string{"myplugin": {"a": "system", "b": "user"}}))
+	assert.Check(t, is.DeepEqual(configFile.CredentialHelpers, map[string]string{"registry.example.com": "system-helper"}))
+	assert.Check(t, is.DeepEqual(configFile.Formats, map[string]map[string]string{
+		"ps":     {"ports": "{{.Ports}}", "mem": "{{.Names}}"},
+		"images": {"short": "{{.ID}}"},
+	}))
+
+	system := Origin{Layer: LayerSystem, Filename: "/etc/docker/cli-config.json"}
+	user := Origin{Layer: LayerUser, Filename: "/home/user/.docker/config.json"}
+	project := Origin{Layer: LayerProject, Filename: "/project/.dockercli.json"}
+	assert.Check(t, is.DeepEqual(configFile.Origins(), map[string]Origin{
+		"psFormat":                         user,
+		"imagesFormat":                     project,
+		"detachKeys":                       system,
+		"aliases.builder":                  system,
+		"aliases.compose":                  project,
+		"proxies.default":                  user,
+		"plugins.myplugin.a":               system,
+		"plugins.myplugin.b":               user,
+		"formats.ps.ports":                 system,
+		"formats.ps.mem":                   project,
+		"formats.images.short":             project,
+		"credHelpers.registry.example.com": system,
+	}))
+
+	assert.Check(t, is.DeepEqual(configFile.Sources("psFormat"), []Origin{system, user}))
+	assert.Check(t, is.DeepEqual(configFile.Sources("proxies.default.httpProxy"), []Origin{system, user}))
+	assert.Check(t, is.DeepEqual(configFile.Sources("aliases"), []Origin{system, project}))
+	assert.Check(t, is.DeepEqual(configFile.Sources("plugins.myplugin.a"), []Origin{system}))
+	assert.Check(t, is.Len(configFile.Sources("nodesFormat"), 0))
+}
+
+func TestNewLayeredErrors(t *testing.T) {
+	_, _, err := NewLayered([]Layer{{Name: LayerSystem, Filename: "system.json"}})
+	assert.Check(t, is.Error(err, "a layered configuration must have a user layer"))
+
+	_, _, err = NewLayered([]Layer{{Name: LayerUser, Filename: "config.json", Data: []byte(`{"aliases": 1}`)}})
+	assert.Check(t, is.ErrorContains(err, "config.json: json: cannot unmarshal"))
+}
+
+func TestNewLayeredUserOnly(t *testing.T) {
+	configFile, warnings, err := NewLayered([]Layer{{Name: LayerUser, Filename: "config.json", Data: []byte(testUserConfig)}})
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(warnings, 0))
+	assert.Check(t, configFile.Layers == nil)
+
+	user := Origin{Layer: LayerUser, Filename: "config.json"}
+	assert.Check(t, is.DeepEqual(configFile.Origins(), map[string]Origin{
+		"psFormat":           user,
+		"proxies.default":    user,
+		"plugins.myplugin.b": user,
+	}))
+}
+
+func TestLayeredSave(t *testing.T) {
+	dir := t.TempDir()
+	configFile, _, err := NewLayered(testLayers(dir))
+	assert.NilError(t, err)
+
+	// change a value of the user layer, override a value of the system
+	// layer, and add new values.
+	configFile.PsFormat = "table {{.ID}}\t{{.Names}}"
+	configFile.Plugins["myplugin"]["a"] = "user"
+	configFile.Aliases["image"] = "images"
+	configFile.CurrentContext = "remote"
+	// values shadowed by the project layer are only written if changed.
+	configFile.ImagesFormat = "table {{.ID}}"
+	assert.NilError(t, configFile.Save())
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), `{
+	"auths": {},
+	"psFormat": "table {{.ID}}\t{{.Names}}",
+	"imagesFormat": "table {{.ID}}",
+	"proxies": {
+		"default": {
+			"httpsProxy": "https://user:3128"
+		}
+	},
+	"currentContext": "remote",
+	"plugins": {
+		"myplugin": {
+			"a": "user",
+			"b": "user"
+		}
+	},
+	"aliases": {
+		"image": "images"
+	}
+}`))
+}
+
+func TestLayeredSaveShadowedValues(t *testing.T) {
+	dir := t.TempDir()
+	layers := []Layer{
+		{Name: LayerUser, Filename: filepath.Join(dir, "config.json"), Data: []byte(`{"psFormat": "table {{.ID}}"}`)},
+		{Name: LayerProject, Filename: "/project/.dockercli.json", Data: []byte(`{"psFormat": "table {{.Names}}", "imagesFormat": "table {{.Repository}}"}`)},
+	}
+	readUserLayer := func() string {
+		data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+		assert.NilError(t, err)
+		return string(data)
+	}
+
+	// unchanged values shadowed by the project layer are not written, and the
+	// values of the user layer they shadow are kept.
+	configFile, _, err := NewLayered(layers)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(configFile.PsFormat, "table {{.Names}}"))
+	assert.NilError(t, configFile.Save())
+	assert.Check(t, is.Equal(readUserLayer(), `{
+	"auths": {},
+	"psFormat": "table {{.ID}}"
+}`))
+
+	// changed values are written, even though they are shadowed.
+	configFile, _, err = NewLayered(layers)
+	assert.NilError(t, err)
+	configFile.PsFormat = "table {{.Image}}"
+	configFile.ImagesFormat = "table {{.ID}}"
+	assert.NilError(t, configFile.Save())
+	assert.Check(t, is.Equal(readUserLayer(), `{
+	"auths": {},
+	"psFormat": "table {{.Image}}",
+	"imagesFormat": "table {{.ID}}"
+}`))
+}
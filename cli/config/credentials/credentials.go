@@ -15,3 +15,9 @@ type Store interface {
 	// Store saves credentials in the store.
 	Store(authConfig types.AuthConfig) error
 }
+
+// HasSecrets returns true if authConfig holds any credentials, as opposed to
+// only the address of the server or an email address.
+func HasSecrets(authConfig types.AuthConfig) bool {
+	return authConfig.Username != "" || authConfig.Password != "" || authConfig.Auth != "" || authConfig.IdentityToken != "" || authConfig.RegistryToken != ""
+}
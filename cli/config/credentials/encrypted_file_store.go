@@ -0,0 +1,267 @@
+package credentials
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/types"
+	"github.com/harness-community/docker-cli-v23/internal/lockfile"
+	"github.com/harness-community/docker-cli-v23/internal/secretbox"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// EncryptedFileStoreName is the name of the built-in credentials store
+	// that keeps credentials encrypted in a file. Set it as "credsStore",
+	// or as the helper of a registry in "credHelpers", to use it.
+	EncryptedFileStoreName = "encrypted-file"
+
+	// EnvCredentialsKeyFile is the name of the environment variable that
+	// points to a file holding the secret used to encrypt credentials in the
+	// encrypted file store.
+	EnvCredentialsKeyFile = "DOCKER_CREDENTIALS_KEYFILE"
+	// EnvCredentialsPassphrase is the name of the environment variable that
+	// holds a passphrase used to encrypt credentials in the encrypted file
+	// store. It is ignored if EnvCredentialsKeyFile is set.
+	EnvCredentialsPassphrase = "DOCKER_CREDENTIALS_PASSPHRASE"
+
+	// encryptedCredentialsFileName is the name of the file, next to the
+	// configuration file, the encrypted file store keeps credentials in.
+	encryptedCredentialsFileName = "credentials.enc"
+)
+
+// encryptedCredentialsHeader prefixes the encrypted credentials file.
+var encryptedCredentialsHeader = []byte("DOCKER-CREDENTIALS-AES256GCM-V1\n")
+
+// encryptedFileStore implements a credentials store that keeps credentials
+// encrypted in a file next to the docker configuration file, using a secret
+// read from the key file or passphrase set in the environment.
+//
+// Credentials stored in plain text in the configuration file are moved to
+// the encrypted file when they are accessed through this store.
+type encryptedFileStore struct {
+	file      store
+	fileStore Store
+	filename  string
+}
+
+// NewEncryptedFileStore creates a new credentials store that keeps
+// credentials encrypted in a file next to the docker configuration file.
+func NewEncryptedFileStore(file store) Store {
+	return &encryptedFileStore{
+		file:      file,
+		fileStore: NewFileStore(file),
+		filename:  filepath.Join(filepath.Dir(file.GetFilename()), encryptedCredentialsFileName),
+	}
+}
+
+// Erase removes the given credentials from the encrypted file store.
+func (c *encryptedFileStore) Erase(serverAddress string) error {
+	err := c.update(func(auths map[string]types.AuthConfig) bool {
+		if _, ok := auths[serverAddress]; !ok {
+			return false
+		}
+		delete(auths, serverAddress)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	// Also remove the entry from the configuration file.
+	return c.fileStore.Erase(serverAddress)
+}
+
+// Get retrieves credentials for a specific server from the encrypted file
+// store.
+func (c *encryptedFileStore) Get(serverAddress string) (types.AuthConfig, error) {
+	if err := c.migrate(func(addr string) bool {
+		return addr == serverAddress || (!IsRepositoryScope(addr) && ConvertToHostname(addr) == serverAddress)
+	}); err != nil {
+		return types.AuthConfig{}, err
+	}
+	auths, err := c.read()
+	if err != nil {
+		return types.AuthConfig{}, err
+	}
+	if authConfig, ok := auths[serverAddress]; ok {
+		return authConfig, nil
+	}
+	// Maybe the credentials were stored with a legacy address.
+	for r, ac := range auths {
+		if !IsRepositoryScope(r) && serverAddress == ConvertToHostname(r) {
+			return ac, nil
+		}
+	}
+	return types.AuthConfig{}, nil
+}
+
+// GetAll retrieves all the credentials from the encrypted file store.
+func (c *encryptedFileStore) GetAll() (map[string]types.AuthConfig, error) {
+	if err := c.migrate(func(string) bool { return true }); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// Store saves the given credentials in the encrypted file store.
+func (c *encryptedFileStore) Store(authConfig types.AuthConfig) error {
+	err := c.update(func(auths map[string]types.AuthConfig) bool {
+		auths[authConfig.ServerAddress] = authConfig
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	// Keep an entry without credentials in the configuration file, as done
+	// for external credentials stores.
+	return c.fileStore.Store(types.AuthConfig{
+		ServerAddress: authConfig.ServerAddress,
+		Email:         authConfig.Email,
+	})
+}
+
+// migrate moves the credentials stored in plain text in the configuration
+// file for the servers matching match to the encrypted file.
+func (c *encryptedFileStore) migrate(match func(serverAddress string) bool) error {
+	plain := map[string]types.AuthConfig{}
+	for addr, ac := range c.file.GetAuthConfigs() {
+		if HasSecrets(ac) && match(addr) {
+			if ac.ServerAddress == "" {
+				ac.ServerAddress = addr
+			}
+			plain[addr] = ac
+		}
+	}
+	if len(plain) == 0 {
+		return nil
+	}
+	err := c.update(func(auths map[string]types.AuthConfig) bool {
+		for addr, ac := range plain {
+			auths[addr] = ac
+		}
+		return true
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to move credentials to the encrypted credentials file")
+	}
+	for addr, ac := range plain {
+		c.file.GetAuthConfigs()[addr] = types.AuthConfig{ServerAddress: ac.ServerAddress, Email: ac.Email}
+		logrus.Debugf("moved credentials for %s to %s", addr, c.filename)
+	}
+	return c.file.Save()
+}
+
+// read returns the credentials in the encrypted file, or an empty map if the
+// file does not exist.
+func (c *encryptedFileStore) read() (map[string]types.AuthConfig, error) {
+	data, err := os.ReadFile(c.filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]types.AuthConfig{}, nil
+		}
+		return nil, err
+	}
+	secret, err := credentialsSecret()
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := decryptCredentials(secret, data)
+	if err != nil {
+		return nil, errors.Wrap(err, c.filename)
+	}
+	auths := map[string]types.AuthConfig{}
+	if err := json.Unmarshal(plaintext, &auths); err != nil {
+		return nil, errors.Wrapf(err, "invalid encrypted credentials file %s", c.filename)
+	}
+	return auths, nil
+}
+
+// update applies fn to the credentials in the encrypted file, and writes
+// them back if fn returns true. Concurrent updates are serialized.
+func (c *encryptedFileStore) update(fn func(auths map[string]types.AuthConfig) bool) (retErr error) {
+	dir := filepath.Dir(c.filename)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	lock, err := lockfile.Lock(c.filename + ".lock")
+	if err != nil {
+		return errors.Wrap(err, "failed to lock encrypted credentials file")
+	}
+	defer lock.Unlock()
+
+	auths, err := c.read()
+	if err != nil {
+		return err
+	}
+	if !fn(auths) {
+		return nil
+	}
+	plaintext, err := json.Marshal(auths)
+	if err != nil {
+		return err
+	}
+
+	secret, err := credentialsSecret()
+	if err != nil {
+		return err
+	}
+	data, err := encryptCredentials(secret, plaintext)
+	if err != nil {
+		return err
+	}
+
+	temp, err := os.CreateTemp(dir, encryptedCredentialsFileName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		temp.Close()
+		if retErr != nil {
+			if err := os.Remove(temp.Name()); err != nil {
+				logrus.WithError(err).WithField("file", temp.Name()).Debug("Error cleaning up temp file")
+			}
+		}
+	}()
+	if _, err := temp.Write(data); err != nil {
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return errors.Wrap(err, "error closing temp file")
+	}
+	return os.Rename(temp.Name(), c.filename)
+}
+
+// credentialsSecret returns the secret read from the key file, or the
+// passphrase, set in the environment.
+func credentialsSecret() ([]byte, error) {
+	if keyFile := os.Getenv(EnvCredentialsKeyFile); keyFile != "" {
+		key, err := secretbox.ReadKeyFile(keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read credentials encryption key file")
+		}
+		return key, nil
+	}
+	if passphrase := os.Getenv(EnvCredentialsPassphrase); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	return nil, errors.Errorf("the %s credentials store requires an encryption key: set %s or %s", EncryptedFileStoreName, EnvCredentialsKeyFile, EnvCredentialsPassphrase)
+}
+
+// encryptCredentials encrypts data using a key derived from secret.
+func encryptCredentials(secret, data []byte) ([]byte, error) {
+	return secretbox.Seal(encryptedCredentialsHeader, secret, data)
+}
+
+// decryptCredentials decrypts data produced by encryptCredentials.
+func decryptCredentials(secret, data []byte) ([]byte, error) {
+	if !secretbox.IsSealed(encryptedCredentialsHeader, data) {
+		return nil, errors.New("not an encrypted credentials file")
+	}
+	plain, err := secretbox.Open(encryptedCredentialsHeader, secret, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt credentials")
+	}
+	return plain, nil
+}
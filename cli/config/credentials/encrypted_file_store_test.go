@@ -0,0 +1,159 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/types"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/fs"
+)
+
+type fakeConfigFile struct {
+	fakeStore
+	filename string
+	saved    int
+}
+
+func (f *fakeConfigFile) Save() error {
+	f.saved++
+	return nil
+}
+
+func (f *fakeConfigFile) GetFilename() string {
+	return f.filename
+}
+
+func newEncryptedTestStore(t *testing.T, auths map[string]types.AuthConfig) (*fakeConfigFile, Store) {
+	t.Helper()
+	dir := fs.NewDir(t, t.Name())
+	f := &fakeConfigFile{
+		fakeStore: fakeStore{configs: auths},
+		filename:  dir.Join("config.json"),
+	}
+	return f, NewEncryptedFileStore(f)
+}
+
+func TestEncryptedFileStore(t *testing.T) {
+	t.Setenv(EnvCredentialsKeyFile, "")
+	t.Setenv(EnvCredentialsPassphrase, "passphrase")
+	f, s := newEncryptedTestStore(t, map[string]types.AuthConfig{})
+
+	auth := types.AuthConfig{
+		Username:      "user",
+		Password:      "s3cr3t",
+		Email:         "foo@example.com",
+		ServerAddress: "https://example.com",
+	}
+	assert.NilError(t, s.Store(auth))
+	assert.Check(t, is.DeepEqual(f.GetAuthConfigs(), map[string]types.AuthConfig{
+		"https://example.com": {Email: "foo@example.com", ServerAddress: "https://example.com"},
+	}))
+
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(f.filename), "credentials.enc"))
+	assert.NilError(t, err)
+	assert.Check(t, !strings.Contains(string(data), "s3cr3t"))
+
+	// use a new store, which has to decrypt the file
+	s = NewEncryptedFileStore(f)
+	actual, err := s.Get("https://example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, auth))
+
+	actual, err = s.Get("example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, auth))
+
+	actual, err = s.Get("https://unknown.example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, types.AuthConfig{}))
+
+	all, err := s.GetAll()
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(all, map[string]types.AuthConfig{"https://example.com": auth}))
+
+	assert.NilError(t, s.Erase("https://example.com"))
+	assert.Check(t, is.Len(f.GetAuthConfigs(), 0))
+	all, err = s.GetAll()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(all, 0))
+}
+
+func TestEncryptedFileStoreWrongKey(t *testing.T) {
+	t.Setenv(EnvCredentialsKeyFile, "")
+	t.Setenv(EnvCredentialsPassphrase, "passphrase")
+	f, s := newEncryptedTestStore(t, map[string]types.AuthConfig{})
+	assert.NilError(t, s.Store(types.AuthConfig{Username: "user", Password: "s3cr3t", ServerAddress: "example.com"}))
+
+	t.Setenv(EnvCredentialsPassphrase, "other")
+	_, err := NewEncryptedFileStore(f).Get("example.com")
+	assert.Check(t, is.ErrorContains(err, "failed to decrypt credentials: invalid key or corrupted data"))
+}
+
+func TestEncryptedFileStoreKeyFile(t *testing.T) {
+	keyFile := fs.NewFile(t, "key", fs.WithContent("s3cr3t-key\n"))
+	t.Setenv(EnvCredentialsKeyFile, keyFile.Path())
+	t.Setenv(EnvCredentialsPassphrase, "ignored")
+	f, s := newEncryptedTestStore(t, map[string]types.AuthConfig{})
+	auth := types.AuthConfig{Username: "user", Password: "s3cr3t", ServerAddress: "example.com"}
+	assert.NilError(t, s.Store(auth))
+
+	// the key file takes precedence over the passphrase
+	t.Setenv(EnvCredentialsPassphrase, "")
+	actual, err := NewEncryptedFileStore(f).Get("example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(actual, auth))
+
+	t.Setenv(EnvCredentialsKeyFile, filepath.Join(t.TempDir(), "missing"))
+	_, err = NewEncryptedFileStore(f).Get("example.com")
+	assert.Check(t, is.ErrorContains(err, "failed to read credentials encryption key file"))
+}
+
+func TestEncryptedFileStoreNoKey(t *testing.T) {
+	t.Setenv(EnvCredentialsKeyFile, "")
+	t.Setenv(EnvCredentialsPassphrase, "")
+	_, s := newEncryptedTestStore(t, map[string]types.AuthConfig{})
+
+	// no key is needed as long as there are no credentials
+	_, err := s.GetAll()
+	assert.NilError(t, err)
+
+	err = s.Store(types.AuthConfig{Username: "user", Password: "s3cr3t", ServerAddress: "example.com"})
+	assert.Check(t, is.Error(err, "the encrypted-file credentials store requires an encryption key: set DOCKER_CREDENTIALS_KEYFILE or DOCKER_CREDENTIALS_PASSPHRASE"))
+}
+
+func TestEncryptedFileStoreMigrate(t *testing.T) {
+	t.Setenv(EnvCredentialsKeyFile, "")
+	t.Setenv(EnvCredentialsPassphrase, "passphrase")
+	f, s := newEncryptedTestStore(t, map[string]types.AuthConfig{
+		"https://example.com":  {Username: "user", Password: "pass", Email: "foo@example.com", ServerAddress: "https://example.com"},
+		"registry.example.com": {Username: "other", Password: "pass2", ServerAddress: "registry.example.com"},
+		"empty.example.com":    {ServerAddress: "empty.example.com"},
+	})
+
+	// only the requested credentials are moved by Get
+	actual, err := s.Get("example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(actual.Username, "user"))
+	assert.Check(t, is.Equal(f.saved, 1))
+	assert.Check(t, is.DeepEqual(f.GetAuthConfigs()["https://example.com"], types.AuthConfig{Email: "foo@example.com", ServerAddress: "https://example.com"}))
+	assert.Check(t, is.Equal(f.GetAuthConfigs()["registry.example.com"].Password, "pass2"))
+
+	// all credentials are moved by GetAll
+	all, err := s.GetAll()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(all, 2))
+	assert.Check(t, is.Equal(all["registry.example.com"].Password, "pass2"))
+	assert.Check(t, is.Equal(f.saved, 2))
+	for addr, ac := range f.GetAuthConfigs() {
+		assert.Check(t, !HasSecrets(ac), addr)
+	}
+
+	// nothing left to move
+	_, err = s.GetAll()
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(f.saved, 2))
+}
@@ -36,7 +36,7 @@ func (c *fileStore) Get(serverAddress string) (types.AuthConfig, error) {
 		// Maybe they have a legacy config file, we will iterate the keys converting
 		// them to the new format and testing
 		for r, ac := range c.file.GetAuthConfigs() {
-			if serverAddress == ConvertToHostname(r) {
+			if !IsRepositoryScope(r) && serverAddress == ConvertToHostname(r) {
 				return ac, nil
 			}
 		}
@@ -78,3 +78,17 @@ func ConvertToHostname(url string) string {
 	hostName, _, _ := strings.Cut(stripped, "/")
 	return hostName
 }
+
+// IsRepositoryScope returns true if serverAddress is the hostname of a
+// registry followed by a path, such as "registry.example.com/team-a", which
+// scopes credentials to the repositories under that path. Addresses that
+// include a scheme, such as "https://index.docker.io/v1/", or that end with
+// the "/v1/" or "/v2/" API path, such as "registry.example.com/v2/", are
+// not scoped, for backward compatibility.
+func IsRepositoryScope(serverAddress string) bool {
+	if strings.Contains(serverAddress, "://") {
+		return false
+	}
+	_, path, ok := strings.Cut(strings.TrimSuffix(serverAddress, "/"), "/")
+	return ok && path != "v1" && path != "v2"
+}
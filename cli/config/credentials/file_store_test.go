@@ -68,6 +68,42 @@ func TestFileStoreGet(t *testing.T) {
 	}
 }
 
+func TestFileStoreGetSkipsScopedCredentials(t *testing.T) {
+	f := newStore(map[string]types.AuthConfig{
+		"registry.example.com/team-a": {
+			Auth:          "team_a_token",
+			ServerAddress: "registry.example.com/team-a",
+		},
+	})
+
+	s := NewFileStore(f)
+	a, err := s.Get("registry.example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(a, types.AuthConfig{}))
+
+	a, err = s.Get("registry.example.com/team-a")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(a.Auth, "team_a_token"))
+}
+
+func TestIsRepositoryScope(t *testing.T) {
+	for _, addr := range []string{"registry.example.com/team-a", "registry.example.com/team-a/", "localhost:5000/team-a/app"} {
+		assert.Check(t, IsRepositoryScope(addr), addr)
+	}
+	for _, addr := range []string{"registry.example.com", "registry.example.com/", "https://index.docker.io/v1/", "http://registry.example.com/team-a", "registry.example.com/v1/", "registry.example.com/v2/", "registry.example.com/v2"} {
+		assert.Check(t, !IsRepositoryScope(addr), addr)
+	}
+}
+
+func TestFileStoreGetLegacyAPIPath(t *testing.T) {
+	f := newStore(map[string]types.AuthConfig{
+		"registry.example.com/v2/": {Auth: "legacy_token"},
+	})
+	a, err := NewFileStore(f).Get("registry.example.com")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(a.Auth, "legacy_token"))
+}
+
 func TestFileStoreGetAll(t *testing.T) {
 	s1 := "https://example.com"
 	s2 := "https://example2.example.com"
@@ -0,0 +1,324 @@
+// Package schema validates configuration files of the CLI against the JSON
+// schema of the configuration file.
+package schema
+
+import (
+	_ "embed" // for the configuration file schema
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"github.com/harness-community/docker-cli-v23/templates"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed data/config_schema.json
+var schemaData []byte
+
+var (
+	compileOnce sync.Once
+	compiled    *gojsonschema.Schema
+	compileErr  error
+	definition  map[string]interface{}
+)
+
+// predefinedFormats are the formats that can be used instead of a template.
+var predefinedFormats = []string{"table", "json", "raw", "pretty"}
+
+type templateFormatChecker struct{}
+
+func (checker templateFormatChecker) IsFormat(input interface{}) bool {
+	value, ok := input.(string)
+	if !ok {
+		return false
+	}
+	return ValidateFormat(value) == nil
+}
+
+func init() {
+	gojsonschema.FormatCheckers.Add("go-template", templateFormatChecker{})
+}
+
+// ValidateFormat checks that format is either a predefined format ("table",
+// "json", "raw", or "pretty"), or a valid Go template, optionally prefixed
+// with "table".
+func ValidateFormat(format string) error {
+	for _, f := range predefinedFormats {
+		if format == f {
+			return nil
+		}
+	}
+	_, err := templates.Parse(strings.TrimPrefix(format, "table"))
+	return err
+}
+
+func compile() (*gojsonschema.Schema, error) {
+	compileOnce.Do(func() {
+		if compileErr = json.Unmarshal(schemaData, &definition); compileErr != nil {
+			return
+		}
+		compiled, compileErr = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schemaData))
+	})
+	return compiled, compileErr
+}
+
+// Validate validates the content of a configuration file against the schema
+// of the configuration file, and returns the problems found, such as unknown
+// properties, values of the wrong type, or malformed format templates. An
+// error is returned if data is not valid JSON.
+//
+// Like when the configuration file is loaded, property names are matched
+// case-insensitively, so "psformat" is accepted as "psFormat". Keys of
+// mappings, such as the names of registries or commands, are case-sensitive.
+func Validate(data []byte) ([]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	s, err := compile()
+	if err != nil {
+		return nil, err
+	}
+	var config interface{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+	normalizeKeys(config, reflect.TypeOf(configfile.ConfigFile{}))
+	result, err := s.Validate(gojsonschema.NewGoLoader(config))
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for _, e := range result.Errors() {
+		problems = append(problems, describe(e))
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// normalizeKeys renames the properties of the objects in v that encoding/json
+// matches case-insensitively to a field of t, to the name of that field. A
+// property is left as-is if the object has an exact match for the field, or
+// multiple properties match it, as only one of them takes effect.
+func normalizeKeys(v interface{}, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for _, e := range v {
+				normalizeKeys(e, t.Elem())
+			}
+		}
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for _, e := range v {
+				normalizeKeys(e, t.Elem())
+			}
+		case reflect.Struct:
+			fields := jsonFields(t)
+			matches := map[string][]string{}
+			for key := range v {
+				if _, ok := fields[key]; ok {
+					matches[key] = append(matches[key], key)
+					continue
+				}
+				for name := range fields {
+					if strings.EqualFold(key, name) {
+						matches[name] = append(matches[name], key)
+						break
+					}
+				}
+			}
+			for name, keys := range matches {
+				if len(keys) == 1 && keys[0] != name {
+					v[name] = v[keys[0]]
+					delete(v, keys[0])
+				}
+			}
+			for name, ft := range fields {
+				if e, ok := v[name]; ok {
+					normalizeKeys(e, ft)
+				}
+			}
+		}
+	}
+}
+
+// jsonFields returns the types of the fields of struct type t, by the name
+// encoding/json uses for them.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-" || (!f.IsExported() && !f.Anonymous):
+			continue
+		case name == "" && f.Anonymous && f.Type.Kind() == reflect.Struct:
+			for n, ft := range jsonFields(f.Type) {
+				fields[n] = ft
+			}
+			continue
+		case name == "":
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+	return fields
+}
+
+// describe returns a description of a validation error.
+func describe(e gojsonschema.ResultError) string {
+	path := contextPath(e.Context())
+	switch e.Type() {
+	case "additional_property_not_allowed":
+		property, _ := e.Details()["property"].(string)
+		msg := fmt.Sprintf("unknown property %q", joinPath(append(path, property)))
+		if suggestion := suggest(property, knownProperties(path)); suggestion != "" {
+			msg += fmt.Sprintf(", did you mean %q?", joinPath(append(path, suggestion)))
+		}
+		return msg
+	case "format":
+		value, _ := e.Value().(string)
+		if err := ValidateFormat(value); err != nil {
+			return fmt.Sprintf("%s: invalid format: %v", joinPath(path), err)
+		}
+	case "invalid_type":
+		if expected, ok := e.Details()["expected"].(string); ok {
+			return fmt.Sprintf("%s: must be a %s", joinPath(path), humanReadableType(expected))
+		}
+	case "enum":
+		if allowed, ok := e.Details()["allowed"].(string); ok {
+			return fmt.Sprintf("%s: must be one of %s", joinPath(path), allowed)
+		}
+	}
+	return fmt.Sprintf("%s: %s", joinPath(path), e.Description())
+}
+
+func humanReadableType(t string) string {
+	switch t {
+	case "object":
+		return "mapping"
+	case "array":
+		return "list"
+	default:
+		return t
+	}
+}
+
+// contextSeparator is used to split the context of a validation error into
+// the keys it consists of, as keys (such as registry hostnames) can contain
+// dots.
+const contextSeparator = "\x00"
+
+// contextPath returns the keys leading to the value a validation error is
+// about.
+func contextPath(ctx *gojsonschema.JsonContext) []string {
+	if ctx == nil {
+		return nil
+	}
+	path := strings.Split(ctx.String(contextSeparator), contextSeparator)
+	// the first element is always "(root)"
+	return path[1:]
+}
+
+func joinPath(path []string) string {
+	if len(path) == 0 {
+		return "(root)"
+	}
+	return strings.Join(path, ".")
+}
+
+// knownProperties returns the properties the schema defines for the object at
+// the given path.
+func knownProperties(path []string) []string {
+	node := resolve(definition)
+	for _, k := range path {
+		if props, ok := node["properties"].(map[string]interface{}); ok {
+			if p, ok := props[k].(map[string]interface{}); ok {
+				node = resolve(p)
+				continue
+			}
+		}
+		additional, ok := node["additionalProperties"].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		node = resolve(additional)
+	}
+	props, _ := node["properties"].(map[string]interface{})
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resolve returns the definition a node of the schema refers to, if any.
+func resolve(node map[string]interface{}) map[string]interface{} {
+	ref, ok := node["$ref"].(string)
+	if !ok {
+		return node
+	}
+	definitions, _ := definition["definitions"].(map[string]interface{})
+	d, _ := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{})
+	return d
+}
+
+// suggest returns the known property that the given (unknown) property is
+// most likely a misspelling of, or an empty string if none is close enough.
+func suggest(property string, known []string) string {
+	var (
+		best     string
+		bestDist int
+	)
+	for _, k := range known {
+		if strings.EqualFold(k, property) {
+			return k
+		}
+		d := levenshtein(strings.ToLower(property), strings.ToLower(k))
+		if d <= 2 && d*2 < len(k) && (best == "" || d < bestDist) {
+			best, bestDist = k, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
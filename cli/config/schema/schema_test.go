@@ -0,0 +1,159 @@
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli/config/configfile"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		config   string
+		expected []string
+	}{
+		{
+			doc:    "empty file",
+			config: ``,
+		},
+		{
+			doc: "valid configuration",
+			config: `{
+				"auths": {"https://index.docker.io/v1/": {"auth": "dXNlcjpwYXNz"}},
+				"psFormat": "table {{.ID}}\t{{.Names}}",
+				"imagesFormat": "json",
+				"experimental": "enabled",
+				"proxies": {"default": {"httpProxy": "http://proxy"}},
+				"plugins": {"myplugin": {"option": "value"}},
+				"aliases": {"lsa": "ps -a"},
+				"formats": {"ps": {"ports": "table {{.Names}}\t{{.Ports}}"}}
+			}`,
+		},
+		{
+			doc:      "unknown property",
+			config:   `{"helicopters": "yes"}`,
+			expected: []string{`unknown property "helicopters"`},
+		},
+		{
+			doc:      "misspelled property",
+			config:   `{"psFormt": "{{.ID}}", "credStore": "pass"}`,
+			expected: []string{`unknown property "credStore", did you mean "credsStore"?`, `unknown property "psFormt", did you mean "psFormat"?`},
+		},
+		{
+			doc:    "property names are case-insensitive",
+			config: `{"psformat": "{{.ID}}", "CredsStore": "pass", "proxies": {"default": {"httpproxy": "http://proxy"}}}`,
+		},
+		{
+			doc:      "case-insensitive property is validated",
+			config:   `{"psformat": "{{.ID"}`,
+			expected: []string{`psFormat: invalid format: template: :1: unclosed action`},
+		},
+		{
+			doc:      "property with an exact and a case-insensitive match",
+			config:   `{"psFormat": "{{.ID}}", "psformat": "{{.Names}}"}`,
+			expected: []string{`unknown property "psformat", did you mean "psFormat"?`},
+		},
+		{
+			doc:      "keys of mappings are case-sensitive",
+			config:   `{"formats": {"PS": {"short": "{{.ID}}"}}}`,
+			expected: []string{`unknown property "formats.PS", did you mean "formats.ps"?`},
+		},
+		{
+			doc:      "misspelled nested property",
+			config:   `{"proxies": {"default": {"httpProxi": "http://proxy"}}}`,
+			expected: []string{`unknown property "proxies.default.httpProxi", did you mean "proxies.default.httpProxy"?`},
+		},
+		{
+			doc:      "misspelled property of an auth",
+			config:   `{"auths": {"registry.example.com": {"usrname": "user"}}}`,
+			expected: []string{`unknown property "auths.registry.example.com.usrname", did you mean "auths.registry.example.com.username"?`},
+		},
+		{
+			doc:      "misspelled command of a format preset",
+			config:   `{"formats": {"image": {"short": "{{.ID}}"}}}`,
+			expected: []string{`unknown property "formats.image", did you mean "formats.images"?`},
+		},
+		{
+			doc:      "malformed format preset",
+			config:   `{"formats": {"ps": {"ports": "{{.Ports"}}}`,
+			expected: []string{`formats.ps.ports: invalid format: template: :1: unclosed action`},
+		},
+		{
+			doc:      "malformed template",
+			config:   `{"psFormat": "table {{.ID}"}`,
+			expected: []string{`psFormat: invalid format: template: :1: bad character U+007D '}'`},
+		},
+		{
+			doc:      "wrong type",
+			config:   `{"pruneFilters": "label=foo", "aliases": {"lsa": 1}}`,
+			expected: []string{"aliases.lsa: must be a string", "pruneFilters: must be a list"},
+		},
+		{
+			doc:      "invalid value",
+			config:   `{"experimental": "yes"}`,
+			expected: []string{`experimental: must be one of "enabled", "disabled"`},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			problems, err := Validate([]byte(tc.config))
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(tc.expected, problems))
+		})
+	}
+}
+
+func TestValidateMalformedJSON(t *testing.T) {
+	_, err := Validate([]byte(`{"psFormat": `))
+	assert.Check(t, is.ErrorContains(err, "unexpected end of JSON input"))
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, format := range []string{"table", "json", "raw", "pretty", "{{.ID}}", "table {{.ID}}\t{{.Names}}"} {
+		assert.Check(t, ValidateFormat(format), format)
+	}
+	assert.Check(t, is.ErrorContains(ValidateFormat("{{.ID"), "unclosed action"))
+	assert.Check(t, is.ErrorContains(ValidateFormat("{{nosuchfunc .ID}}"), `function "nosuchfunc" not defined`))
+}
+
+// TestSchemaProperties checks that the schema has a definition for each
+// property of the configuration file.
+func TestSchemaProperties(t *testing.T) {
+	_, err := compile()
+	assert.NilError(t, err)
+
+	ct := reflect.TypeOf(configfile.ConfigFile{})
+	for i := 0; i < ct.NumField(); i++ {
+		name, _, _ := strings.Cut(ct.Field(i).Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		assert.Check(t, is.Contains(knownProperties(nil), name))
+		// each command with a default format can have format presets
+		if command := strings.TrimSuffix(name, "Format"); command != name {
+			assert.Check(t, is.Contains(knownProperties([]string{"formats"}), command))
+		}
+	}
+
+	var proxy map[string]string
+	data, err := json.Marshal(configfile.ProxyConfig{HTTPProxy: "a", HTTPSProxy: "a", NoProxy: "a", FTPProxy: "a", AllProxy: "a"})
+	assert.NilError(t, err)
+	assert.NilError(t, json.Unmarshal(data, &proxy))
+	for name := range proxy {
+		assert.Check(t, is.Contains(knownProperties([]string{"proxies", "default"}), name))
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	known := []string{"psFormat", "imagesFormat", "credsStore", "credHelpers"}
+	assert.Check(t, is.Equal(suggest("PSFORMAT", known), "psFormat"))
+	assert.Check(t, is.Equal(suggest("imageFormat", known), "imagesFormat"))
+	assert.Check(t, is.Equal(suggest("credHelper", known), "credHelpers"))
+	assert.Check(t, is.Equal(suggest("foo", known), ""))
+}
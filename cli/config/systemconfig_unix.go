@@ -0,0 +1,7 @@
+//go:build !windows
+// +build !windows
+
+package config
+
+// systemConfigFile is the path of the system-wide configuration file.
+var systemConfigFile = "/etc/docker/cli-config.json"
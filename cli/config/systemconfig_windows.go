@@ -0,0 +1,9 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// systemConfigFile is the path of the system-wide configuration file.
+var systemConfigFile = filepath.Join(os.Getenv("ProgramData"), "docker", "cli-config.json")
@@ -0,0 +1,96 @@
+package docker
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultFailoverTimeout is the default time to wait for each of an
+// endpoint's hosts to accept a connection, before trying the next one.
+const DefaultFailoverTimeout = 3 * time.Second
+
+// Default ports of the Docker daemon, used to probe tcp hosts that do not
+// specify a port.
+const (
+	defaultHTTPPort = 2375
+	defaultTLSPort  = 2376
+)
+
+// WithFailover returns a copy of the endpoint with Host set to the first of
+// its Hosts that accepts a connection within timeout. Hosts are tried in
+// order. If none of the hosts can be reached, the primary host is used, so
+// that errors are reported when connecting to it.
+//
+// Endpoints with a single host are returned as-is, without connecting.
+func WithFailover(ep Endpoint, timeout time.Duration) Endpoint {
+	if len(ep.Hosts) < 2 {
+		return ep
+	}
+	useTLS := ep.TLSData != nil || ep.SkipTLSVerify
+	for _, host := range ep.Hosts {
+		err := probeHost(host, useTLS, timeout)
+		if err == nil {
+			logrus.Debugf("using docker host %s", host)
+			ep.Host = host
+			return ep
+		}
+		logrus.Debugf("docker host %s is unreachable: %v", host, err)
+	}
+	ep.Host = ep.Hosts[0]
+	return ep
+}
+
+// probeHost checks that host accepts a connection. Hosts for which the
+// address cannot be determined, such as named pipes, are assumed to be
+// reachable.
+func probeHost(host string, useTLS bool, timeout time.Duration) error {
+	network, address, err := probeAddress(host, useTLS)
+	if err != nil || network == "" {
+		return err
+	}
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// probeAddress returns the network and address to dial to check that host
+// is reachable, applying the default port of the scheme if host has none.
+// An empty network is returned for hosts that cannot be checked.
+func probeAddress(host string, useTLS bool) (network, address string, err error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return "", "", err
+	}
+	switch u.Scheme {
+	case "tcp", "http", "https":
+		port := defaultHTTPPort
+		if useTLS || u.Scheme == "https" {
+			port = defaultTLSPort
+		}
+		return "tcp", hostWithDefaultPort(u, port), nil
+	case "ssh":
+		return "tcp", hostWithDefaultPort(u, 22), nil
+	case "unix":
+		return "unix", u.Path, nil
+	default:
+		return "", "", nil
+	}
+}
+
+func hostWithDefaultPort(u *url.URL, port int) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	hostname := u.Hostname()
+	if hostname == "" {
+		// same default as the daemon uses for "tcp://:PORT"
+		hostname = "127.0.0.1"
+	}
+	return net.JoinHostPort(hostname, strconv.Itoa(port))
+}
@@ -0,0 +1,109 @@
+package docker
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/harness-community/docker-cli-v23/cli/context"
+	"gotest.tools/v3/assert"
+)
+
+func TestWithFailover(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	defer l.Close()
+	up := "tcp://" + l.Addr().String()
+
+	// reserve an address that nothing is listening on
+	l2, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	down := "tcp://" + l2.Addr().String()
+	assert.NilError(t, l2.Close())
+
+	testCases := []struct {
+		doc      string
+		meta     EndpointMeta
+		expected string
+	}{
+		{
+			doc:      "single host is not checked",
+			meta:     EndpointMeta{Host: down},
+			expected: down,
+		},
+		{
+			doc:      "primary is reachable",
+			meta:     EndpointMeta{Host: up, Hosts: []string{up, down}},
+			expected: up,
+		},
+		{
+			doc:      "fail over to standby",
+			meta:     EndpointMeta{Host: down, Hosts: []string{down, up}},
+			expected: up,
+		},
+		{
+			doc:      "none reachable uses primary",
+			meta:     EndpointMeta{Host: down, Hosts: []string{down, "tcp://" + l2.Addr().String()}},
+			expected: down,
+		},
+		{
+			doc:      "unchecked hosts are assumed reachable",
+			meta:     EndpointMeta{Host: down, Hosts: []string{down, "npipe:////./pipe/docker_engine"}},
+			expected: "npipe:////./pipe/docker_engine",
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			ep := WithFailover(Endpoint{EndpointMeta: tc.meta}, time.Second)
+			assert.Equal(t, ep.Host, tc.expected)
+			assert.DeepEqual(t, ep.Hosts, tc.meta.Hosts)
+		})
+	}
+}
+
+func TestProbeAddress(t *testing.T) {
+	testCases := []struct {
+		host            string
+		useTLS          bool
+		network         string
+		expectedAddress string
+	}{
+		{host: "tcp://example.com", network: "tcp", expectedAddress: "example.com:2375"},
+		{host: "tcp://example.com", useTLS: true, network: "tcp", expectedAddress: "example.com:2376"},
+		{host: "tcp://example.com:1234", useTLS: true, network: "tcp", expectedAddress: "example.com:1234"},
+		{host: "tcp://", network: "tcp", expectedAddress: "127.0.0.1:2375"},
+		{host: "http://example.com", network: "tcp", expectedAddress: "example.com:2375"},
+		{host: "https://example.com", network: "tcp", expectedAddress: "example.com:2376"},
+		{host: "tcp://[::1]", network: "tcp", expectedAddress: "[::1]:2375"},
+		{host: "ssh://me@example.com", network: "tcp", expectedAddress: "example.com:22"},
+		{host: "unix:///var/run/docker.sock", network: "unix", expectedAddress: "/var/run/docker.sock"},
+		{host: "npipe:////./pipe/docker_engine"},
+	}
+	for _, tc := range testCases {
+		network, address, err := probeAddress(tc.host, tc.useTLS)
+		assert.NilError(t, err)
+		assert.Equal(t, network, tc.network, tc.host)
+		assert.Equal(t, address, tc.expectedAddress, tc.host)
+	}
+}
+
+func TestWithFailoverDefaultPort(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:2375")
+	if err != nil {
+		t.Skipf("default port is not available: %v", err)
+	}
+	defer l.Close()
+	if conn, err := net.Dial("tcp", "127.0.0.1:2376"); err == nil {
+		conn.Close()
+		t.Skip("default TLS port is in use")
+	}
+
+	// nothing listens on the TLS port, so the second host is used with TLS.
+	hosts := []string{"tcp://127.0.0.1", "tcp://127.0.0.1:2375"}
+	ep := WithFailover(Endpoint{EndpointMeta: EndpointMeta{Host: hosts[0], Hosts: hosts}}, time.Second)
+	assert.Equal(t, ep.Host, hosts[0])
+
+	ep = WithFailover(Endpoint{EndpointMeta: EndpointMeta{Host: hosts[0], Hosts: hosts}, TLSData: &context.TLSData{}}, time.Second)
+	assert.Equal(t, ep.Host, hosts[1])
+}
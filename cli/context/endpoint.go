@@ -2,6 +2,10 @@ package context
 
 // EndpointMetaBase contains fields we expect to be common for most context endpoints
 type EndpointMetaBase struct {
-	Host          string `json:",omitempty"`
+	Host string `json:",omitempty"`
+	// Hosts is the ordered list of hosts to try when connecting to the
+	// endpoint. It is only set if the endpoint has more than one host, in
+	// which case Host holds the first (primary) host.
+	Hosts         []string `json:",omitempty"`
 	SkipTLSVerify bool
 }
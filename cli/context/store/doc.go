@@ -27,6 +27,15 @@
 // multi-endpoints approach of this package allows to combine many different
 // endpoints in the same "context".
 //
+// TLS data can optionally be encrypted at rest (see Config.WithTLSEncryption),
+// using AES-256-GCM with a key derived from a passphrase or key file. Encrypted
+// files carry a header, so that plaintext files written before encryption was
+// enabled remain readable until they are migrated (see MigrateTLSMaterial).
+//
+// Writes to the store are serialized across processes using an advisory lock
+// on a ".lock" file at the root of the store, and files are replaced
+// atomically, so that readers never observe partially written files.
+//
 // Context IDs are actually SHA256 hashes of the context name, and are there
 // only to avoid dealing with special characters in context names.
 package store
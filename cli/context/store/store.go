@@ -14,12 +14,19 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/harness-community/docker-cli-v23/internal/lockfile"
 	"github.com/harness-community/docker-v23/errdefs"
 	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 )
 
-const restrictedNamePattern = "^[a-zA-Z0-9][a-zA-Z0-9_.+-]+$"
+const (
+	restrictedNamePattern = "^[a-zA-Z0-9][a-zA-Z0-9_.+-]+$"
+
+	// lockFileName is the name of the lock file, relative to the root of the
+	// store, that serializes writes from concurrent processes.
+	lockFileName = ".lock"
+)
 
 var restrictedNameRegEx = regexp.MustCompile(restrictedNamePattern)
 
@@ -38,6 +45,21 @@ type Reader interface {
 	GetTLSData(contextName, endpointName, fileName string) ([]byte, error)
 }
 
+// RawTLSReader is implemented by stores that can return TLS data in the form
+// it is persisted, which may be encrypted. It is used by Export so that
+// encrypted TLS material is never written out in plaintext.
+type RawTLSReader interface {
+	GetRawTLSData(contextName, endpointName, fileName string) ([]byte, error)
+}
+
+// TLSEncrypter is implemented by stores that can encrypt TLS material at
+// rest.
+type TLSEncrypter interface {
+	// TLSEncryptionEnabled returns true if TLS material is encrypted when it
+	// is written to the store.
+	TLSEncryptionEnabled() bool
+}
+
 // Lister provides listing of contexts
 type Lister interface {
 	List() ([]Metadata, error)
@@ -98,20 +120,34 @@ func New(dir string, cfg Config) *ContextStore {
 	tlsRoot := filepath.Join(dir, tlsDir)
 
 	return &ContextStore{
+		lockPath: filepath.Join(dir, lockFileName),
 		meta: &metadataStore{
 			root:   metaRoot,
 			config: cfg,
 		},
 		tls: &tlsStore{
 			root: tlsRoot,
+			key:  cfg.tlsKey,
 		},
 	}
 }
 
 // ContextStore implements Store.
 type ContextStore struct {
-	meta *metadataStore
-	tls  *tlsStore
+	lockPath string
+	meta     *metadataStore
+	tls      *tlsStore
+}
+
+// lock acquires the store-wide lock, which must be held by all operations
+// that modify the store, so that concurrent writers (in this process, or in
+// other processes) don't interleave their changes.
+func (s *ContextStore) lock() (*lockfile.LockFile, error) {
+	l, err := lockfile.Lock(s.lockPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lock context store")
+	}
+	return l, nil
 }
 
 // List return all contexts.
@@ -134,11 +170,21 @@ func Names(s Lister) ([]string, error) {
 
 // CreateOrUpdate creates or updates metadata for the context.
 func (s *ContextStore) CreateOrUpdate(meta Metadata) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
 	return s.meta.createOrUpdate(meta)
 }
 
 // Remove deletes the context with the given name, if found.
 func (s *ContextStore) Remove(name string) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
 	if err := s.meta.remove(name); err != nil {
 		return errors.Wrapf(err, "failed to remove context %s", name)
 	}
@@ -157,6 +203,11 @@ func (s *ContextStore) GetMetadata(name string) (Metadata, error) {
 // ResetTLSMaterial removes TLS data for all endpoints in the context and replaces
 // it with the new data.
 func (s *ContextStore) ResetTLSMaterial(name string, data *ContextTLSData) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
 	if err := s.tls.remove(name); err != nil {
 		return err
 	}
@@ -176,6 +227,11 @@ func (s *ContextStore) ResetTLSMaterial(name string, data *ContextTLSData) error
 // ResetEndpointTLSMaterial removes TLS data for the given context and endpoint,
 // and replaces it with the new data.
 func (s *ContextStore) ResetEndpointTLSMaterial(contextName string, endpointName string, data *EndpointTLSData) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
 	if err := s.tls.removeEndpoint(contextName, endpointName); err != nil {
 		return err
 	}
@@ -202,6 +258,44 @@ func (s *ContextStore) GetTLSData(contextName, endpointName, fileName string) ([
 	return s.tls.getData(contextName, endpointName, fileName)
 }
 
+// GetRawTLSData returns the content of the given fileName for an endpoint as
+// it is persisted in the store, without decrypting it.
+// It returns an errdefs.ErrNotFound if the file was not found.
+func (s *ContextStore) GetRawTLSData(contextName, endpointName, fileName string) ([]byte, error) {
+	return s.tls.getRawData(contextName, endpointName, fileName)
+}
+
+// TLSEncryptionEnabled returns true if TLS material is encrypted when it is
+// written to the store.
+func (s *ContextStore) TLSEncryptionEnabled() bool {
+	return s.tls.key != nil
+}
+
+// MigrateTLSMaterial rewrites the TLS material of the given context, so that
+// it is persisted according to the store's current configuration. It can be
+// used to encrypt TLS material that was written before TLS encryption was
+// enabled.
+func MigrateTLSMaterial(s ReaderWriter, name string) error {
+	tlsFiles, err := s.ListTLSFiles(name)
+	if err != nil {
+		return err
+	}
+	data := ContextTLSData{
+		Endpoints: make(map[string]EndpointTLSData, len(tlsFiles)),
+	}
+	for endpointName, endpointFiles := range tlsFiles {
+		files := make(map[string][]byte, len(endpointFiles))
+		for _, fileName := range endpointFiles {
+			files[fileName], err = s.GetTLSData(name, endpointName, fileName)
+			if err != nil {
+				return err
+			}
+		}
+		data.Endpoints[endpointName] = EndpointTLSData{Files: files}
+	}
+	return s.ResetTLSMaterial(name, &data)
+}
+
 // GetStorageInfo returns the paths where the Metadata and TLS data are stored
 // for the context.
 func (s *ContextStore) GetStorageInfo(contextName string) StorageInfo {
@@ -228,7 +322,14 @@ func ValidateContextName(name string) error {
 // Export exports an existing namespace into an opaque data stream
 // This stream is actually a tarball containing context metadata and TLS materials, but it does
 // not map 1:1 the layout of the context store (don't try to restore it manually without calling store.Import)
+//
+// If the store implements RawTLSReader, TLS materials are exported in the
+// form they are persisted, so that encrypted materials remain encrypted.
 func Export(name string, s Reader) io.ReadCloser {
+	getTLSData := s.GetTLSData
+	if r, ok := s.(RawTLSReader); ok {
+		getTLSData = r.GetRawTLSData
+	}
 	reader, writer := io.Pipe()
 	go func() {
 		tw := tar.NewWriter(writer)
@@ -281,7 +382,7 @@ func Export(name string, s Reader) io.ReadCloser {
 				return
 			}
 			for _, fileName := range endpointFiles {
-				data, err := s.GetTLSData(name, endpointName, fileName)
+				data, err := getTLSData(name, endpointName, fileName)
 				if err != nil {
 					writer.CloseWithError(err)
 					return
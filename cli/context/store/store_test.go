@@ -12,6 +12,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/harness-community/docker-v23/errdefs"
@@ -86,6 +87,61 @@ func TestExportImport(t *testing.T) {
 	assert.DeepEqual(t, file2, destData2)
 }
 
+func TestExportImportEncrypted(t *testing.T) {
+	dir := t.TempDir()
+	s := New(dir, testCfg.WithTLSEncryption(PassphraseKey("secret")))
+	err := s.CreateOrUpdate(
+		Metadata{
+			Endpoints: map[string]interface{}{
+				"ep1": endpoint{Foo: "bar"},
+			},
+			Metadata: context{Bar: "baz"},
+			Name:     "source",
+		})
+	assert.NilError(t, err)
+	assert.NilError(t, s.ResetEndpointTLSMaterial("source", "ep1", &EndpointTLSData{
+		Files: map[string][]byte{
+			"file1": []byte("test-data"),
+		},
+	}))
+
+	exported, err := io.ReadAll(Export("source", s))
+	assert.NilError(t, err)
+	assert.Check(t, !bytes.Contains(exported, []byte("test-data")))
+
+	assert.NilError(t, Import("dest", s, bytes.NewReader(exported)))
+	data, err := s.GetTLSData("dest", "ep1", "file1")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "test-data")
+
+	// importing into a store without the key fails
+	err = Import("dest2", New(t.TempDir(), testCfg), bytes.NewReader(exported))
+	assert.ErrorContains(t, err, "no encryption key is configured")
+}
+
+func TestMigrateTLSMaterial(t *testing.T) {
+	dir := t.TempDir()
+	plain := New(dir, testCfg)
+	err := plain.CreateOrUpdate(Metadata{Name: "source"})
+	assert.NilError(t, err)
+	assert.NilError(t, plain.ResetEndpointTLSMaterial("source", "ep1", &EndpointTLSData{
+		Files: map[string][]byte{
+			"file1": []byte("test-data"),
+		},
+	}))
+
+	encrypted := New(dir, testCfg.WithTLSEncryption(PassphraseKey("secret")))
+	assert.Check(t, !plain.TLSEncryptionEnabled())
+	assert.Check(t, encrypted.TLSEncryptionEnabled())
+	assert.NilError(t, MigrateTLSMaterial(encrypted, "source"))
+	raw, err := encrypted.GetRawTLSData("source", "ep1", "file1")
+	assert.NilError(t, err)
+	assert.Check(t, IsEncryptedTLSData(raw))
+	data, err := encrypted.GetTLSData("source", "ep1", "file1")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "test-data")
+}
+
 func TestRemove(t *testing.T) {
 	s := New(t.TempDir(), testCfg)
 	err := s.CreateOrUpdate(
@@ -110,6 +166,54 @@ func TestRemove(t *testing.T) {
 	assert.Equal(t, 0, len(f))
 }
 
+func TestConcurrentWrites(t *testing.T) {
+	s := New(t.TempDir(), testCfg)
+
+	const workers = 5
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*2)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- s.CreateOrUpdate(Metadata{
+				Endpoints: map[string]interface{}{
+					"ep1": endpoint{Foo: fmt.Sprintf("bar-%d", i)},
+				},
+				Metadata: context{Bar: fmt.Sprintf("baz-%d", i)},
+				Name:     "source",
+			})
+			errs <- s.ResetTLSMaterial("source", &ContextTLSData{
+				Endpoints: map[string]EndpointTLSData{
+					"ep1": {
+						Files: map[string][]byte{
+							"file1": []byte(fmt.Sprintf("data-%d", i)),
+							"file2": []byte(fmt.Sprintf("data-%d", i)),
+						},
+					},
+				},
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NilError(t, err)
+	}
+
+	meta, err := s.GetMetadata("source")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(meta.Name, "source"))
+
+	// Each reset replaces the TLS material as a whole, so both files must
+	// come from the same writer.
+	file1, err := s.GetTLSData("source", "ep1", "file1")
+	assert.NilError(t, err)
+	file2, err := s.GetTLSData("source", "ep1", "file2")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(file1), string(file2)))
+}
+
 func TestListEmptyStore(t *testing.T) {
 	result, err := New(t.TempDir(), testCfg).List()
 	assert.NilError(t, err)
@@ -23,6 +23,21 @@ func EndpointTypeGetter(name string, getter TypeGetter) NamedTypeGetter {
 type Config struct {
 	contextType   TypeGetter
 	endpointTypes map[string]TypeGetter
+	tlsKey        KeyProvider
+}
+
+// WithTLSEncryption returns a copy of the config that encrypts TLS material
+// at rest, using the secret returned by key. Existing plaintext TLS material
+// remains readable, and is encrypted when it is written again (see
+// MigrateTLSMaterial).
+func (c Config) WithTLSEncryption(key KeyProvider) Config {
+	c.tlsKey = key
+	return c
+}
+
+// TLSEncryptionEnabled returns true if TLS material is encrypted at rest.
+func (c Config) TLSEncryptionEnabled() bool {
+	return c.tlsKey != nil
 }
 
 // SetEndpoint set an endpoint typing information
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"github.com/harness-community/docker-cli-v23/internal/secretbox"
+	"github.com/pkg/errors"
+)
+
+// encryptedTLSHeader prefixes TLS files that are encrypted at rest. Files
+// without this header are treated as plaintext, so that stores created before
+// encryption was enabled remain readable.
+var encryptedTLSHeader = []byte("DOCKER-CONTEXT-TLS-AES256GCM-V1\n")
+
+// KeyProvider returns the secret used to encrypt and decrypt TLS material in
+// the context store. The secret is not used as-is; a per-file key is derived
+// from it using PBKDF2.
+type KeyProvider func() ([]byte, error)
+
+// PassphraseKey returns a KeyProvider for the given passphrase.
+func PassphraseKey(passphrase string) KeyProvider {
+	return func() ([]byte, error) {
+		if passphrase == "" {
+			return nil, errors.New("TLS encryption passphrase cannot be empty")
+		}
+		return []byte(passphrase), nil
+	}
+}
+
+// KeyFile returns a KeyProvider that reads the secret from the given file.
+// Leading and trailing whitespace in the file is ignored.
+func KeyFile(path string) KeyProvider {
+	return func() ([]byte, error) {
+		key, err := secretbox.ReadKeyFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read TLS encryption key file")
+		}
+		return key, nil
+	}
+}
+
+// IsEncryptedTLSData returns true if data is TLS material in encrypted form.
+func IsEncryptedTLSData(data []byte) bool {
+	return secretbox.IsSealed(encryptedTLSHeader, data)
+}
+
+// encryptTLSData encrypts data using a key derived from secret.
+func encryptTLSData(secret, data []byte) ([]byte, error) {
+	return secretbox.Seal(encryptedTLSHeader, secret, data)
+}
+
+// decryptTLSData decrypts data produced by encryptTLSData.
+func decryptTLSData(secret, data []byte) ([]byte, error) {
+	if !IsEncryptedTLSData(data) {
+		return nil, errors.New("TLS data is not encrypted")
+	}
+	plain, err := secretbox.Open(encryptedTLSHeader, secret, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt TLS data")
+	}
+	return plain, nil
+}
@@ -13,6 +13,7 @@ const tlsDir = "tls"
 
 type tlsStore struct {
 	root string
+	key  KeyProvider
 }
 
 func (s *tlsStore) contextDir(name string) string {
@@ -28,6 +29,10 @@ func (s *tlsStore) createOrUpdate(name, endpointName, filename string, data []by
 	if err := os.MkdirAll(parentOfRoot, 0o755); err != nil {
 		return err
 	}
+	data, err := s.encrypt(data)
+	if err != nil {
+		return errors.Wrapf(err, "failed to store TLS data for endpoint %s", endpointName)
+	}
 	endpointDir := s.endpointDir(name, endpointName)
 	if err := os.MkdirAll(endpointDir, 0o700); err != nil {
 		return err
@@ -35,7 +40,50 @@ func (s *tlsStore) createOrUpdate(name, endpointName, filename string, data []by
 	return ioutils.AtomicWriteFile(filepath.Join(endpointDir, filename), data, 0o600)
 }
 
+// encrypt returns data in the form it is persisted in the store. Data that
+// is already encrypted (for example, when importing an exported context) is
+// stored as-is, after verifying that it can be decrypted.
+func (s *tlsStore) encrypt(data []byte) ([]byte, error) {
+	if s.key == nil {
+		if IsEncryptedTLSData(data) {
+			return nil, errors.New("TLS data is encrypted, but no encryption key is configured")
+		}
+		return data, nil
+	}
+	secret, err := s.key()
+	if err != nil {
+		return nil, err
+	}
+	if IsEncryptedTLSData(data) {
+		if _, err := decryptTLSData(secret, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+	return encryptTLSData(secret, data)
+}
+
 func (s *tlsStore) getData(name, endpointName, filename string) ([]byte, error) {
+	data, err := s.getRawData(name, endpointName, filename)
+	if err != nil || !IsEncryptedTLSData(data) {
+		return data, err
+	}
+	if s.key == nil {
+		return nil, errors.Errorf("TLS data for endpoint %s is encrypted, but no encryption key is configured", endpointName)
+	}
+	secret, err := s.key()
+	if err != nil {
+		return nil, err
+	}
+	data, err = decryptTLSData(secret, data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read TLS data for endpoint %s", endpointName)
+	}
+	return data, nil
+}
+
+// getRawData returns the TLS data as it is persisted, without decrypting it.
+func (s *tlsStore) getRawData(name, endpointName, filename string) ([]byte, error) {
 	data, err := os.ReadFile(filepath.Join(s.endpointDir(name, endpointName), filename))
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -1,6 +1,9 @@
 package store
 
 import (
+	"bytes"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/harness-community/docker-v23/errdefs"
@@ -70,3 +73,58 @@ func TestTlsListAndBatchRemove(t *testing.T) {
 	assert.NilError(t, err)
 	assert.DeepEqual(t, resEmpty, map[string]EndpointFiles{})
 }
+
+func TestTlsEncrypted(t *testing.T) {
+	root := t.TempDir()
+	plain := tlsStore{root: root}
+	encrypted := tlsStore{root: root, key: PassphraseKey("secret")}
+
+	const contextName = "test-ctx"
+
+	// plaintext data written before encryption was enabled remains readable
+	assert.NilError(t, plain.createOrUpdate(contextName, "test-ep", "plain", []byte("data")))
+	data, err := encrypted.getData(contextName, "test-ep", "plain")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "data")
+
+	assert.NilError(t, encrypted.createOrUpdate(contextName, "test-ep", "test-data", []byte("data")))
+	raw, err := encrypted.getRawData(contextName, "test-ep", "test-data")
+	assert.NilError(t, err)
+	assert.Check(t, IsEncryptedTLSData(raw))
+	assert.Check(t, !bytes.Contains(raw, []byte("data")))
+	data, err = encrypted.getData(contextName, "test-ep", "test-data")
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "data")
+
+	_, err = plain.getData(contextName, "test-ep", "test-data")
+	assert.ErrorContains(t, err, "no encryption key is configured")
+
+	wrongKey := tlsStore{root: root, key: PassphraseKey("wrong")}
+	_, err = wrongKey.getData(contextName, "test-ep", "test-data")
+	assert.ErrorContains(t, err, "invalid key or corrupted data")
+
+	// already encrypted data is stored as-is, if it can be decrypted
+	assert.NilError(t, encrypted.createOrUpdate(contextName, "test-ep", "copy", raw))
+	copied, err := encrypted.getRawData(contextName, "test-ep", "copy")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, copied, raw)
+	err = wrongKey.createOrUpdate(contextName, "test-ep", "copy", raw)
+	assert.ErrorContains(t, err, "invalid key or corrupted data")
+	err = plain.createOrUpdate(contextName, "test-ep", "copy", raw)
+	assert.ErrorContains(t, err, "no encryption key is configured")
+}
+
+func TestTlsKeyFile(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key")
+	assert.NilError(t, os.WriteFile(keyFile, []byte("  secret\n"), 0o600))
+	key, err := KeyFile(keyFile)()
+	assert.NilError(t, err)
+	assert.Equal(t, string(key), "secret")
+
+	assert.NilError(t, os.WriteFile(keyFile, []byte("\n"), 0o600))
+	_, err = KeyFile(keyFile)()
+	assert.ErrorContains(t, err, "is empty")
+
+	_, err = KeyFile(filepath.Join(t.TempDir(), "missing"))()
+	assert.ErrorContains(t, err, "failed to read TLS encryption key file")
+}
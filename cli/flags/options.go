@@ -27,8 +27,30 @@ const (
 'table':            Print output in table format with column headers (default)
 'table TEMPLATE':   Print output in table format using the given Go template
 'json':             Print in JSON format
+'ndjson':           Print in newline-delimited JSON format
+'yaml':             Print in YAML format
+'csv':              Print in CSV format with all fields
+'csv TEMPLATE':     Print in CSV format using the given Go template
 'TEMPLATE':         Print output using the given Go template.
 Refer to https://docs.docker.com/go/formatting/ for more information about formatting output with templates`
+	// FormatPresetHelp describes the --format flag behavior for list commands
+	// that support format presets
+	FormatPresetHelp = `Format output using a custom template:
+'table':            Print output in table format with column headers (default)
+'table TEMPLATE':   Print output in table format using the given Go template
+'json':             Print in JSON format
+'ndjson':           Print in newline-delimited JSON format
+'yaml':             Print in YAML format
+'csv':              Print in CSV format with all fields
+'csv TEMPLATE':     Print in CSV format using the given Go template
+'@NAME':            Print output using the format preset NAME from the configuration file
+'help':             List the format presets from the configuration file
+'TEMPLATE':         Print output using the given Go template.
+Refer to https://docs.docker.com/go/formatting/ for more information about formatting output with templates`
+	// SortHelp describes the --sort flag behavior for list commands
+	SortHelp = `Sort output by the given comma-separated fields, each optionally followed by ":desc" (e.g. "size:desc,name")`
+	// WideHelp describes the --wide flag behavior for list commands
+	WideHelp = "Do not truncate table columns to fit the width of the terminal"
 	// InspectFormatHelp describes the --format flag behavior for inspect commands
 	InspectFormatHelp = `Format output using a custom template:
 'json':             Print in JSON format
@@ -45,14 +67,15 @@ var (
 
 // ClientOptions are the options used to configure the client cli.
 type ClientOptions struct {
-	Debug      bool
-	Hosts      []string
-	LogLevel   string
-	TLS        bool
-	TLSVerify  bool
-	TLSOptions *tlsconfig.Options
-	Context    string
-	ConfigDir  string
+	Debug        bool
+	Hosts        []string
+	LogLevel     string
+	TLS          bool
+	TLSVerify    bool
+	TLSOptions   *tlsconfig.Options
+	Context      string
+	ConfigDir    string
+	StrictConfig bool
 }
 
 // NewClientOptions returns a new ClientOptions.
@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	manifesttypes "github.com/harness-community/docker-cli-v23/cli/manifest/types"
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/reference"
+	"github.com/docker/distribution/registry/api/errcode"
 	distributionclient "github.com/docker/distribution/registry/client"
 	"github.com/harness-community/docker-v23/api/types"
 	registrytypes "github.com/harness-community/docker-v23/api/types/registry"
+	"github.com/harness-community/docker-v23/errdefs"
+	"github.com/harness-community/docker-v23/registry"
 	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -26,8 +30,28 @@ type RegistryClient interface {
 	PutManifest(ctx context.Context, ref reference.Named, manifest distribution.Manifest) (digest.Digest, error)
 }
 
+// CredentialsChecker is implemented by a RegistryClient that can check
+// credentials against a registry. It is not part of the RegistryClient
+// interface, so that existing implementations of RegistryClient do not have
+// to implement it.
+type CredentialsChecker interface {
+	CheckCredentials(ctx context.Context, serverAddress string, authConfig types.AuthConfig) error
+}
+
 // NewRegistryClient returns a new RegistryClient with a resolver
 func NewRegistryClient(resolver AuthConfigResolver, userAgent string, insecure bool) RegistryClient {
+	var repoResolver RepositoryAuthConfigResolver
+	if resolver != nil {
+		repoResolver = func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+			return resolver(ctx, repoInfo.Index)
+		}
+	}
+	return NewRepositoryRegistryClient(repoResolver, userAgent, insecure)
+}
+
+// NewRepositoryRegistryClient returns a new RegistryClient with a resolver
+// that can use credentials scoped to the repository.
+func NewRepositoryRegistryClient(resolver RepositoryAuthConfigResolver, userAgent string, insecure bool) RegistryClient {
 	return &client{
 		authConfigResolver: resolver,
 		insecureRegistry:   insecure,
@@ -38,6 +62,9 @@ func NewRegistryClient(resolver AuthConfigResolver, userAgent string, insecure b
 // AuthConfigResolver returns Auth Configuration for an index
 type AuthConfigResolver func(ctx context.Context, index *registrytypes.IndexInfo) types.AuthConfig
 
+// RepositoryAuthConfigResolver returns Auth Configuration for a repository
+type RepositoryAuthConfigResolver func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig
+
 // PutManifestOptions is the data sent to push a manifest
 type PutManifestOptions struct {
 	MediaType string
@@ -45,7 +72,7 @@ type PutManifestOptions struct {
 }
 
 type client struct {
-	authConfigResolver AuthConfigResolver
+	authConfigResolver RepositoryAuthConfigResolver
 	insecureRegistry   bool
 	userAgent          string
 }
@@ -70,7 +97,10 @@ func (err ErrHTTPProto) Error() string {
 	return err.OrigErr
 }
 
-var _ RegistryClient = &client{}
+var (
+	_ RegistryClient     = &client{}
+	_ CredentialsChecker = &client{}
+)
 
 // MountBlob into the registry, so it can be referenced by a manifest
 func (c *client) MountBlob(ctx context.Context, sourceRef reference.Canonical, targetRef reference.Named) error {
@@ -149,13 +179,79 @@ func (c *client) getRepositoryForReference(ctx context.Context, ref reference.Na
 
 func (c *client) getHTTPTransportForRepoEndpoint(ctx context.Context, repoEndpoint repositoryEndpoint) (http.RoundTripper, error) {
 	httpTransport, err := getHTTPTransport(
-		c.authConfigResolver(ctx, repoEndpoint.info.Index),
+		c.authConfigResolver(ctx, repoEndpoint.info),
 		repoEndpoint.endpoint,
 		repoEndpoint.Name(),
 		c.userAgent)
 	return httpTransport, errors.Wrap(err, "failed to configure transport")
 }
 
+// CheckCredentials checks that the registry at serverAddress accepts the given
+// credentials, by exchanging them for a token at the token endpoint of the
+// registry (or using them for basic authentication), and requesting the
+// registry's API version check endpoint with it.
+func (c *client) CheckCredentials(ctx context.Context, serverAddress string, authConfig types.AuthConfig) error {
+	endpoint, err := newDefaultRegistryEndpoint(serverAddress, c.insecureRegistry)
+	if err != nil {
+		return err
+	}
+	httpTransport, err := getHTTPTransport(authConfig, endpoint, "", c.userAgent)
+	if err != nil {
+		if !strings.Contains(err.Error(), "server gave HTTP response to HTTPS client") {
+			return err
+		}
+		if !endpoint.TLSConfig.InsecureSkipVerify {
+			return ErrHTTPProto{OrigErr: err.Error()}
+		}
+		// --insecure was set; fall back to plain HTTP
+		endpoint.URL.Scheme = "http"
+		httpTransport, err = getHTTPTransport(authConfig, endpoint, "", c.userAgent)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(endpoint.URL.String(), "/")+"/v2/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Transport: httpTransport}).Do(req)
+	if err != nil {
+		return translateAuthError(err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized:
+		return errdefs.Unauthorized(errors.New("unauthorized: invalid credentials"))
+	default:
+		return errors.Errorf("registry returned status: %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+}
+
+// translateAuthError returns an Unauthorized error if the token endpoint of
+// a registry rejected the credentials.
+func translateAuthError(err error) error {
+	urlErr, ok := err.(*url.Error)
+	if !ok {
+		return err
+	}
+	switch e := urlErr.Err.(type) {
+	case errcode.Error:
+		if e.Code == errcode.ErrorCodeUnauthorized {
+			return errdefs.Unauthorized(e)
+		}
+	case errcode.Errors:
+		if len(e) > 0 {
+			if e0, ok := e[0].(errcode.Error); ok && e0.Code == errcode.ErrorCodeUnauthorized {
+				return errdefs.Unauthorized(e0)
+			}
+		}
+	}
+	return err
+}
+
 // GetManifest returns an ImageManifest for the reference
 func (c *client) GetManifest(ctx context.Context, ref reference.Named) (manifesttypes.ImageManifest, error) {
 	var result manifesttypes.ImageManifest
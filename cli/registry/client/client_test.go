@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/harness-community/docker-v23/api/types"
+	"github.com/harness-community/docker-v23/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// the test server listens on 127.0.0.1, which is an insecure registry
+	serverAddress := server.Listener.Addr().String()
+	c := NewRegistryClient(nil, "test", false).(CredentialsChecker)
+
+	err := c.CheckCredentials(context.Background(), serverAddress, types.AuthConfig{Username: "user", Password: "pass"})
+	assert.Check(t, err)
+
+	err = c.CheckCredentials(context.Background(), serverAddress, types.AuthConfig{Username: "user", Password: "wrong"})
+	assert.Check(t, is.ErrorContains(err, "unauthorized"))
+	assert.Check(t, errdefs.IsUnauthorized(err))
+}
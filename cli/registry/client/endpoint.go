@@ -50,6 +50,28 @@ func newDefaultRepositoryEndpoint(ref reference.Named, insecure bool) (repositor
 }
 
 func getDefaultEndpointFromRepoInfo(repoInfo *registry.RepositoryInfo) (registry.APIEndpoint, error) {
+	return getDefaultEndpoint(reference.Domain(repoInfo.Name), repoInfo.Index.Secure)
+}
+
+// newDefaultRegistryEndpoint returns the endpoint of the registry at the given
+// server address, such as "registry.example.com", or the address of the index
+// server for Docker Hub.
+func newDefaultRegistryEndpoint(serverAddress string, insecure bool) (registry.APIEndpoint, error) {
+	index, err := registry.ParseSearchIndexInfo(registry.ConvertToHostname(serverAddress) + "/")
+	if err != nil {
+		return registry.APIEndpoint{}, err
+	}
+	endpoint, err := getDefaultEndpoint(index.Name, index.Secure)
+	if err != nil {
+		return registry.APIEndpoint{}, err
+	}
+	if insecure {
+		endpoint.TLSConfig.InsecureSkipVerify = true
+	}
+	return endpoint, nil
+}
+
+func getDefaultEndpoint(hostname string, secure bool) (registry.APIEndpoint, error) {
 	var err error
 
 	options := registry.ServiceOptions{}
@@ -57,13 +79,13 @@ func getDefaultEndpointFromRepoInfo(repoInfo *registry.RepositoryInfo) (registry
 	if err != nil {
 		return registry.APIEndpoint{}, err
 	}
-	endpoints, err := registryService.LookupPushEndpoints(reference.Domain(repoInfo.Name))
+	endpoints, err := registryService.LookupPushEndpoints(hostname)
 	if err != nil {
 		return registry.APIEndpoint{}, err
 	}
 	// Default to the highest priority endpoint to return
 	endpoint := endpoints[0]
-	if !repoInfo.Index.Secure {
+	if !secure {
 		for _, ep := range endpoints {
 			if ep.URL.Scheme == "http" {
 				endpoint = ep
@@ -99,7 +121,17 @@ func getHTTPTransport(authConfig authtypes.AuthConfig, endpoint registry.APIEndp
 		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, passThruTokenHandler))
 	} else {
 		creds := registry.NewStaticCredentialStore(&authConfig)
-		tokenHandler := auth.NewTokenHandler(authTransport, creds, repoName, "push", "pull")
+		// Request a token without scope if no repository is given, which
+		// only checks the credentials.
+		var scopes []auth.Scope
+		if repoName != "" {
+			scopes = append(scopes, auth.RepositoryScope{Repository: repoName, Actions: []string{"push", "pull"}})
+		}
+		tokenHandler := auth.NewTokenHandlerWithOptions(auth.TokenHandlerOptions{
+			Transport:   authTransport,
+			Credentials: creds,
+			Scopes:      scopes,
+		})
 		basicHandler := auth.NewBasicHandler(creds)
 		modifiers = append(modifiers, auth.NewAuthorizer(challengeManager, tokenHandler, basicHandler))
 	}
@@ -19,6 +19,11 @@ func (o *Out) Write(p []byte) (int, error) {
 	return o.out.Write(p)
 }
 
+// Writer returns the writer the output stream writes to.
+func (o *Out) Writer() io.Writer {
+	return o.out
+}
+
 // SetRawTerminal sets raw mode on the input terminal
 func (o *Out) SetRawTerminal() (err error) {
 	if os.Getenv("NORAW") != "" || !o.commonStream.isTerminal {
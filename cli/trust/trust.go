@@ -304,6 +304,18 @@ type ImageRefAndAuth struct {
 func GetImageReferencesAndAuth(ctx context.Context, rs registry.Service,
 	authResolver func(ctx context.Context, index *registrytypes.IndexInfo) types.AuthConfig,
 	imgName string,
+) (ImageRefAndAuth, error) {
+	return GetImageReferencesAndRepositoryAuth(ctx, rs, func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+		return authResolver(ctx, repoInfo.Index)
+	}, imgName)
+}
+
+// GetImageReferencesAndRepositoryAuth is like GetImageReferencesAndAuth, but
+// resolves the auth information for the repository of the image, so that
+// credentials scoped to the repository can be used.
+func GetImageReferencesAndRepositoryAuth(ctx context.Context, rs registry.Service,
+	authResolver func(ctx context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig,
+	imgName string,
 ) (ImageRefAndAuth, error) {
 	ref, err := reference.ParseNormalizedNamed(imgName)
 	if err != nil {
@@ -322,7 +334,7 @@ func GetImageReferencesAndAuth(ctx context.Context, rs registry.Service,
 		return ImageRefAndAuth{}, err
 	}
 
-	authConfig := authResolver(ctx, repoInfo.Index)
+	authConfig := authResolver(ctx, repoInfo)
 	return ImageRefAndAuth{
 		original:   imgName,
 		authConfig: &authConfig,
@@ -1,9 +1,13 @@
 package trust
 
 import (
+	"context"
 	"testing"
 
 	"github.com/docker/distribution/reference"
+	"github.com/harness-community/docker-v23/api/types"
+	registrytypes "github.com/harness-community/docker-v23/api/types/registry"
+	"github.com/harness-community/docker-v23/registry"
 	"github.com/opencontainers/go-digest"
 	"github.com/theupdateframework/notary/client"
 	"github.com/theupdateframework/notary/passphrase"
@@ -12,6 +16,22 @@ import (
 	is "gotest.tools/v3/assert/cmp"
 )
 
+func TestGetImageReferencesAndAuth(t *testing.T) {
+	ctx := context.Background()
+	imgRefAndAuth, err := GetImageReferencesAndAuth(ctx, nil, func(_ context.Context, index *registrytypes.IndexInfo) types.AuthConfig {
+		return types.AuthConfig{ServerAddress: index.Name}
+	}, "registry.example.com/team-a/app:1.0")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(imgRefAndAuth.AuthConfig().ServerAddress, "registry.example.com"))
+	assert.Check(t, is.Equal(imgRefAndAuth.Tag(), "1.0"))
+
+	imgRefAndAuth, err = GetImageReferencesAndRepositoryAuth(ctx, nil, func(_ context.Context, repoInfo *registry.RepositoryInfo) types.AuthConfig {
+		return types.AuthConfig{ServerAddress: repoInfo.Name.Name()}
+	}, "registry.example.com/team-a/app:1.0")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(imgRefAndAuth.AuthConfig().ServerAddress, "registry.example.com/team-a/app"))
+}
+
 func TestGetTag(t *testing.T) {
 	ref, err := reference.ParseNormalizedNamed("ubuntu@sha256:45b23dee08af5e43a7fea6c4cf9c25ccf269ee113168c19722f87876677c5cb2")
 	assert.NilError(t, err)
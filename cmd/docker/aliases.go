@@ -1,9 +1,13 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/google/shlex"
+	"github.com/harness-community/docker-cli-v23/cli"
 	pluginmanager "github.com/harness-community/docker-cli-v23/cli-plugins/manager"
 	"github.com/harness-community/docker-cli-v23/cli/command"
 	"github.com/pkg/errors"
@@ -14,26 +18,53 @@ const (
 	keyBuilderAlias = "builder"
 )
 
-var allowedAliases = map[string]struct{}{
-	keyBuilderAlias: {},
+// userAlias is a user-defined alias, such as `"lsa": "ps -a"`. Aliases whose
+// value starts with "!" are shell aliases, which are run as shell commands.
+type userAlias struct {
+	name  string
+	value string
+}
+
+func (a userAlias) isShell() bool {
+	return strings.HasPrefix(a.value, "!")
+}
+
+// expand returns the arguments the alias expands to.
+func (a userAlias) expand() ([]string, error) {
+	fields, err := shlex.Split(a.value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid alias %q", a.name)
+	}
+	if len(fields) == 0 {
+		return nil, errors.Errorf("invalid alias %q: alias is empty", a.name)
+	}
+	if strings.HasPrefix(fields[0], "-") {
+		return nil, errors.Errorf("invalid alias %q: alias must start with a command, not an option", a.name)
+	}
+	return fields, nil
+}
+
+// description is the description of the alias in help output.
+func (a userAlias) description() string {
+	if a.isShell() {
+		return "Shell alias for: " + strings.ReplaceAll(strings.TrimPrefix(a.value, "!"), "\t", `\t`)
+	}
+	return "Alias for: " + strings.ReplaceAll(a.value, "\t", `\t`)
 }
 
 func processAliases(dockerCli command.Cli, cmd *cobra.Command, args, osArgs []string) ([]string, []string, []string, error) {
 	var err error
 	var envs []string
 	aliasMap := dockerCli.ConfigFile().Aliases
-	aliases := make([][2][]string, 0, len(aliasMap))
+	aliases := make([][2][]string, 0, 1)
 
-	for k, v := range aliasMap {
-		if _, ok := allowedAliases[k]; !ok {
-			return args, osArgs, envs, errors.Errorf("not allowed to alias %q (allowed: %#v)", k, allowedAliases)
-		}
+	if v, ok := aliasMap[keyBuilderAlias]; ok {
 		if c, _, err := cmd.Find(strings.Split(v, " ")); err == nil {
 			if !pluginmanager.IsPluginCommand(c) {
 				return args, osArgs, envs, errors.Errorf("not allowed to alias with builtin %q as target", v)
 			}
 		}
-		aliases = append(aliases, [2][]string{{k}, {v}})
+		aliases = append(aliases, [2][]string{{keyBuilderAlias}, {v}})
 	}
 
 	args, osArgs, envs, err = processBuilder(dockerCli, cmd, args, os.Args)
@@ -50,5 +81,166 @@ func processAliases(dockerCli command.Cli, cmd *cobra.Command, args, osArgs []st
 		}
 	}
 
-	return args, osArgs, envs, nil
+	args, osArgs, err = expandUserAliases(dockerCli, cmd, args, osArgs)
+	return args, osArgs, envs, err
+}
+
+// expandUserAliases expands the user-defined alias the command starts with,
+// if any. Arguments following the alias are passed through. Aliases can
+// expand to other aliases, but not to themselves. Shell aliases are not
+// expanded; they are run by runShellAlias instead.
+func expandUserAliases(dockerCli command.Cli, cmd *cobra.Command, args, osArgs []string) ([]string, []string, error) {
+	idx := 0
+	if len(args) > 0 && (args[0] == cobra.ShellCompRequestCmd || args[0] == cobra.ShellCompNoDescRequestCmd) {
+		// expand aliases when completing their arguments as well.
+		idx = 1
+	}
+	var expanded []string
+	for len(args) > idx {
+		alias, ok := lookupUserAlias(dockerCli, args[idx])
+		if !ok || alias.isShell() {
+			break
+		}
+		if shadowsCommand(dockerCli, cmd.Root(), alias.name) {
+			if len(expanded) == 0 {
+				fmt.Fprintf(dockerCli.Err(), "WARNING: alias %q is ignored, as it has the same name as a docker command\n", alias.name)
+			}
+			break
+		}
+		for _, name := range expanded {
+			if name == alias.name {
+				return args, osArgs, errors.Errorf("alias loop detected: %s -> %s", strings.Join(expanded, " -> "), alias.name)
+			}
+		}
+		expanded = append(expanded, alias.name)
+
+		fields, err := alias.expand()
+		if err != nil {
+			return args, osArgs, err
+		}
+		osIdx := len(osArgs) - len(args) + idx
+		args, _ = command.StringSliceReplaceAt(args, []string{alias.name}, fields, idx)
+		osArgs, _ = command.StringSliceReplaceAt(osArgs, []string{alias.name}, fields, osIdx)
+	}
+	return args, osArgs, nil
+}
+
+// lookupUserAlias returns the user-defined alias with the given name, if any.
+// The builder alias, which selects the builder plugin, is not a user-defined
+// alias.
+func lookupUserAlias(dockerCli command.Cli, name string) (userAlias, bool) {
+	if name == keyBuilderAlias {
+		return userAlias{}, false
+	}
+	value, ok := dockerCli.ConfigFile().Aliases[name]
+	return userAlias{name: name, value: value}, ok
+}
+
+// shellAlias returns the shell alias the command starts with, if any.
+func shellAlias(dockerCli command.Cli, cmd *cobra.Command, args []string) (userAlias, bool) {
+	if len(args) == 0 {
+		return userAlias{}, false
+	}
+	alias, ok := lookupUserAlias(dockerCli, args[0])
+	if !ok || !alias.isShell() || shadowsCommand(dockerCli, cmd.Root(), alias.name) {
+		return userAlias{}, false
+	}
+	return alias, true
+}
+
+// isCommand returns true if name is the name, or an alias, of one of the
+// commands of rootCmd, not counting the commands standing for user-defined
+// aliases.
+func isCommand(rootCmd *cobra.Command, name string) bool {
+	for _, c := range rootCmd.Commands() {
+		if !cli.IsAliasCommand(c) && (c.Name() == name || c.HasAlias(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// shadowsCommand returns true if an alias with the given name would shadow a
+// built-in command or a CLI plugin, in which case the alias is ignored.
+func shadowsCommand(dockerCli command.Cli, rootCmd *cobra.Command, name string) bool {
+	if isCommand(rootCmd, name) {
+		return true
+	}
+	_, err := pluginmanager.GetPlugin(name, dockerCli, rootCmd)
+	return err == nil
+}
+
+// runShellAlias runs the shell command of a shell alias. Arguments following
+// the alias are passed to the shell command as positional parameters.
+func runShellAlias(alias userAlias, args []string) error {
+	c := shellCommand(strings.TrimPrefix(alias.value, "!"), alias.name, args)
+	// As for plugins, use os.Stdin rather than dockerCli.In(), which would
+	// hang until something is input.
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return statusError(c.Run())
+}
+
+// addAliasCommandStubs adds commands standing for the user-defined aliases to
+// rootCmd, so that aliases are listed in help output and completed. Plugin
+// command stubs must be added first, so that aliases shadowing plugins are
+// left out.
+func addAliasCommandStubs(dockerCli command.Cli, rootCmd *cobra.Command) {
+	aliasMap := dockerCli.ConfigFile().Aliases
+	names := make([]string, 0, len(aliasMap))
+	for name := range aliasMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	existing := map[string]struct{}{}
+	for _, c := range rootCmd.Commands() {
+		if cli.IsAliasCommand(c) {
+			existing[c.Name()] = struct{}{}
+		}
+	}
+	for _, name := range names {
+		alias, ok := lookupUserAlias(dockerCli, name)
+		if !ok || isCommand(rootCmd, name) {
+			continue
+		}
+		if _, ok := existing[name]; ok {
+			continue
+		}
+		rootCmd.AddCommand(&cobra.Command{
+			Use:                name,
+			Short:              alias.description(),
+			Annotations:        map[string]string{cli.CommandAnnotationAlias: alias.value},
+			DisableFlagParsing: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return errors.Errorf("docker: alias '%s' cannot be used here", cmd.Name())
+			},
+		})
+	}
+}
+
+// aliasHelp prints what the alias at the start of args expands to, and
+// returns the arguments to show the help of, if any.
+func aliasHelp(dockerCli command.Cli, rootCmd *cobra.Command, args []string) ([]string, bool, error) {
+	alias, ok := lookupUserAlias(dockerCli, args[0])
+	if !ok || shadowsCommand(dockerCli, rootCmd, alias.name) {
+		return args, false, nil
+	}
+	fmt.Fprintf(dockerCli.Out(), "'%s' is aliased to '%s'\n", alias.name, alias.value)
+	if alias.isShell() {
+		return nil, true, nil
+	}
+	fields, err := alias.expand()
+	if err != nil {
+		return nil, true, err
+	}
+	var helpArgs []string
+	for _, f := range fields {
+		if strings.HasPrefix(f, "-") {
+			break
+		}
+		helpArgs = append(helpArgs, f)
+	}
+	return helpArgs, true, nil
 }
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/spf13/cobra"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+var testAliases = map[string]string{
+	"lsa":   "ps -a --format 'table {{.Names}}\t{{.Status}}'",
+	"ll":    "lsa --no-trunc",
+	"ps":    "images",
+	"loop1": "loop2",
+	"loop2": "loop1",
+	"opt":   "--debug ps",
+	"rmx":   "!docker rm $(docker ps -aq -f status=exited)",
+}
+
+func newAliasTestCli(t *testing.T, out *bytes.Buffer) (*command.DockerCli, *cobra.Command) {
+	t.Helper()
+	dockerCli, err := command.NewDockerCli(command.WithInputStream(discard), command.WithCombinedStreams(out))
+	assert.NilError(t, err)
+	tcmd := newDockerCommand(dockerCli)
+	tcmd.SetArgs(nil)
+	cmd, _, err := tcmd.HandleGlobalFlags()
+	assert.NilError(t, err)
+	assert.NilError(t, tcmd.Initialize())
+	dockerCli.ConfigFile().Aliases = testAliases
+	dockerCli.ConfigFile().CLIPluginsExtraDirs = []string{t.TempDir()}
+	return dockerCli, cmd
+}
+
+func TestExpandUserAliases(t *testing.T) {
+	tests := []struct {
+		doc          string
+		args         []string
+		expected     []string
+		expectedErr  string
+		expectedWarn string
+	}{
+		{
+			doc:      "not an alias",
+			args:     []string{"images", "lsa"},
+			expected: []string{"images", "lsa"},
+		},
+		{
+			doc:      "alias with arguments",
+			args:     []string{"lsa", "-q"},
+			expected: []string{"ps", "-a", "--format", "table {{.Names}}\t{{.Status}}", "-q"},
+		},
+		{
+			doc:      "alias of alias",
+			args:     []string{"ll"},
+			expected: []string{"ps", "-a", "--format", "table {{.Names}}\t{{.Status}}", "--no-trunc"},
+		},
+		{
+			doc:      "completion",
+			args:     []string{"__complete", "lsa", ""},
+			expected: []string{"__complete", "ps", "-a", "--format", "table {{.Names}}\t{{.Status}}", ""},
+		},
+		{
+			doc:          "alias shadowing a command",
+			args:         []string{"ps"},
+			expected:     []string{"ps"},
+			expectedWarn: "WARNING: alias \"ps\" is ignored, as it has the same name as a docker command\n",
+		},
+		{
+			doc:      "shell alias",
+			args:     []string{"rmx", "foo"},
+			expected: []string{"rmx", "foo"},
+		},
+		{
+			doc:         "alias loop",
+			args:        []string{"loop1"},
+			expectedErr: "alias loop detected: loop1 -> loop2 -> loop1",
+		},
+		{
+			doc:         "alias starting with an option",
+			args:        []string{"opt"},
+			expectedErr: `invalid alias "opt": alias must start with a command, not an option`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.doc, func(t *testing.T) {
+			var out bytes.Buffer
+			dockerCli, cmd := newAliasTestCli(t, &out)
+			osArgs := append([]string{"docker", "--debug"}, tc.args...)
+			args, osArgs, err := expandUserAliases(dockerCli, cmd, tc.args, osArgs)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.Error(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(args, tc.expected))
+			assert.Check(t, is.DeepEqual(osArgs, append([]string{"docker", "--debug"}, tc.expected...)))
+			assert.Check(t, is.Equal(out.String(), tc.expectedWarn))
+		})
+	}
+}
+
+func TestRunShellAlias(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell aliases are run with cmd.exe on Windows")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	err := runShellAlias(userAlias{name: "echo", value: `!echo "$0:$1" >` + out}, []string{"a", "b"})
+	assert.NilError(t, err)
+	data, err := os.ReadFile(out)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "echo:a a b\n"))
+
+	err = runShellAlias(userAlias{name: "fail", value: "!exit 3"}, nil)
+	assert.Check(t, is.DeepEqual(err, cli.StatusError{StatusCode: 3}))
+}
+
+func TestAliasHelp(t *testing.T) {
+	var out bytes.Buffer
+	_, cmd := newAliasTestCli(t, &out)
+	cmd.SetArgs([]string{"help"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Contains(out.String(), "\nCommand Aliases:\n"))
+	assert.Check(t, is.Contains(out.String(), "  lsa         Alias for: ps -a --format 'table {{.Names}}\\t{{.Status}}'\n"))
+	assert.Check(t, is.Contains(out.String(), "  rmx         Shell alias for: docker rm $(docker ps -aq -f status=exited)\n"))
+	// aliases shadowing commands are not listed
+	assert.Check(t, !bytes.Contains(out.Bytes(), []byte("Alias for: images")))
+
+	out.Reset()
+	cmd.SetArgs([]string{"help", "lsa"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Contains(out.String(), "'lsa' is aliased to 'ps -a --format 'table {{.Names}}\t{{.Status}}''\n"))
+	assert.Check(t, is.Contains(out.String(), "Usage:  docker ps [OPTIONS]"))
+
+	out.Reset()
+	cmd.SetArgs([]string{"help", "rmx"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(out.String(), "'rmx' is aliased to '!docker rm $(docker ps -aq -f status=exited)'\n"))
+}
+
+func TestAliasCompletion(t *testing.T) {
+	var out bytes.Buffer
+	dockerCli, cmd := newAliasTestCli(t, &out)
+	addAliasCommandStubs(dockerCli, cmd)
+	// adding stubs is idempotent
+	addAliasCommandStubs(dockerCli, cmd)
+
+	cmd.SetArgs([]string{"__completeNoDesc", "l"})
+	assert.NilError(t, cmd.Execute())
+	assert.Check(t, is.Equal(out.String(), "ll\nload\nlogin\nlogout\nlogs\nloop1\nloop2\nlsa\n:4\nCompletion ended with directive: ShellCompDirectiveNoFileComp\n"))
+}
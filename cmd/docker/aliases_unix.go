@@ -0,0 +1,15 @@
+//go:build !windows
+// +build !windows
+
+package main
+
+import "os/exec"
+
+// shellCommand returns the command running script with sh. Arguments are
+// passed as positional parameters ("$@"), and the name of the alias as "$0".
+func shellCommand(script, name string, args []string) *exec.Cmd {
+	if len(args) > 0 {
+		script += ` "$@"`
+	}
+	return exec.Command("/bin/sh", append([]string{"-c", script, name}, args...)...)
+}
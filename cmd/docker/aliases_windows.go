@@ -0,0 +1,11 @@
+package main
+
+import (
+	"os/exec"
+)
+
+// shellCommand returns the command running script with cmd.exe. Arguments
+// are appended to the script.
+func shellCommand(script, _ string, args []string) *exec.Cmd {
+	return exec.Command("cmd.exe", append([]string{"/C", script}, args...)...)
+}
@@ -99,6 +99,13 @@ func setupHelpCommand(dockerCli command.Cli, rootCmd, helpCmd *cobra.Command) {
 
 	helpCmd.Run = nil
 	helpCmd.RunE = func(c *cobra.Command, args []string) error {
+		if len(args) > 0 {
+			helpArgs, isAlias, err := aliasHelp(dockerCli, rootCmd, args)
+			if err != nil || (isAlias && len(helpArgs) == 0) {
+				return err
+			}
+			args = helpArgs
+		}
 		if len(args) > 0 {
 			helpcmd, err := pluginmanager.PluginRunCommand(dockerCli, args[0], rootCmd)
 			if err == nil {
@@ -137,6 +144,7 @@ func setHelpFunc(dockerCli command.Cli, cmd *cobra.Command) {
 			ccmd.Println(err)
 			return
 		}
+		addAliasCommandStubs(dockerCli, ccmd.Root())
 
 		if len(args) >= 1 {
 			err := tryRunPluginHelp(dockerCli, ccmd, args)
@@ -200,20 +208,26 @@ func tryPluginRun(dockerCli command.Cli, cmd *cobra.Command, subcommand string,
 		<-appcontext.Context().Done()
 	}()
 
-	if err := plugincmd.Run(); err != nil {
-		statusCode := 1
-		exitErr, ok := err.(*exec.ExitError)
-		if !ok {
-			return err
-		}
-		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok {
-			statusCode = ws.ExitStatus()
-		}
-		return cli.StatusError{
-			StatusCode: statusCode,
-		}
+	return statusError(plugincmd.Run())
+}
+
+// statusError converts the error returned when running an external command
+// into a cli.StatusError holding its exit status.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	statusCode := 1
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return err
+	}
+	if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+		statusCode = ws.ExitStatus()
+	}
+	return cli.StatusError{
+		StatusCode: statusCode,
 	}
-	return nil
 }
 
 func runDocker(dockerCli *command.DockerCli) error {
@@ -234,6 +248,14 @@ func runDocker(dockerCli *command.DockerCli) error {
 		return err
 	}
 
+	if alias, ok := shellAlias(dockerCli, cmd, args); ok {
+		return runShellAlias(alias, args[1:])
+	}
+
+	if len(args) > 0 && command.IsContextSelector(dockerCli.CurrentContext()) && !cli.HasCompletionArg(args) {
+		return runMultiContext(dockerCli, cmd, os.Args[1:], args)
+	}
+
 	if cli.HasCompletionArg(args) {
 		// We add plugin command stubs early only for completion. We don't
 		// want to add them for normal command execution as it would cause
@@ -242,6 +264,7 @@ func runDocker(dockerCli *command.DockerCli) error {
 		if err != nil {
 			return err
 		}
+		addAliasCommandStubs(dockerCli, cmd)
 	}
 
 	if len(args) > 0 {
@@ -260,7 +283,53 @@ func runDocker(dockerCli *command.DockerCli) error {
 	// We've parsed global args already, so reset args to those
 	// which remain.
 	cmd.SetArgs(args)
-	return cmd.Execute()
+	if cli.HasCompletionArg(args) {
+		return cmd.Execute()
+	}
+	return executeWithHooks(dockerCli, cmd)
+}
+
+// executeWithHooks executes the root command, and runs the hooks that CLI
+// plugins declare for the command that runs, before and after it runs.
+func executeWithHooks(dockerCli command.Cli, cmd *cobra.Command) error {
+	var (
+		hookCmd     *cobra.Command
+		hookPlugins []pluginmanager.Plugin
+	)
+	preRunE := cmd.PersistentPreRunE
+	cmd.PersistentPreRunE = func(ccmd *cobra.Command, args []string) error {
+		if err := preRunE(ccmd, args); err != nil {
+			return err
+		}
+		if pluginmanager.IsPluginCommand(ccmd) || !ccmd.Runnable() {
+			return nil
+		}
+		plugins, err := pluginmanager.HookPlugins(dockerCli, cmd)
+		if err != nil {
+			logrus.WithError(err).Debug("failed to list plugins for hooks")
+			return nil
+		}
+		hookCmd, hookPlugins = ccmd, plugins
+		pluginmanager.RunHooks(dockerCli, hookPlugins, cmd, ccmd, pluginmanager.HookBefore, 0)
+		return nil
+	}
+
+	_, err := cmd.ExecuteC()
+	if hookCmd != nil {
+		pluginmanager.RunHooks(dockerCli, hookPlugins, cmd, hookCmd, pluginmanager.HookAfter, exitCode(err))
+	}
+	return err
+}
+
+// exitCode returns the exit status the CLI exits with for err.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if sterr, ok := err.(cli.StatusError); ok && sterr.StatusCode != 0 {
+		return sterr.StatusCode
+	}
+	return 1
 }
 
 func main() {
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/command/formatter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// contextRun is a run of a command against a single context.
+type contextRun struct {
+	name   string
+	cmd    *cobra.Command
+	out    *formatter.Collector
+	errOut bytes.Buffer
+	err    error
+}
+
+// runMultiContext runs the command selected by args concurrently against each
+// of the contexts selected by the current context name (e.g. "a,b,c" or
+// "label=env=prod"), and merges their output. Errors for individual contexts
+// are reported once all runs are complete, and don't prevent the command from
+// running against the other contexts.
+func runMultiContext(dockerCli *command.DockerCli, cmd *cobra.Command, globalArgs, args []string) error {
+	ccmd, _, err := cmd.Find(args)
+	if err != nil {
+		return err
+	}
+	if !command.SupportsMultiContext(ccmd) {
+		return errors.Errorf("%s does not support running against multiple contexts", ccmd.CommandPath())
+	}
+	names, err := command.ResolveContextSelector(dockerCli.ContextStore(), dockerCli.CurrentContext())
+	if err != nil {
+		return err
+	}
+
+	runs := make([]*contextRun, 0, len(names))
+	for _, name := range names {
+		r, err := newContextRun(name, globalArgs, args)
+		if err != nil {
+			return err
+		}
+		runs = append(runs, r)
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range runs {
+		if r.err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(r *contextRun) {
+			defer wg.Done()
+			r.err = r.cmd.Execute()
+		}(r)
+	}
+	wg.Wait()
+
+	outputs := make([]formatter.CollectedOutput, 0, len(runs))
+	for _, r := range runs {
+		outputs = append(outputs, formatter.CollectedOutput{Context: r.name, Collector: r.out})
+	}
+	if err := formatter.WriteMerged(dockerCli.Out(), outputs); err != nil {
+		return err
+	}
+
+	var failed bool
+	for _, r := range runs {
+		writePrefixed(dockerCli.Err(), r.name, r.errOut.String())
+		if r.err != nil {
+			failed = true
+			writePrefixed(dockerCli.Err(), r.name, r.err.Error())
+		}
+	}
+	if failed {
+		return cli.StatusError{StatusCode: 1}
+	}
+	return nil
+}
+
+// newContextRun prepares a run of the command against the given context,
+// using a CLI of its own that collects the output of the command.
+func newContextRun(contextName string, globalArgs, args []string) (*contextRun, error) {
+	r := &contextRun{name: contextName, out: formatter.NewCollector()}
+	dockerCli, err := command.NewDockerCli(
+		command.WithInputStream(io.NopCloser(strings.NewReader(""))),
+		command.WithOutputStream(r.out),
+		command.WithErrorStream(&r.errOut),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tcmd := newDockerCommand(dockerCli)
+	tcmd.SetArgs(globalArgs)
+	cmd, _, err := tcmd.HandleGlobalFlags()
+	if err != nil {
+		return nil, err
+	}
+	tcmd.SetFlag("context", contextName)
+
+	if err := tcmd.Initialize(); err != nil {
+		return nil, err
+	}
+
+	// Resolve the endpoint of the context upfront, as failing to do so
+	// later on would terminate the whole process.
+	apiClient, err := command.NewAPIClientFromContext(dockerCli.ContextStore(), contextName, dockerCli.ConfigFile())
+	if err != nil {
+		r.err = err
+		return r, nil
+	}
+	if err := dockerCli.Apply(command.WithAPIClient(apiClient)); err != nil {
+		return nil, err
+	}
+	cmd.SetArgs(args)
+	r.cmd = cmd
+	return r, nil
+}
+
+// writePrefixed writes each line of text to w, prefixed with the name of the
+// context it relates to.
+func writePrefixed(w io.Writer, contextName, text string) {
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		fmt.Fprintf(w, "%s: %s\n", contextName, scanner.Text())
+	}
+}
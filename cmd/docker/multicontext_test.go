@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/harness-community/docker-cli-v23/cli"
+	"github.com/harness-community/docker-cli-v23/cli/command"
+	"github.com/harness-community/docker-cli-v23/cli/config"
+	"github.com/harness-community/docker-cli-v23/cli/context/docker"
+	"github.com/harness-community/docker-cli-v23/cli/context/store"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func newVolumesEngine(t *testing.T, volumeName string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Api-Version", "1.42")
+		switch {
+		case r.URL.Path == "/_ping":
+			_, _ = w.Write([]byte("OK"))
+		case strings.HasSuffix(r.URL.Path, "/volumes"):
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"Volumes":[{"Name":%q,"Driver":"local"}]}`, volumeName)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return "tcp://" + srv.Listener.Addr().String()
+}
+
+func runMultiContextCommand(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	dockerCli, err := command.NewDockerCli(command.WithInputStream(discard), command.WithOutputStream(&stdout), command.WithErrorStream(&stderr))
+	assert.NilError(t, err)
+	tcmd := newDockerCommand(dockerCli)
+	tcmd.SetArgs(args)
+	cmd, cmdArgs, err := tcmd.HandleGlobalFlags()
+	assert.NilError(t, err)
+	assert.NilError(t, tcmd.Initialize())
+	err = runMultiContext(dockerCli, cmd, args, cmdArgs)
+	return stdout.String(), stderr.String(), err
+}
+
+func TestRunMultiContext(t *testing.T) {
+	oldDir := config.Dir()
+	defer config.SetDir(oldDir)
+	configDir := t.TempDir()
+	config.SetDir(configDir)
+
+	// reserve an address that nothing is listening on
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	downHost := "tcp://" + l.Addr().String()
+	assert.NilError(t, l.Close())
+
+	s := store.New(config.ContextStoreDir(), command.DefaultContextStoreConfig())
+	for name, host := range map[string]string{
+		"one":  newVolumesEngine(t, "vol-one"),
+		"two":  newVolumesEngine(t, "vol-two"),
+		"down": downHost,
+	} {
+		assert.NilError(t, s.CreateOrUpdate(store.Metadata{
+			Name:      name,
+			Metadata:  command.DockerContext{Labels: map[string]string{"fleet": "yes"}},
+			Endpoints: map[string]interface{}{docker.DockerEndpoint: docker.EndpointMeta{Host: host}},
+		}))
+	}
+
+	t.Run("table", func(t *testing.T) {
+		stdout, stderr, err := runMultiContextCommand(t, "--config", configDir, "--context", "one,two", "volume", "ls")
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(stdout, `CONTEXT   DRIVER    VOLUME NAME
+one       local     vol-one
+two       local     vol-two
+`))
+		assert.Check(t, is.Equal(stderr, ""))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		stdout, _, err := runMultiContextCommand(t, "--config", configDir, "--context", "one,two", "volume", "ls", "--format", "json")
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(stdout, `"Context":"one"`))
+		assert.Check(t, is.Contains(stdout, `"Context":"two"`))
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		stdout, stderr, err := runMultiContextCommand(t, "--config", configDir, "--context", "label=fleet", "volume", "ls", "-q")
+		assert.Check(t, is.DeepEqual(err, cli.StatusError{StatusCode: 1}))
+		assert.Check(t, is.Contains(stdout, "vol-one\n"))
+		assert.Check(t, is.Contains(stdout, "vol-two\n"))
+		assert.Check(t, strings.HasPrefix(stderr, "down: "), stderr)
+	})
+
+	t.Run("unsupported command", func(t *testing.T) {
+		_, _, err := runMultiContextCommand(t, "--config", configDir, "--context", "one,two", "volume", "create")
+		assert.Check(t, is.Error(err, "docker volume create does not support running against multiple contexts"))
+	})
+}
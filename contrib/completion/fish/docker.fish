@@ -105,6 +105,7 @@ complete -c docker -f -n '__fish_docker_no_subcommand' -l ip-masq -d "Enable IP
 complete -c docker -f -n '__fish_docker_no_subcommand' -l iptables -d "Enable Docker's addition of iptables rules"
 complete -c docker -f -n '__fish_docker_no_subcommand' -l ipv6 -d 'Enable IPv6 networking'
 complete -c docker -f -n '__fish_docker_no_subcommand' -s l -l log-level -d 'Set the logging level ("debug", "info", "warn", "error", "fatal")'
+complete -c docker -f -n '__fish_docker_no_subcommand' -l strict-config -d 'Fail if client config files contain unknown or invalid properties'
 complete -c docker -f -n '__fish_docker_no_subcommand' -l label -d 'Set key=value labels to the daemon (displayed in `docker info`)'
 complete -c docker -f -n '__fish_docker_no_subcommand' -l mtu -d 'Set the containers network MTU'
 complete -c docker -f -n '__fish_docker_no_subcommand' -s p -l pidfile -d 'Path to use for daemon PID file'
@@ -359,6 +360,7 @@ complete -c docker -A -f -n '__fish_seen_subcommand_from load' -s q -l quiet -d
 
 # login
 complete -c docker -f -n '__fish_docker_no_subcommand' -a login -d 'Log in to a registry'
+complete -c docker -A -f -n '__fish_seen_subcommand_from login' -l device -d 'Log in by authorizing this device in a browser'
 complete -c docker -A -f -n '__fish_seen_subcommand_from login' -l help -d 'Print usage'
 complete -c docker -A -f -n '__fish_seen_subcommand_from login' -s p -l password -d 'Password'
 complete -c docker -A -f -n '__fish_seen_subcommand_from login' -l password-stdin -d 'Take the password from stdin'
@@ -0,0 +1,70 @@
+// Package lockfile provides advisory, inter-process locking based on lock
+// files, to coordinate concurrent writers of the CLI's configuration and
+// context store.
+package lockfile
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultTimeout is the default time to wait for a lock to be acquired.
+	DefaultTimeout = 30 * time.Second
+
+	retryInterval = 10 * time.Millisecond
+)
+
+// LockFile is an exclusive advisory lock held on a file.
+type LockFile struct {
+	f *os.File
+}
+
+// Lock acquires an exclusive advisory lock on the file at path, creating the
+// file and its parent directories if needed. It blocks until the lock is
+// acquired, or returns an error if the lock could not be acquired within
+// DefaultTimeout.
+func Lock(path string) (*LockFile, error) {
+	return LockWithTimeout(path, DefaultTimeout)
+}
+
+// LockWithTimeout is like Lock, but waits at most timeout for the lock to be
+// acquired.
+func LockWithTimeout(path string, timeout time.Duration) (*LockFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		ok, err := tryLock(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, errors.Wrapf(err, "failed to lock %s", path)
+		}
+		if ok {
+			return &LockFile{f: f}, nil
+		}
+		if time.Now().After(deadline) {
+			_ = f.Close()
+			return nil, errors.Errorf("timed out waiting for lock on %s", path)
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// Unlock releases the lock. The lock file itself is not removed, as other
+// processes may be waiting to acquire a lock on it.
+func (l *LockFile) Unlock() error {
+	if err := unlock(l.f); err != nil {
+		_ = l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
@@ -0,0 +1,127 @@
+package lockfile
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+const (
+	helperEnvPath  = "TEST_LOCKFILE_HELPER_PATH"
+	helperEnvCount = "TEST_LOCKFILE_HELPER_COUNT"
+)
+
+// TestMain allows the test binary to be re-executed as a helper process that
+// competes for the lock with other processes.
+func TestMain(m *testing.M) {
+	if path := os.Getenv(helperEnvPath); path != "" {
+		n, _ := strconv.Atoi(os.Getenv(helperEnvCount))
+		if err := incrementN(path, n); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// increment performs a non-atomic read-modify-write of the counter stored in
+// path while holding the lock, so that lost updates indicate a broken lock.
+func increment(path string) error {
+	l, err := Lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	var n int
+	if data, err := os.ReadFile(path); err == nil {
+		n, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(n+1)), 0o600)
+}
+
+func incrementN(path string, n int) error {
+	for i := 0; i < n; i++ {
+		if err := increment(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readCounter(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	n, err := strconv.Atoi(string(data))
+	assert.NilError(t, err)
+	return n
+}
+
+func TestLockGoroutines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "counter")
+
+	const workers, iterations = 5, 10
+	var wg sync.WaitGroup
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- incrementN(path, iterations)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NilError(t, err)
+	}
+	assert.Check(t, is.Equal(readCounter(t, path), workers*iterations))
+}
+
+func TestLockProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping multi-process test in short mode")
+	}
+	path := filepath.Join(t.TempDir(), "counter")
+
+	const workers, iterations = 4, 10
+	cmds := make([]*exec.Cmd, 0, workers)
+	for i := 0; i < workers; i++ {
+		cmd := exec.Command(os.Args[0], "-test.run=^$")
+		cmd.Env = append(os.Environ(), helperEnvPath+"="+path, helperEnvCount+"="+strconv.Itoa(iterations))
+		cmd.Stderr = os.Stderr
+		assert.NilError(t, cmd.Start())
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range cmds {
+		assert.NilError(t, cmd.Wait())
+	}
+	assert.Check(t, is.Equal(readCounter(t, path), workers*iterations))
+}
+
+func TestLockTimeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.lock")
+	l, err := Lock(path)
+	assert.NilError(t, err)
+
+	_, err = LockWithTimeout(path, 50*time.Millisecond)
+	assert.Check(t, is.ErrorContains(err, "timed out waiting for lock"))
+
+	assert.NilError(t, l.Unlock())
+	l, err = LockWithTimeout(path, 50*time.Millisecond)
+	assert.NilError(t, err)
+	assert.NilError(t, l.Unlock())
+}
@@ -0,0 +1,28 @@
+//go:build !windows
+// +build !windows
+
+package lockfile
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryLock attempts to acquire an exclusive lock on f without blocking. It
+// returns false if the lock is held by someone else.
+func tryLock(f *os.File) (bool, error) {
+	err := unix.Flock(int(f.Fd()), unix.LOCK_EX|unix.LOCK_NB)
+	switch err {
+	case nil:
+		return true, nil
+	case unix.EWOULDBLOCK, unix.EINTR:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func unlock(f *os.File) error {
+	return unix.Flock(int(f.Fd()), unix.LOCK_UN)
+}
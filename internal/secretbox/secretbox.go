@@ -0,0 +1,113 @@
+// Package secretbox encrypts data at rest using AES-256-GCM, with a key
+// derived from a secret using PBKDF2, for the stores of the CLI that keep
+// secrets on disk.
+package secretbox
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// saltLen is the length of the random salt used to derive the
+	// encryption key each time data is sealed.
+	saltLen = 16
+	// iterations is the number of PBKDF2 iterations used to derive the
+	// encryption key from the secret.
+	iterations = 100000
+)
+
+var (
+	// ErrTruncated is returned when opening data that is too short to have
+	// been produced by Seal.
+	ErrTruncated = errors.New("encrypted data is truncated")
+	// ErrDecrypt is returned when data cannot be opened, because the secret
+	// is not the one it was sealed with, or the data was modified.
+	ErrDecrypt = errors.New("invalid key or corrupted data")
+)
+
+// IsSealed returns true if data starts with header, as data sealed with
+// that header does.
+func IsSealed(header, data []byte) bool {
+	return bytes.HasPrefix(data, header)
+}
+
+// Seal encrypts data using a key derived from secret. header identifies the
+// kind of data, and is authenticated along with it. The result has the
+// following layout:
+//
+//	<header><salt><nonce><ciphertext>
+func Seal(header, secret, data []byte) ([]byte, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	aead, err := newCipher(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(header)+len(salt)+len(nonce)+len(data)+aead.Overhead())
+	out = append(out, header...)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, header), nil
+}
+
+// Open decrypts data produced by Seal with the same header and secret. The
+// caller is expected to check that data is sealed using IsSealed first.
+func Open(header, secret, data []byte) ([]byte, error) {
+	payload := bytes.TrimPrefix(data, header)
+	if len(payload) < saltLen {
+		return nil, ErrTruncated
+	}
+	salt, payload := payload[:saltLen], payload[saltLen:]
+	aead, err := newCipher(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) < aead.NonceSize() {
+		return nil, ErrTruncated
+	}
+	nonce, ciphertext := payload[:aead.NonceSize()], payload[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, ciphertext, header)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plain, nil
+}
+
+// ReadKeyFile reads a secret from the given file. Leading and trailing
+// whitespace in the file is ignored, and an error is returned if the file
+// holds no secret.
+func ReadKeyFile(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key = bytes.TrimSpace(key)
+	if len(key) == 0 {
+		return nil, errors.Errorf("key file %s is empty", path)
+	}
+	return key, nil
+}
+
+func newCipher(secret, salt []byte) (cipher.AEAD, error) {
+	key := pbkdf2.Key(secret, salt, iterations, 32, sha256.New)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,54 @@
+package secretbox
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+var testHeader = []byte("TEST-HEADER\n")
+
+func TestSealOpen(t *testing.T) {
+	sealed, err := Seal(testHeader, []byte("secret"), []byte("data"))
+	assert.NilError(t, err)
+	assert.Check(t, IsSealed(testHeader, sealed))
+	assert.Check(t, !bytes.Contains(sealed, []byte("data")))
+
+	again, err := Seal(testHeader, []byte("secret"), []byte("data"))
+	assert.NilError(t, err)
+	assert.Check(t, !bytes.Equal(sealed, again), "each seal uses a new salt and nonce")
+
+	plain, err := Open(testHeader, []byte("secret"), sealed)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(plain), "data"))
+
+	_, err = Open(testHeader, []byte("wrong"), sealed)
+	assert.Check(t, is.ErrorIs(err, ErrDecrypt))
+
+	_, err = Open([]byte("OTHER-HEADER\n"), []byte("secret"), sealed)
+	assert.Check(t, is.ErrorIs(err, ErrDecrypt))
+
+	_, err = Open(testHeader, []byte("secret"), sealed[:len(testHeader)+saltLen+1])
+	assert.Check(t, is.ErrorIs(err, ErrTruncated))
+}
+
+func TestReadKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	assert.NilError(t, os.WriteFile(keyFile, []byte("  secret\n"), 0o600))
+	key, err := ReadKeyFile(keyFile)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(key), "secret"))
+
+	emptyFile := filepath.Join(dir, "empty")
+	assert.NilError(t, os.WriteFile(emptyFile, []byte("\n"), 0o600))
+	_, err = ReadKeyFile(emptyFile)
+	assert.Check(t, is.ErrorContains(err, "is empty"))
+
+	_, err = ReadKeyFile(filepath.Join(dir, "missing"))
+	assert.Check(t, os.IsNotExist(err))
+}
@@ -37,6 +37,7 @@ type FakeCli struct {
 	contentTrust     bool
 	contextStore     store.Store
 	currentContext   string
+	contextSource    command.ContextSource
 	dockerEndpoint   docker.Endpoint
 }
 
@@ -54,6 +55,7 @@ func NewFakeCli(client client.APIClient, opts ...func(*FakeCli)) *FakeCli {
 		// Set cli.ConfigFile().Filename to a tempfile to support Save.
 		configfile:     configfile.New(""),
 		currentContext: command.DefaultContextName,
+		contextSource:  command.ContextSource{Type: command.ContextSourceDefault},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -136,6 +138,16 @@ func (c *FakeCli) CurrentContext() string {
 	return c.currentContext
 }
 
+// CurrentContextSource returns where the cli context was selected from
+func (c *FakeCli) CurrentContextSource() command.ContextSource {
+	return c.contextSource
+}
+
+// SetCurrentContextSource sets where the cli context was selected from
+func (c *FakeCli) SetCurrentContextSource(source command.ContextSource) {
+	c.contextSource = source
+}
+
 // DockerEndpoint returns the current DockerEndpoint
 func (c *FakeCli) DockerEndpoint() docker.Endpoint {
 	return c.dockerEndpoint
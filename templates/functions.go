@@ -0,0 +1,405 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/fvbommel/sortorder"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// now returns the current time. It is a var for unit testing.
+var now = time.Now
+
+// timeLayouts are the layouts of the times accepted by since and ago, in
+// addition to Unix timestamps. The first is the layout of time.Time.String,
+// which is how times such as the CreatedAt of containers are rendered.
+var timeLayouts = []string{
+	"2006-01-02 15:04:05 -0700 MST",
+	time.RFC3339Nano,
+}
+
+// colors are the colors supported by the color function, and their ANSI
+// escape codes.
+var colors = map[string]string{
+	"black":   "\x1b[30m",
+	"red":     "\x1b[31m",
+	"green":   "\x1b[32m",
+	"yellow":  "\x1b[33m",
+	"blue":    "\x1b[34m",
+	"magenta": "\x1b[35m",
+	"cyan":    "\x1b[36m",
+	"white":   "\x1b[37m",
+}
+
+const (
+	// noColor is the code to reset the color to the default. It is as long
+	// as the codes of colors, so that headers are as wide as colored cells.
+	noColor    = "\x1b[39m"
+	resetColor = "\x1b[0m"
+)
+
+// colorDisabled returns whether colors are disabled using the NO_COLOR
+// environment variable (see https://no-color.org).
+func colorDisabled() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// toFloat returns v as a float64, if it is a number or a string holding a
+// number.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// toBytes returns the number of bytes of v, which is either a number, or a
+// human-readable size such as "1.5GB" or "512MiB".
+func toBytes(v interface{}) (int64, bool) {
+	if f, ok := toFloat(v); ok {
+		return int64(f), true
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, false
+	}
+	s = strings.TrimSpace(s)
+	parse := units.FromHumanSize
+	if strings.Contains(strings.ToLower(s), "ib") {
+		parse = units.RAMInBytes
+	}
+	n, err := parse(s)
+	return n, err == nil
+}
+
+// humanSize renders a size in bytes, or a human-readable size, as a
+// human-readable size (e.g. "1.5GB"). Other values are returned unchanged.
+func humanSize(v interface{}) interface{} {
+	n, ok := toBytes(v)
+	if !ok {
+		return v
+	}
+	return units.HumanSizeWithPrecision(float64(n), 3)
+}
+
+// byteCount returns the number of bytes of a human-readable size, such as
+// "1.5GB". Other values are returned unchanged.
+func byteCount(v interface{}) interface{} {
+	n, ok := toBytes(v)
+	if !ok {
+		return v
+	}
+	return n
+}
+
+// toTime returns v as a time, if it is a time.Time, a Unix timestamp, or a
+// string holding a time in one of the timeLayouts.
+func toTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+		return time.Time{}, false
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, true
+			}
+		}
+	}
+	if f, ok := toFloat(v); ok {
+		return time.Unix(int64(f), 0), true
+	}
+	return time.Time{}, false
+}
+
+// since returns the time elapsed since v (e.g. "2 hours"). Values that are
+// not times are returned unchanged.
+func since(v interface{}) interface{} {
+	t, ok := toTime(v)
+	if !ok || t.IsZero() {
+		return v
+	}
+	return units.HumanDuration(now().Sub(t))
+}
+
+// ago returns the time elapsed since v, followed by "ago" (e.g. "2 hours
+// ago"). Values that are not times are returned unchanged.
+func ago(v interface{}) interface{} {
+	t, ok := toTime(v)
+	if !ok || t.IsZero() {
+		return v
+	}
+	return units.HumanDuration(now().Sub(t)) + " ago"
+}
+
+// defaultValue returns v, or def if v is empty (nil, false, 0, or an empty
+// string, slice, or map).
+func defaultValue(def, v interface{}) interface{} {
+	if v == nil {
+		return def
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if rv.Len() == 0 {
+			return def
+		}
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return def
+		}
+	default:
+		if rv.IsZero() {
+			return def
+		}
+	}
+	return v
+}
+
+// regexMatch returns whether s matches the regular expression pattern.
+func regexMatch(pattern string, s string) (bool, error) {
+	return regexp.MatchString(pattern, s)
+}
+
+// regexReplace replaces the matches of the regular expression pattern in s
+// with replacement, which can refer to submatches (e.g. "${1}").
+func regexReplace(pattern, replacement, s string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", err
+	}
+	return re.ReplaceAllString(s, replacement), nil
+}
+
+// toYAML renders v as YAML, with the same fields as the json function.
+func toYAML(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value interface{}
+	if err := dec.Decode(&value); err != nil {
+		return "", err
+	}
+	out, err := yaml.Marshal(yamlNumbers(value))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// yamlNumbers converts the json.Numbers in v to int64 or float64, so that
+// integers are not rendered in exponent notation.
+func yamlNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = yamlNumbers(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = yamlNumbers(e)
+		}
+	}
+	return v
+}
+
+// sortBy returns a copy of the slice or array collection, sorted by the
+// given field of its elements, which can be the name of a field or method of
+// a struct, or a key of a map. If field is empty, the elements themselves
+// are compared. Numbers and times are compared by value, and other values
+// using a natural sort order.
+func sortBy(field string, collection interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(collection)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errors.Errorf("sortBy: cannot sort %T", collection)
+	}
+	sorted := reflect.MakeSlice(reflect.SliceOf(rv.Type().Elem()), rv.Len(), rv.Len())
+	reflect.Copy(sorted, rv)
+
+	keys := make([]interface{}, sorted.Len())
+	for i := range keys {
+		key, err := fieldOf(sorted.Index(i), field)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	swap := reflect.Swapper(sorted.Interface())
+	sort.Stable(&sorter{keys: keys, swap: swap})
+	return sorted.Interface(), nil
+}
+
+type sorter struct {
+	keys []interface{}
+	swap func(i, j int)
+}
+
+func (s *sorter) Len() int {
+	return len(s.keys)
+}
+
+func (s *sorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.swap(i, j)
+}
+
+func (s *sorter) Less(i, j int) bool {
+	a, b := s.keys[i], s.keys[j]
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return x < y
+		}
+	}
+	if x, ok := a.(time.Time); ok {
+		if y, ok := b.(time.Time); ok {
+			return x.Before(y)
+		}
+	}
+	return sortorder.NaturalLess(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// fieldOf returns the given field of v, which is the name of a field or
+// method of a struct, or a key of a map.
+func fieldOf(v reflect.Value, field string) (interface{}, error) {
+	if field == "" {
+		return v.Interface(), nil
+	}
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if m := v.MethodByName(field); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+		return m.Call(nil)[0].Interface(), nil
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if f := v.FieldByName(field); f.IsValid() && f.CanInterface() {
+			return f.Interface(), nil
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			if e := v.MapIndex(reflect.ValueOf(field).Convert(v.Type().Key())); e.IsValid() {
+				return e.Interface(), nil
+			}
+			return nil, nil
+		}
+	}
+	return nil, errors.Errorf("sortBy: %s has no field %q", v.Type(), field)
+}
+
+// indexOr returns the element of collection at key, like the index function,
+// or fallback if collection is nil, does not have the key, or the element
+// is empty.
+func indexOr(collection, key, fallback interface{}) interface{} {
+	rv := reflect.ValueOf(collection)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return fallback
+		}
+		rv = rv.Elem()
+	}
+	var e reflect.Value
+	switch rv.Kind() {
+	case reflect.Map:
+		k := reflect.ValueOf(key)
+		if !k.IsValid() || !k.Type().ConvertibleTo(rv.Type().Key()) {
+			return fallback
+		}
+		e = rv.MapIndex(k.Convert(rv.Type().Key()))
+	case reflect.Slice, reflect.Array, reflect.String:
+		i, ok := toFloat(key)
+		if !ok || i < 0 || int(i) >= rv.Len() {
+			return fallback
+		}
+		e = rv.Index(int(i))
+	default:
+		return fallback
+	}
+	if !e.IsValid() {
+		return fallback
+	}
+	return defaultValue(fallback, e.Interface())
+}
+
+// color renders s in the given color (black, red, green, yellow, blue,
+// magenta, cyan, or white), unless colors are disabled using the NO_COLOR
+// environment variable.
+func color(name string, s interface{}) (string, error) {
+	if colorDisabled() {
+		return plainColor(name, s)
+	}
+	code, ok := colors[name]
+	if !ok {
+		return "", errors.Errorf("color: unknown color %q", name)
+	}
+	return code + fmt.Sprint(s) + resetColor, nil
+}
+
+// plainColor renders s without escape codes. The color is still checked, so
+// that a format fails the same whether it is rendered in color or not.
+func plainColor(name string, s interface{}) (string, error) {
+	if _, ok := colors[name]; !ok {
+		return "", errors.Errorf("color: unknown color %q", name)
+	}
+	return fmt.Sprint(s), nil
+}
+
+// headerColor renders a table header in the default color. The header is
+// wrapped in escape codes as long as those of colored cells, so that the
+// columns of tables stay aligned.
+func headerColor(_ string, s string) string {
+	if colorDisabled() {
+		return s
+	}
+	return noColor + s + resetColor
+}
@@ -0,0 +1,234 @@
+package templates
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func execute(t *testing.T, format string, data interface{}) string {
+	t.Helper()
+	tm, err := Parse(format)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	assert.NilError(t, tm.Execute(&b, data))
+	return b.String()
+}
+
+func TestSizeFunctions(t *testing.T) {
+	testCases := []struct {
+		template string
+		data     interface{}
+		expected string
+	}{
+		{template: `{{humanSize .}}`, data: int64(1500000000), expected: "1.5GB"},
+		{template: `{{humanSize .}}`, data: uint64(2048), expected: "2.05kB"},
+		{template: `{{humanSize .}}`, data: "1536MiB", expected: "1.61GB"},
+		{template: `{{humanSize .}}`, data: "N/A", expected: "N/A"},
+		{template: `{{bytes .}}`, data: "1.5GB", expected: "1500000000"},
+		{template: `{{bytes .}}`, data: "2KiB", expected: "2048"},
+		{template: `{{bytes .}}`, data: "0B", expected: "0"},
+		{template: `{{bytes .}}`, data: "N/A", expected: "N/A"},
+		{template: `{{if gt (bytes .) 1000000}}large{{end}}`, data: "12.3MB", expected: "large"},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(execute(t, tc.template, tc.data), tc.expected), tc.template)
+	}
+}
+
+func TestTimeFunctions(t *testing.T) {
+	reference := time.Date(2023, 1, 2, 15, 4, 5, 0, time.UTC)
+	now = func() time.Time { return reference }
+	defer func() { now = time.Now }()
+
+	testCases := []struct {
+		template string
+		data     interface{}
+		expected string
+	}{
+		{template: `{{since .}}`, data: reference.Add(-2 * time.Hour), expected: "2 hours"},
+		{template: `{{ago .}}`, data: reference.Add(-3 * 24 * time.Hour), expected: "3 days ago"},
+		{template: `{{ago .}}`, data: "2023-01-02 14:04:05 +0000 UTC", expected: "About an hour ago"},
+		{template: `{{ago .}}`, data: "2023-01-02T15:03:05Z", expected: "About a minute ago"},
+		{template: `{{ago .}}`, data: reference.Add(-30 * time.Second).Unix(), expected: "30 seconds ago"},
+		{template: `{{ago .}}`, data: "N/A", expected: "N/A"},
+		{template: `{{ago .}}`, data: time.Time{}, expected: "0001-01-01 00:00:00 +0000 UTC"},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(execute(t, tc.template, tc.data), tc.expected), tc.template)
+	}
+}
+
+func TestDefaultFunction(t *testing.T) {
+	testCases := []struct {
+		data     interface{}
+		expected string
+	}{
+		{data: map[string]interface{}{"Value": "value"}, expected: "value"},
+		{data: map[string]interface{}{"Value": ""}, expected: "none"},
+		{data: map[string]interface{}{"Value": 0}, expected: "none"},
+		{data: map[string]interface{}{"Value": false}, expected: "none"},
+		{data: map[string]interface{}{"Value": []string{}}, expected: "none"},
+		{data: map[string]interface{}{"Value": nil}, expected: "none"},
+		{data: map[string]interface{}{}, expected: "none"},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(execute(t, `{{.Value | default "none"}}`, tc.data), tc.expected))
+	}
+}
+
+func TestRegexFunctions(t *testing.T) {
+	assert.Check(t, is.Equal(execute(t, `{{if regexMatch "^web-[0-9]+$" .}}match{{end}}`, "web-10"), "match"))
+	assert.Check(t, is.Equal(execute(t, `{{if regexMatch "^web-[0-9]+$" .}}match{{end}}`, "db-1"), ""))
+	assert.Check(t, is.Equal(execute(t, `{{regexReplace ":[0-9]+$" "" .}}`, "registry.example.com:5000"), "registry.example.com"))
+	assert.Check(t, is.Equal(execute(t, `{{. | regexReplace "^(\\w+)/(\\w+)$" "${2}/${1}"}}`, "foo/bar"), "bar/foo"))
+
+	tm, err := Parse(`{{regexMatch "(" .}}`)
+	assert.NilError(t, err)
+	assert.Check(t, is.ErrorContains(tm.Execute(&bytes.Buffer{}, "foo"), "missing closing )"))
+}
+
+func TestToYamlFunction(t *testing.T) {
+	data := struct {
+		Name   string
+		Size   int64
+		Labels map[string]string
+		Ports  []string
+	}{
+		Name:   "foo",
+		Size:   12345678901,
+		Labels: map[string]string{"com.example": "value"},
+		Ports:  []string{"80/tcp"},
+	}
+	expected := `Labels:
+  com.example: value
+Name: foo
+Ports:
+- 80/tcp
+Size: 12345678901`
+	assert.Check(t, is.Equal(execute(t, `{{toYaml .}}`, data), expected))
+}
+
+type sortable struct {
+	Name string
+	Size int
+}
+
+func (s sortable) Upper() string {
+	return "X" + s.Name
+}
+
+func TestSortByFunction(t *testing.T) {
+	data := []sortable{{Name: "web-10", Size: 1}, {Name: "web-2", Size: 30}, {Name: "db", Size: 200}}
+	testCases := []struct {
+		template string
+		data     interface{}
+		expected string
+	}{
+		{template: `{{range sortBy "Name" .}}{{.Name}} {{end}}`, data: data, expected: "db web-2 web-10 "},
+		{template: `{{range sortBy "Size" .}}{{.Name}} {{end}}`, data: data, expected: "web-10 web-2 db "},
+		{template: `{{range sortBy "Upper" .}}{{.Name}} {{end}}`, data: data, expected: "db web-2 web-10 "},
+		{template: `{{range sortBy "" .}}{{.}} {{end}}`, data: []string{"b", "c", "a"}, expected: "a b c "},
+		{
+			template: `{{range sortBy "Name" .}}{{.Name}} {{end}}`,
+			data:     []map[string]string{{"Name": "b"}, {"Name": "a"}},
+			expected: "a b ",
+		},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(execute(t, tc.template, tc.data), tc.expected), tc.template)
+	}
+	// the collection itself is not sorted
+	assert.Check(t, is.Equal(data[0].Name, "web-10"))
+
+	tm, err := Parse(`{{sortBy "Nope" .}}`)
+	assert.NilError(t, err)
+	assert.Check(t, is.ErrorContains(tm.Execute(&bytes.Buffer{}, data), `has no field "Nope"`))
+}
+
+func TestIndexOrFunction(t *testing.T) {
+	data := map[string]interface{}{
+		"Labels": map[string]string{"com.example": "value", "empty": ""},
+		"Ports":  []string{"80/tcp"},
+	}
+	testCases := []struct {
+		template string
+		expected string
+	}{
+		{template: `{{indexOr .Labels "com.example" "none"}}`, expected: "value"},
+		{template: `{{indexOr .Labels "missing" "none"}}`, expected: "none"},
+		{template: `{{indexOr .Labels "empty" "none"}}`, expected: "none"},
+		{template: `{{indexOr .Ports 0 "none"}}`, expected: "80/tcp"},
+		{template: `{{indexOr .Ports 1 "none"}}`, expected: "none"},
+		{template: `{{indexOr .Missing "key" "none"}}`, expected: "none"},
+	}
+	for _, tc := range testCases {
+		assert.Check(t, is.Equal(execute(t, tc.template, data), tc.expected), tc.template)
+	}
+}
+
+func TestColorFunction(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	assert.Check(t, is.Equal(execute(t, `{{color "red" .}}`, "exited"), "\x1b[31mexited\x1b[0m"))
+	assert.Check(t, is.Equal(execute(t, `{{. | color "green"}}`, "running"), "\x1b[32mrunning\x1b[0m"))
+
+	tm, err := Parse(`{{color "purple" .}}`)
+	assert.NilError(t, err)
+	assert.Check(t, is.ErrorContains(tm.Execute(&bytes.Buffer{}, "foo"), `unknown color "purple"`))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Check(t, is.Equal(execute(t, `{{color "red" .}}`, "exited"), "exited"))
+}
+
+func TestHeaderFunctions(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	const format = `{{humanSize .Size}}|{{bytes .Size}}|{{since .CreatedAt}}|{{ago .CreatedAt}}|` +
+		`{{.Labels | default "none"}}|{{if regexMatch "^web" .Names}}{{.Names}}{{end}}|` +
+		`{{regexReplace ":.*" "" .Image}}|{{toYaml .Labels}}|{{sortBy "Name" .Mounts}}|` +
+		`{{indexOr .Labels "key" "none"}}|{{color "red" .Status}}`
+	header := map[string]string{
+		"Size":      "SIZE",
+		"CreatedAt": "CREATED",
+		"Labels":    "LABELS",
+		"Names":     "NAMES",
+		"Image":     "IMAGE",
+		"Mounts":    "MOUNTS",
+		"Status":    "STATUS",
+	}
+	tm, err := Parse(format)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	assert.NilError(t, tm.Funcs(HeaderFunctions).Execute(&b, header))
+	assert.Check(t, is.Equal(b.String(), "SIZE|SIZE|CREATED|CREATED|LABELS|NAMES|IMAGE|LABELS|MOUNTS|LABELS|STATUS"))
+}
+
+func TestHeaderColorFunction(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	tm, err := Parse(`{{color "red" .Status}}`)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	assert.NilError(t, tm.Funcs(HeaderFunctions).Execute(&b, map[string]string{"Status": "STATUS"}))
+	// the header is as wide as colored cells, so that columns are aligned
+	assert.Check(t, is.Equal(b.String(), "\x1b[39mSTATUS\x1b[0m"))
+	assert.Check(t, is.Len(b.String(), len(execute(t, `{{color "red" .}}`, "STATUS"))))
+}
+
+func TestNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	tm, err := Parse(`{{color "red" .Status}}`)
+	assert.NilError(t, err)
+	var b bytes.Buffer
+	assert.NilError(t, NoColor(tm).Execute(&b, map[string]string{"Status": "exited"}))
+	assert.Check(t, is.Equal(b.String(), "exited"))
+
+	b.Reset()
+	assert.NilError(t, NoColor(tm.Funcs(HeaderFunctions)).Execute(&b, map[string]string{"Status": "STATUS"}))
+	assert.Check(t, is.Equal(b.String(), "STATUS"))
+
+	tm, err = Parse(`{{color "purple" .}}`)
+	assert.NilError(t, err)
+	assert.Check(t, is.ErrorContains(NoColor(tm).Execute(&bytes.Buffer{}, "foo"), `unknown color "purple"`))
+}
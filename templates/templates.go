@@ -25,6 +25,18 @@ var basicFunctions = template.FuncMap{
 	"upper":    strings.ToUpper,
 	"pad":      padWithSpace,
 	"truncate": truncateWithLength,
+
+	"humanSize":    humanSize,
+	"bytes":        byteCount,
+	"since":        since,
+	"ago":          ago,
+	"default":      defaultValue,
+	"regexMatch":   regexMatch,
+	"regexReplace": regexReplace,
+	"toYaml":       toYAML,
+	"sortBy":       sortBy,
+	"indexOr":      indexOr,
+	"color":        color,
 }
 
 // HeaderFunctions are used to created headers of a table.
@@ -59,6 +71,48 @@ var HeaderFunctions = template.FuncMap{
 	"truncate": func(v string, _ int) string {
 		return v
 	},
+	"humanSize": func(v string) string {
+		return v
+	},
+	"bytes": func(v string) string {
+		return v
+	},
+	"since": func(v string) string {
+		return v
+	},
+	"ago": func(v string) string {
+		return v
+	},
+	"default": func(_ interface{}, v string) string {
+		return v
+	},
+	"regexMatch": func(_ string, _ string) bool {
+		// conditional columns are always shown in the header row, as
+		// their header does not depend on the values of the rows
+		return true
+	},
+	"regexReplace": func(_ string, _ string, v string) string {
+		return v
+	},
+	"toYaml": func(v string) string {
+		return v
+	},
+	"sortBy": func(_ string, v string) string {
+		// table headers are always a string, so use a different signature
+		// for the "sortBy" function (string instead of a collection)
+		return v
+	},
+	"indexOr": func(v string, _ interface{}, _ interface{}) string {
+		return v
+	},
+	"color": headerColor,
+}
+
+// NoColor makes tmpl render values passed to the "color" function without
+// escape codes, for output that is not a terminal. It must be called after
+// adding functions that override "color", such as HeaderFunctions.
+func NoColor(tmpl *template.Template) *template.Template {
+	return tmpl.Funcs(template.FuncMap{"color": plainColor})
 }
 
 // Parse creates a new anonymous template with the basic functions